@@ -4,8 +4,11 @@ import (
 	"flag"
 
 	"github.com/interline-io/transitland-lib/copier"
+	"github.com/interline-io/transitland-lib/dmfr"
 	"github.com/interline-io/transitland-lib/ext"
 	"github.com/interline-io/transitland-lib/internal/log"
+	"github.com/interline-io/transitland-lib/tl"
+	"github.com/interline-io/transitland-lib/tlcsv"
 	"github.com/interline-io/transitland-lib/tldb"
 )
 
@@ -17,6 +20,32 @@ type basicCopyOptions struct {
 	allowReferenceErrors bool
 	extensions           arrayFlags
 	filters              arrayFlags
+	rulesFile            string
+	tripWorkers          int
+	diskCache            bool
+	progress             bool
+	upsert               bool
+	writeExtraColumns    bool
+}
+
+// setProgress prints progress while copying, with estimated totals if the Reader is a tlcsv.Reader.
+func (opts *basicCopyOptions) setProgress(cp *copier.Copier, reader tl.Reader) {
+	if !opts.progress {
+		return
+	}
+	if v, ok := reader.(*tlcsv.Reader); ok {
+		fvfis, err := dmfr.NewFeedVersionFileInfosFromReader(v)
+		if err != nil {
+			log.Exit("Could not count rows: %s", err)
+		}
+		cp.EstimatedRows = map[string]int{}
+		for _, fvfi := range fvfis {
+			cp.EstimatedRows[fvfi.Name] = int(fvfi.Rows)
+		}
+	}
+	cp.ProgressHandler = copier.ProgressFunc(func(p copier.Progress) {
+		log.Print("%s", p)
+	})
 }
 
 // copyCommand
@@ -35,6 +64,12 @@ func (cmd *copyCommand) Run(args []string) error {
 	fl.Var(&cmd.extensions, "ext", "Include GTFS Extension")
 	fl.IntVar(&cmd.fvid, "fvid", 0, "Specify FeedVersionID when writing to a database")
 	fl.BoolVar(&cmd.create, "create", false, "Create a basic database schema if none exists")
+	fl.StringVar(&cmd.rulesFile, "rules", "", "JSON file with rules to change the severity of errors and warnings")
+	fl.IntVar(&cmd.tripWorkers, "trip-workers", 1, "Number of workers used to validate and interpolate trips and stop_times")
+	fl.BoolVar(&cmd.diskCache, "disk-cache", false, "Cache trips, shapes, and stop patterns in a temporary file instead of memory")
+	fl.BoolVar(&cmd.progress, "progress", false, "Print progress while copying")
+	fl.BoolVar(&cmd.upsert, "upsert", false, "Update existing entities with the same ID when writing to a database, instead of inserting duplicates")
+	fl.BoolVar(&cmd.writeExtraColumns, "write-extra-columns", false, "Include extra columns that are not part of the GTFS specification when writing GTFS files")
 	fl.Parse(args)
 	if fl.NArg() < 2 {
 		fl.Usage()
@@ -49,6 +84,17 @@ func (cmd *copyCommand) Run(args []string) error {
 	cp := copier.NewCopier(reader, writer)
 	cp.AllowEntityErrors = cmd.allowEntityErrors
 	cp.AllowReferenceErrors = cmd.allowReferenceErrors
+	cp.ErrorLimit = 0 // only counts are displayed
+	cp.Workers = cmd.tripWorkers
+	cp.DiskCache = cmd.diskCache
+	cmd.setProgress(&cp, reader)
+	if cmd.rulesFile != "" {
+		rules, err := copier.LoadRuleSet(cmd.rulesFile)
+		if err != nil {
+			log.Exit("Could not load rules: %s", err)
+		}
+		cp.Rules = rules
+	}
 	if dbw, ok := writer.(*tldb.Writer); ok {
 		if cmd.fvid != 0 {
 			dbw.FeedVersionID = cmd.fvid
@@ -59,8 +105,12 @@ func (cmd *copyCommand) Run(args []string) error {
 			}
 			dbw.FeedVersionID = fvid
 		}
+		dbw.Upsert = cmd.upsert
 		cp.NormalizeServiceIDs = true
 	}
+	if csvw, ok := writer.(*tlcsv.Writer); ok {
+		csvw.WriteExtraColumns = cmd.writeExtraColumns
+	}
 	for _, extName := range cmd.extensions {
 		e, err := ext.GetExtension(extName)
 		if err != nil {
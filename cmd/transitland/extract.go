@@ -9,6 +9,7 @@ import (
 	"github.com/interline-io/transitland-lib/ext"
 	"github.com/interline-io/transitland-lib/extract"
 	"github.com/interline-io/transitland-lib/internal/log"
+	"github.com/interline-io/transitland-lib/tlcsv"
 	"github.com/interline-io/transitland-lib/tldb"
 )
 
@@ -42,6 +43,12 @@ func (cmd *extractCommand) Run(args []string) error {
 	fl.Var(&cmd.extensions, "ext", "Include GTFS Extension")
 	fl.IntVar(&cmd.fvid, "fvid", 0, "Specify FeedVersionID when writing to a database")
 	fl.BoolVar(&cmd.create, "create", false, "Create a basic database schema if none exists")
+	fl.StringVar(&cmd.rulesFile, "rules", "", "JSON file with rules to change the severity of errors and warnings")
+	fl.IntVar(&cmd.tripWorkers, "trip-workers", 1, "Number of workers used to validate and interpolate trips and stop_times")
+	fl.BoolVar(&cmd.diskCache, "disk-cache", false, "Cache trips, shapes, and stop patterns in a temporary file instead of memory")
+	fl.BoolVar(&cmd.progress, "progress", false, "Print progress while copying")
+	fl.BoolVar(&cmd.upsert, "upsert", false, "Update existing entities with the same ID when writing to a database, instead of inserting duplicates")
+	fl.BoolVar(&cmd.writeExtraColumns, "write-extra-columns", false, "Include extra columns that are not part of the GTFS specification when writing GTFS files")
 	// Extract options
 	fl.BoolVar(&cmd.interpolateStopTimes, "interpolate-stop-times", false, "Interpolate missing StopTime arrival/departure values")
 	fl.BoolVar(&cmd.createMissingShapes, "create-missing-shapes", false, "Create missing Shapes from Trip stop-to-stop geometries")
@@ -71,6 +78,17 @@ func (cmd *extractCommand) Run(args []string) error {
 	cp := copier.NewCopier(reader, writer)
 	cp.AllowEntityErrors = cmd.allowEntityErrors
 	cp.AllowReferenceErrors = cmd.allowReferenceErrors
+	cp.ErrorLimit = 0 // only counts are displayed
+	cp.Workers = cmd.tripWorkers
+	cp.DiskCache = cmd.diskCache
+	cmd.setProgress(&cp, reader)
+	if cmd.rulesFile != "" {
+		rules, err := copier.LoadRuleSet(cmd.rulesFile)
+		if err != nil {
+			log.Exit("Could not load rules: %s", err)
+		}
+		cp.Rules = rules
+	}
 	cp.UseBasicRouteTypes = cmd.useBasicRouteTypes
 	cp.InterpolateStopTimes = cmd.interpolateStopTimes
 	cp.CreateMissingShapes = cmd.createMissingShapes
@@ -85,8 +103,12 @@ func (cmd *extractCommand) Run(args []string) error {
 			}
 			dbw.FeedVersionID = fvid
 		}
+		dbw.Upsert = cmd.upsert
 		cp.NormalizeServiceIDs = true
 	}
+	if csvw, ok := writer.(*tlcsv.Writer); ok {
+		csvw.WriteExtraColumns = cmd.writeExtraColumns
+	}
 	for _, extName := range cmd.extensions {
 		e, err := ext.GetExtension(extName)
 		if err != nil {
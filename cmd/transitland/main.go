@@ -92,6 +92,7 @@ func main() {
 		log.Print("Commands:")
 		log.Print("  copy")
 		log.Print("  extract")
+		log.Print("  merge")
 		log.Print("  validate")
 		log.Print("  dmfr")
 		return
@@ -130,6 +131,8 @@ func main() {
 		r = &validateCommand{}
 	case "extract":
 		r = &extractCommand{}
+	case "merge":
+		r = &mergeCommand{}
 	case "dmfr":
 		r = &dmfr.Command{}
 	default:
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/interline-io/transitland-lib/internal/log"
+	"github.com/interline-io/transitland-lib/merge"
+	"github.com/interline-io/transitland-lib/tl"
+	"github.com/interline-io/transitland-lib/tlcsv"
+	"github.com/interline-io/transitland-lib/tldb"
+)
+
+// mergeCommand
+type mergeCommand struct {
+	dburl             string
+	cutoff            string
+	prefix            string
+	writeExtraColumns bool
+}
+
+func (cmd *mergeCommand) Run(args []string) error {
+	fl := flag.NewFlagSet("merge", flag.ExitOnError)
+	fl.Usage = func() {
+		log.Print("Usage: merge <older> <newer> <output>")
+		fl.PrintDefaults()
+	}
+	fl.StringVar(&cmd.cutoff, "cutoff", "", "First day of service from the newer feed version, YYYY-MM-DD (default: first day of service in the newer feed version)")
+	fl.StringVar(&cmd.prefix, "prefix", "old", "Namespace for IDs in the older feed version that are also used by the newer feed version")
+	fl.StringVar(&cmd.dburl, "dburl", "", "Database URL for inputs specified as a feed version ID (default: $DMFR_DATABASE_URL)")
+	fl.BoolVar(&cmd.writeExtraColumns, "write-extra-columns", false, "Include extra columns that are not part of the GTFS specification when writing GTFS files")
+	fl.Parse(args)
+	if fl.NArg() < 3 {
+		fl.Usage()
+		log.Exit("Requires older and newer feed versions and output path")
+	}
+	if cmd.dburl == "" {
+		cmd.dburl = os.Getenv("DMFR_DATABASE_URL")
+	}
+	opts := merge.Options{Prefix: cmd.prefix}
+	if cmd.cutoff != "" {
+		var err error
+		if opts.Cutoff, err = time.Parse("2006-01-02", cmd.cutoff); err != nil {
+			return err
+		}
+	}
+	older := cmd.mustGetReader(fl.Arg(0))
+	defer older.Close()
+	newer := cmd.mustGetReader(fl.Arg(1))
+	defer newer.Close()
+	writer, err := tlcsv.NewWriter(fl.Arg(2))
+	if err != nil {
+		return err
+	}
+	if err := writer.Open(); err != nil {
+		return err
+	}
+	defer writer.Close()
+	writer.WriteExtraColumns = cmd.writeExtraColumns
+	result, err := merge.MergeReaders(context.Background(), older, newer, writer, opts)
+	if err != nil {
+		return err
+	}
+	log.Print("Service from the newer feed version starts on %s", result.Cutoff.Format("2006-01-02"))
+	log.Print("Older feed version:")
+	result.Older.DisplaySummary()
+	log.Print("Newer feed version:")
+	result.Newer.DisplaySummary()
+	return nil
+}
+
+// mustGetReader opens a GTFS path or URL, or a feed version in the database if the input is a feed version ID.
+func (cmd *mergeCommand) mustGetReader(input string) tl.Reader {
+	fvid, err := strconv.Atoi(input)
+	if err != nil {
+		return MustGetReader(input)
+	}
+	if cmd.dburl == "" {
+		log.Exit("Feed version %d requires -dburl", fvid)
+	}
+	reader, err := tldb.NewReader(cmd.dburl)
+	if err != nil {
+		log.Exit("Could not open database: %s", err)
+	}
+	reader.FeedVersionIDs = []int{fvid}
+	if err := reader.Open(); err != nil {
+		log.Exit("Could not open database: %s", err)
+	}
+	fv := tl.FeedVersion{}
+	fv.ID = fvid
+	if err := reader.Adapter.Find(&fv); err != nil {
+		log.Exit("Could not find feed version %d: %s", fvid, err)
+	}
+	return reader
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"bufio"
 	"flag"
 	"fmt"
+	"os"
 
+	"github.com/interline-io/transitland-lib/copier"
 	"github.com/interline-io/transitland-lib/ext"
 	"github.com/interline-io/transitland-lib/internal/log"
 	"github.com/interline-io/transitland-lib/validator"
@@ -12,17 +15,26 @@ import (
 // validateCommand
 type validateCommand struct {
 	validateExtensions arrayFlags
+	outputFile         string
+	htmlFile           string
+	rulesFile          string
+	errorsFile         string
+	errorLimit         int
 }
 
-func (cmd *validateCommand) Run(args []string) error {
+func (cmd *validateCommand) Run(args []string) (err error) {
 	fl := flag.NewFlagSet("validate", flag.ExitOnError)
 	fl.Usage = func() {
 		log.Print("Usage: validate <reader>")
 		fl.PrintDefaults()
 	}
 	fl.Var(&cmd.validateExtensions, "ext", "Include GTFS Extension")
-	err := fl.Parse(args)
-	if err != nil || fl.NArg() < 1 {
+	fl.StringVar(&cmd.outputFile, "o", "", "Write validation report as JSON to this file")
+	fl.StringVar(&cmd.htmlFile, "html", "", "Write validation report as HTML to this file")
+	fl.StringVar(&cmd.rulesFile, "rules", "", "JSON file with rules to change the severity of errors and warnings")
+	fl.StringVar(&cmd.errorsFile, "errors-jsonl", "", "Write every error and warning as a line of JSON to this file")
+	fl.IntVar(&cmd.errorLimit, "error-limit", validator.DefaultErrorLimit, "Maximum number of example errors for each error type; 0 for no limit")
+	if err := fl.Parse(args); err != nil || fl.NArg() < 1 {
 		fl.Usage()
 		log.Exit("Requires input reader")
 	}
@@ -33,6 +45,13 @@ func (cmd *validateCommand) Run(args []string) error {
 	if err != nil {
 		return err
 	}
+	if cmd.rulesFile != "" {
+		rules, err := copier.LoadRuleSet(cmd.rulesFile)
+		if err != nil {
+			return err
+		}
+		v.Copier.Rules = rules
+	}
 	for _, extName := range cmd.validateExtensions {
 		e, err := ext.GetExtension(extName)
 		if err != nil {
@@ -40,6 +59,55 @@ func (cmd *validateCommand) Run(args []string) error {
 		}
 		v.Copier.AddExtension(e)
 	}
-	v.Validate()
+	if cmd.errorsFile != "" {
+		f, err := os.Create(cmd.errorsFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w := bufio.NewWriter(f)
+		defer func() {
+			if ferr := w.Flush(); ferr != nil && err == nil {
+				err = ferr
+			}
+		}()
+		eh := validator.NewJSONLErrorHandler(w, v.Copier.ErrorHandler)
+		v.Copier.ErrorHandler = eh
+		defer func() {
+			if err := eh.Err(); err != nil {
+				log.Error("Could not write errors: %s", err)
+			}
+		}()
+	}
+	v.ErrorLimit = cmd.errorLimit
+	if cmd.outputFile == "" && cmd.htmlFile == "" {
+		v.Validate()
+		return nil
+	}
+	result := v.ValidateResult()
+	if cmd.outputFile != "" {
+		f, err := os.Create(cmd.outputFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if err := result.WriteJSON(f); err != nil {
+			return err
+		}
+	}
+	if cmd.htmlFile != "" {
+		report, err := validator.NewHTMLReport(reader, result)
+		if err != nil {
+			return err
+		}
+		f, err := os.Create(cmd.htmlFile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if err := report.WriteHTML(f); err != nil {
+			return err
+		}
+	}
 	return nil
 }
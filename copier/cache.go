@@ -0,0 +1,122 @@
+package copier
+
+import (
+	"sync"
+
+	"github.com/interline-io/transitland-lib/tl"
+)
+
+// copierCache stores Trips, shape geometries, and stop patterns while copying.
+// Implementations must be safe for concurrent use: the trip producer and the stop time workers share one cache.
+type copierCache interface {
+	// AddTripID records a trip_id that is present in the source, even if the Trip is not copied.
+	AddTripID(string) error
+	HasTripID(string) (bool, error)
+	// AddTrip stores a Trip by EntityID until it is deleted.
+	AddTrip(tl.Trip) error
+	GetTrip(string) (tl.Trip, bool, error)
+	DeleteTrip(string) error
+	// EachTrip calls the function for each stored Trip.
+	EachTrip(func(tl.Trip) error) error
+	// StopPattern returns the ID for a stop pattern key, assigning the next ID if it is new.
+	StopPattern(string) (int, error)
+	AddShape(string, [][2]float64) error
+	GetShape(string) ([][2]float64, error)
+	Close() error
+}
+
+// memoryCache is a copierCache that keeps everything in memory.
+type memoryCache struct {
+	tripIDs  map[string]int
+	trips    map[string]tl.Trip
+	patterns map[string]int
+	shapes   map[string][][2]float64
+	lock     sync.Mutex
+}
+
+func newMemoryCache() *memoryCache {
+	return &memoryCache{
+		tripIDs:  map[string]int{},
+		trips:    map[string]tl.Trip{},
+		patterns: map[string]int{},
+		shapes:   map[string][][2]float64{},
+	}
+}
+
+func (c *memoryCache) AddTripID(eid string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.tripIDs[eid]++
+	return nil
+}
+
+func (c *memoryCache) HasTripID(eid string) (bool, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	_, ok := c.tripIDs[eid]
+	return ok, nil
+}
+
+func (c *memoryCache) AddTrip(trip tl.Trip) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.trips[trip.EntityID()] = trip
+	return nil
+}
+
+func (c *memoryCache) GetTrip(eid string) (tl.Trip, bool, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	trip, ok := c.trips[eid]
+	return trip, ok, nil
+}
+
+func (c *memoryCache) DeleteTrip(eid string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.trips, eid)
+	return nil
+}
+
+func (c *memoryCache) EachTrip(cb func(tl.Trip) error) error {
+	c.lock.Lock()
+	trips := make([]tl.Trip, 0, len(c.trips))
+	for _, trip := range c.trips {
+		trips = append(trips, trip)
+	}
+	c.lock.Unlock()
+	for _, trip := range trips {
+		if err := cb(trip); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *memoryCache) StopPattern(key string) (int, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if pat, ok := c.patterns[key]; ok {
+		return pat, nil
+	}
+	pat := len(c.patterns)
+	c.patterns[key] = pat
+	return pat, nil
+}
+
+func (c *memoryCache) AddShape(eid string, coords [][2]float64) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.shapes[eid] = coords
+	return nil
+}
+
+func (c *memoryCache) GetShape(eid string) ([][2]float64, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.shapes[eid], nil
+}
+
+func (c *memoryCache) Close() error {
+	return nil
+}
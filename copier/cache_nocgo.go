@@ -0,0 +1,10 @@
+// +build !cgo
+
+package copier
+
+import "errors"
+
+// newDiskCache is not available without cgo.
+func newDiskCache(dir string) (copierCache, error) {
+	return nil, errors.New("disk cache requires SQLite support, which is not available in this build")
+}
@@ -0,0 +1,346 @@
+// +build cgo
+
+package copier
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/binary"
+	"encoding/gob"
+	"io/ioutil"
+	"math"
+	"os"
+	"sync"
+
+	"github.com/interline-io/transitland-lib/tl"
+
+	// sqlite3
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// diskCache is a copierCache backed by a temporary SQLite database.
+// Trips with load errors or warnings are kept in memory, since errors cannot be serialized.
+// Writes are collected in a transaction that is committed every diskCacheBatchSize statements;
+// reads use the same transaction so they see uncommitted writes.
+type diskCache struct {
+	filename        string
+	db              *sql.DB
+	stmts           map[string]*sql.Stmt // prepared on db
+	tx              *sql.Tx
+	txStmts         map[string]*sql.Stmt // stmts bound to tx
+	pending         int
+	patternCount    int
+	tripsWithErrors map[string]tl.Trip
+	lock            sync.Mutex // guards all fields; callers may be concurrent
+}
+
+// diskCacheBatchSize is the number of writes per transaction.
+const diskCacheBatchSize = 10000
+
+// cachedTrip is the serialized form of a Trip; unexported fields are not encoded by gob.
+type cachedTrip struct {
+	Trip  tl.Trip
+	Line  int
+	Extra tl.ExtraFields
+}
+
+var diskCacheSchema = []string{
+	"CREATE TABLE trip_ids (trip_id TEXT PRIMARY KEY)",
+	"CREATE TABLE trips (trip_id TEXT PRIMARY KEY, data BLOB NOT NULL)",
+	"CREATE TABLE stop_patterns (pattern_key TEXT PRIMARY KEY, id INTEGER NOT NULL)",
+	"CREATE TABLE shapes (shape_id TEXT PRIMARY KEY, coords BLOB NOT NULL)",
+}
+
+const (
+	diskCacheAddTripID  = "INSERT OR IGNORE INTO trip_ids(trip_id) VALUES (?)"
+	diskCacheHasTripID  = "SELECT count(*) FROM trip_ids WHERE trip_id = ?"
+	diskCacheAddTrip    = "INSERT OR REPLACE INTO trips(trip_id, data) VALUES (?, ?)"
+	diskCacheGetTrip    = "SELECT data FROM trips WHERE trip_id = ?"
+	diskCacheDeleteTrip = "DELETE FROM trips WHERE trip_id = ?"
+	diskCacheEachTrip   = "SELECT data FROM trips ORDER BY rowid"
+	diskCacheGetPattern = "SELECT id FROM stop_patterns WHERE pattern_key = ?"
+	diskCacheAddPattern = "INSERT INTO stop_patterns(pattern_key, id) VALUES (?, ?)"
+	diskCacheAddShape   = "INSERT OR REPLACE INTO shapes(shape_id, coords) VALUES (?, ?)"
+	diskCacheGetShape   = "SELECT coords FROM shapes WHERE shape_id = ?"
+)
+
+// newDiskCache creates a temporary SQLite database in dir, or the default temporary directory if empty.
+// The database is removed on Close.
+func newDiskCache(dir string) (copierCache, error) {
+	tmpfile, err := ioutil.TempFile(dir, "copier-cache-*.db")
+	if err != nil {
+		return nil, err
+	}
+	filename := tmpfile.Name()
+	tmpfile.Close()
+	// The database is temporary; skip syncing and allow reads during writes.
+	db, err := sql.Open("sqlite3", "file:"+filename+"?_journal_mode=WAL&_synchronous=OFF&_busy_timeout=10000")
+	if err != nil {
+		os.Remove(filename)
+		return nil, err
+	}
+	c := &diskCache{filename: filename, db: db, stmts: map[string]*sql.Stmt{}, tripsWithErrors: map[string]tl.Trip{}}
+	for _, q := range diskCacheSchema {
+		if _, err := db.Exec(q); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+	for _, q := range []string{
+		diskCacheAddTripID, diskCacheHasTripID,
+		diskCacheAddTrip, diskCacheGetTrip, diskCacheDeleteTrip, diskCacheEachTrip,
+		diskCacheGetPattern, diskCacheAddPattern,
+		diskCacheAddShape, diskCacheGetShape,
+	} {
+		stmt, err := db.Prepare(q)
+		if err != nil {
+			c.Close()
+			return nil, err
+		}
+		c.stmts[q] = stmt
+	}
+	return c, nil
+}
+
+// stmt returns the prepared statement for query bound to the current transaction, beginning one if necessary.
+func (c *diskCache) stmt(query string) (*sql.Stmt, error) {
+	if c.tx == nil {
+		tx, err := c.db.Begin()
+		if err != nil {
+			return nil, err
+		}
+		c.tx = tx
+		c.txStmts = map[string]*sql.Stmt{}
+	}
+	if stmt, ok := c.txStmts[query]; ok {
+		return stmt, nil
+	}
+	stmt := c.tx.Stmt(c.stmts[query])
+	c.txStmts[query] = stmt
+	return stmt, nil
+}
+
+// exec runs a write statement and commits when the batch is full.
+func (c *diskCache) exec(query string, args ...interface{}) error {
+	stmt, err := c.stmt(query)
+	if err != nil {
+		return err
+	}
+	if _, err := stmt.Exec(args...); err != nil {
+		return err
+	}
+	c.pending++
+	if c.pending >= diskCacheBatchSize {
+		return c.commit()
+	}
+	return nil
+}
+
+// queryRow runs a read statement in the current transaction.
+func (c *diskCache) queryRow(query string, args ...interface{}) (*sql.Row, error) {
+	stmt, err := c.stmt(query)
+	if err != nil {
+		return nil, err
+	}
+	return stmt.QueryRow(args...), nil
+}
+
+// commit the current transaction, if any.
+func (c *diskCache) commit() error {
+	if c.tx == nil {
+		return nil
+	}
+	err := c.tx.Commit()
+	c.tx = nil
+	c.txStmts = nil
+	c.pending = 0
+	return err
+}
+
+func (c *diskCache) AddTripID(eid string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.exec(diskCacheAddTripID, eid)
+}
+
+func (c *diskCache) HasTripID(eid string) (bool, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	row, err := c.queryRow(diskCacheHasTripID, eid)
+	if err != nil {
+		return false, err
+	}
+	count := 0
+	if err := row.Scan(&count); err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+func (c *diskCache) AddTrip(trip tl.Trip) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	eid := trip.EntityID()
+	if len(trip.BaseEntity.Errors()) > 0 || len(trip.BaseEntity.Warnings()) > 0 {
+		c.tripsWithErrors[eid] = trip
+		return nil
+	}
+	ct := cachedTrip{Trip: trip, Line: trip.Line(), Extra: trip.ExtraFields()}
+	buf := bytes.Buffer{}
+	if err := gob.NewEncoder(&buf).Encode(&ct); err != nil {
+		return err
+	}
+	return c.exec(diskCacheAddTrip, eid, buf.Bytes())
+}
+
+func (c *diskCache) GetTrip(eid string) (tl.Trip, bool, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if trip, ok := c.tripsWithErrors[eid]; ok {
+		return trip, true, nil
+	}
+	row, err := c.queryRow(diskCacheGetTrip, eid)
+	if err != nil {
+		return tl.Trip{}, false, err
+	}
+	var data []byte
+	err = row.Scan(&data)
+	if err == sql.ErrNoRows {
+		return tl.Trip{}, false, nil
+	} else if err != nil {
+		return tl.Trip{}, false, err
+	}
+	trip, err := decodeTrip(data)
+	return trip, err == nil, err
+}
+
+func (c *diskCache) DeleteTrip(eid string) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	delete(c.tripsWithErrors, eid)
+	return c.exec(diskCacheDeleteTrip, eid)
+}
+
+// EachTrip commits pending writes and then reads outside the lock, so the callback may use the cache.
+func (c *diskCache) EachTrip(cb func(tl.Trip) error) error {
+	c.lock.Lock()
+	trips := make([]tl.Trip, 0, len(c.tripsWithErrors))
+	for _, trip := range c.tripsWithErrors {
+		trips = append(trips, trip)
+	}
+	err := c.commit()
+	c.lock.Unlock()
+	if err != nil {
+		return err
+	}
+	for _, trip := range trips {
+		if err := cb(trip); err != nil {
+			return err
+		}
+	}
+	rows, err := c.stmts[diskCacheEachTrip].Query()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return err
+		}
+		trip, err := decodeTrip(data)
+		if err != nil {
+			return err
+		}
+		if err := cb(trip); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (c *diskCache) StopPattern(key string) (int, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	row, err := c.queryRow(diskCacheGetPattern, key)
+	if err != nil {
+		return 0, err
+	}
+	pat := 0
+	err = row.Scan(&pat)
+	if err == nil {
+		return pat, nil
+	} else if err != sql.ErrNoRows {
+		return 0, err
+	}
+	pat = c.patternCount
+	if err := c.exec(diskCacheAddPattern, key, pat); err != nil {
+		return 0, err
+	}
+	c.patternCount++
+	return pat, nil
+}
+
+func (c *diskCache) AddShape(eid string, coords [][2]float64) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	data := make([]byte, len(coords)*16)
+	for i, p := range coords {
+		binary.LittleEndian.PutUint64(data[i*16:], math.Float64bits(p[0]))
+		binary.LittleEndian.PutUint64(data[i*16+8:], math.Float64bits(p[1]))
+	}
+	return c.exec(diskCacheAddShape, eid, data)
+}
+
+func (c *diskCache) GetShape(eid string) ([][2]float64, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	row, err := c.queryRow(diskCacheGetShape, eid)
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	err = row.Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	coords := make([][2]float64, len(data)/16)
+	for i := range coords {
+		coords[i][0] = math.Float64frombits(binary.LittleEndian.Uint64(data[i*16:]))
+		coords[i][1] = math.Float64frombits(binary.LittleEndian.Uint64(data[i*16+8:]))
+	}
+	return coords, nil
+}
+
+// Close the database and remove the temporary files.
+func (c *diskCache) Close() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.tx != nil {
+		c.tx.Rollback()
+		c.tx = nil
+	}
+	for _, stmt := range c.stmts {
+		stmt.Close()
+	}
+	err := c.db.Close()
+	for _, fn := range []string{c.filename, c.filename + "-wal", c.filename + "-shm"} {
+		if rerr := os.Remove(fn); rerr != nil && !os.IsNotExist(rerr) && err == nil {
+			err = rerr
+		}
+	}
+	return err
+}
+
+func decodeTrip(data []byte) (tl.Trip, error) {
+	ct := cachedTrip{}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&ct); err != nil {
+		return tl.Trip{}, err
+	}
+	trip := ct.Trip
+	trip.SetLine(ct.Line)
+	trip.SetExtraFields(ct.Extra)
+	return trip, nil
+}
@@ -0,0 +1,128 @@
+package copier
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/interline-io/transitland-lib/tl"
+)
+
+func TestMemoryCache(t *testing.T) {
+	testCopierCache(t, newMemoryCache())
+}
+
+func TestDiskCache(t *testing.T) {
+	cache, err := newDiskCache("")
+	if err != nil {
+		t.Skip(err)
+	}
+	defer cache.Close()
+	testCopierCache(t, cache)
+}
+
+func testCopierCache(t *testing.T, cache copierCache) {
+	t.Run("Trips", func(t *testing.T) {
+		trip := tl.Trip{TripID: "a", RouteID: "r", ServiceID: "s"}
+		trip.ShapeID.Key = "shape"
+		trip.ShapeID.Valid = true
+		trip.SetLine(3)
+		trip.SetExtra("ext", "value")
+		errtrip := tl.Trip{TripID: "b"}
+		errtrip.AddError(errors.New("test"))
+		for _, ent := range []tl.Trip{trip, errtrip} {
+			if err := cache.AddTripID(ent.TripID); err != nil {
+				t.Fatal(err)
+			}
+			if err := cache.AddTrip(ent); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if ok, err := cache.HasTripID("a"); err != nil || !ok {
+			t.Errorf("expected trip_id 'a'")
+		}
+		if ok, err := cache.HasTripID("c"); err != nil || ok {
+			t.Errorf("expected no trip_id 'c'")
+		}
+		got, ok, err := cache.GetTrip("a")
+		if err != nil || !ok {
+			t.Fatalf("expected trip 'a'")
+		}
+		if got.RouteID != "r" || got.ShapeID.Key != "shape" || got.Line() != 3 || got.Extra()["ext"] != "value" {
+			t.Errorf("got %#v", got)
+		}
+		got, ok, err = cache.GetTrip("b")
+		if err != nil || !ok || len(got.Errors()) == 0 {
+			t.Errorf("expected trip 'b' with errors")
+		}
+		if err := cache.DeleteTrip("a"); err != nil {
+			t.Fatal(err)
+		}
+		if _, ok, _ := cache.GetTrip("a"); ok {
+			t.Errorf("expected trip 'a' to be deleted")
+		}
+		if ok, _ := cache.HasTripID("a"); !ok {
+			t.Errorf("expected trip_id 'a' after delete")
+		}
+		tripids := []string{}
+		if err := cache.EachTrip(func(trip tl.Trip) error {
+			tripids = append(tripids, trip.TripID)
+			return nil
+		}); err != nil {
+			t.Fatal(err)
+		}
+		if len(tripids) != 1 || tripids[0] != "b" {
+			t.Errorf("got trips %v, expected [b]", tripids)
+		}
+	})
+	t.Run("StopPattern", func(t *testing.T) {
+		for i, key := range []string{"a", "b", "a", "c"} {
+			exp := []int{0, 1, 0, 2}[i]
+			if pat, err := cache.StopPattern(key); err != nil || pat != exp {
+				t.Errorf("key %s: got %d, expected %d", key, pat, exp)
+			}
+		}
+	})
+	t.Run("Shapes", func(t *testing.T) {
+		coords := [][2]float64{{-122.5, 37.5}, {-122.25, 37.75}}
+		if err := cache.AddShape("s", coords); err != nil {
+			t.Fatal(err)
+		}
+		got, err := cache.GetShape("s")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 2 || got[0] != coords[0] || got[1] != coords[1] {
+			t.Errorf("got %v, expected %v", got, coords)
+		}
+		if got, err := cache.GetShape("missing"); err != nil || got != nil {
+			t.Errorf("expected no shape")
+		}
+	})
+	t.Run("Concurrent", func(t *testing.T) {
+		wg := sync.WaitGroup{}
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					eid := fmt.Sprintf("%d-%d", i, j)
+					if err := cache.AddTrip(tl.Trip{TripID: eid}); err != nil {
+						t.Error(err)
+					}
+					if _, err := cache.StopPattern(eid); err != nil {
+						t.Error(err)
+					}
+					if _, err := cache.GetShape("s"); err != nil {
+						t.Error(err)
+					}
+				}
+			}(i)
+		}
+		wg.Wait()
+		if pat, err := cache.StopPattern("new"); err != nil || pat != 403 {
+			t.Errorf("got %d, expected 403", pat)
+		}
+	})
+}
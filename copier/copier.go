@@ -1,10 +1,12 @@
 package copier
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/interline-io/transitland-lib/ext"
@@ -28,10 +30,15 @@ type errorWithContext interface {
 	Context() *causes.Context
 }
 
-// CopyError wraps an underlying GTFS Error with the filename and entity ID.
+type hasLine interface {
+	Line() int
+}
+
+// CopyError wraps an underlying GTFS Error with the filename, entity ID, and line number.
 type CopyError struct {
 	filename string
 	entityID string
+	line     int
 	cause    error
 }
 
@@ -44,6 +51,15 @@ func NewCopyError(efn string, eid string, err error) *CopyError {
 	}
 }
 
+// NewEntityCopyError returns a new CopyError with the filename, ID, and source line number of the entity.
+func NewEntityCopyError(ent tl.Entity, err error) *CopyError {
+	ce := NewCopyError(ent.Filename(), ent.EntityID(), err)
+	if v, ok := ent.(hasLine); ok {
+		ce.line = v.Line()
+	}
+	return ce
+}
+
 // Error returns the error string.
 func (ce *CopyError) Error() string {
 	return fmt.Sprintf("%s '%s': %s", ce.filename, ce.entityID, ce.cause)
@@ -59,6 +75,7 @@ func (ce *CopyError) Context() *causes.Context {
 	return &causes.Context{
 		Filename: ce.filename,
 		EntityID: ce.entityID,
+		Line:     ce.line,
 	}
 }
 
@@ -88,15 +105,39 @@ type Copier struct {
 	Marker Marker
 	// Error handler, called for each entity
 	ErrorHandler ErrorHandler
+	// Rules to change the severity of errors and warnings, or suppress them
+	Rules *RuleSet
+	// Maximum number of example errors and warnings of each type kept in the CopyResult for each file; -1 for no limit
+	ErrorLimit int
+	// Number of goroutines used to validate and interpolate StopTimes and create missing Shapes; writes remain ordered
+	Workers int
+	// Called periodically with the number of rows read
+	ProgressHandler ProgressHandler
+	// Minimum time between calls to the ProgressHandler; it is also called once at the end
+	ProgressInterval time.Duration
+	// Estimated number of rows in each file, e.g. from FeedVersionFileInfos, used for progress totals
+	EstimatedRows map[string]int
+	// Store Trips, Shape geometries, and stop patterns in a temporary SQLite database instead of memory
+	DiskCache bool
+	// Directory for the DiskCache database; the default is the system temporary directory
+	CacheDir string
 	// book keeping
-	agencyCount         int
-	extensions          []copyableExtension // interface
-	filters             []tl.EntityFilter   // interface
-	geomCache           *geomCache
-	stopPatterns        map[string]int
-	stopPatternShapeIDs map[int]string
-	result              *CopyResult
-	duplicateMap        *tl.EntityMap
+	ctx                  context.Context
+	agencyCount          int
+	extensions           []copyableExtension // interface
+	filters              []tl.EntityFilter   // interface
+	beforeFileGroupHooks []BeforeFileGroupHook
+	afterFileGroupHooks  []AfterFileGroupHook
+	fileGroupHooks       []FileGroupHook
+	afterTripBatchHooks  []AfterTripBatchHook
+	afterCopyHooks       []AfterCopyHook
+	progress             *progressTracker
+	geomCache            *geomCache
+	cache                copierCache
+	stopPatternShapeIDs  map[int]string
+	result               *CopyResult
+	duplicateMap         *tl.EntityMap
+	sourceIDs            *tl.EntityMap // IDs changed by filters, to the source ID
 	*tl.EntityMap
 }
 
@@ -111,6 +152,9 @@ func NewCopier(reader tl.Reader, writer tl.Writer) Copier {
 		InterpolateStopTimes: false,
 		CreateMissingShapes:  false,
 		NormalizeServiceIDs:  false,
+		ErrorLimit:           -1,
+		Workers:              1,
+		ProgressInterval:     time.Second,
 	}
 	// Result
 	result := NewCopyResult()
@@ -122,34 +166,41 @@ func NewCopier(reader tl.Reader, writer tl.Writer) Copier {
 	copier.EntityMap = tl.NewEntityMap()
 	// Check for duplicate IDs
 	copier.duplicateMap = tl.NewEntityMap()
+	copier.sourceIDs = tl.NewEntityMap()
 	// Default filters
 	copier.filters = []tl.EntityFilter{}
 	// Geom Cache
 	copier.geomCache = newGeomCache()
-	copier.stopPatterns = map[string]int{}
+	copier.cache = copier.geomCache.shapes
 	copier.stopPatternShapeIDs = map[int]string{}
 	// Set the DefaultAgencyID from the Reader
 	copier.DefaultAgencyID = ""
-	for e := range copier.Reader.Agencies() {
-		copier.DefaultAgencyID = e.AgencyID
+	it := tl.NewIterator(copier.Reader, &tl.Agency{})
+	for it.Next() {
+		copier.DefaultAgencyID = it.Value().(*tl.Agency).AgencyID
 		copier.agencyCount++
 	}
+	it.Close()
 	return copier
 }
 
 // AddExtension adds an Extension to the copy process.
+// The Extension must provide a Copy method, implement one or more hooks, or both.
 func (copier *Copier) AddExtension(e ext.Extension) error {
 	extc, ok := e.(copyableExtension)
-	if !ok {
-		return fmt.Errorf("Extension does not provide Copy method")
+	if !copier.addHook(e) && !ok {
+		return fmt.Errorf("Extension does not provide Copy method or hooks")
+	}
+	if ok {
+		copier.extensions = append(copier.extensions, extc)
 	}
-	copier.extensions = append(copier.extensions, extc)
 	return nil
 }
 
-// AddEntityFilter adds an EntityFilter to the copy process.
+// AddEntityFilter adds an EntityFilter to the copy process; it is also added as a hook if it implements any hooks.
 func (copier *Copier) AddEntityFilter(ef tl.EntityFilter) error {
 	copier.filters = append(copier.filters, ef)
+	copier.addHook(ef)
 	return nil
 }
 
@@ -169,6 +220,9 @@ func (copier *Copier) isMarked(ent tl.Entity) bool {
 func (copier *Copier) CopyEntity(ent tl.Entity) (string, error, error) {
 	efn := ent.Filename()
 	sid := ent.EntityID()
+	if err := copier.ctxErr(); err != nil {
+		return "", err, err
+	}
 	if err := copier.checkEntity(ent); err != nil {
 		return "", err, nil
 	}
@@ -186,13 +240,20 @@ func (copier *Copier) CopyEntity(ent tl.Entity) (string, error, error) {
 
 // writeBatch does housekeeping for writing multiple entities.
 func (copier *Copier) writeBatch(ents []tl.Entity) error {
+	if err := copier.ctxErr(); err != nil {
+		return err
+	}
 	if len(ents) == 0 {
 		return nil
 	}
 	efn := ents[0].Filename()
 	sids := []string{}
 	for _, ent := range ents {
-		sids = append(sids, ent.EntityID())
+		sid := ent.EntityID()
+		if v, ok := copier.sourceIDs.Get(efn, sid); ok {
+			sid = v
+		}
+		sids = append(sids, sid)
 	}
 	// OK, Save
 	eids, err := copier.Writer.AddEntities(ents)
@@ -236,6 +297,10 @@ func (copier *Copier) checkEntity(ent tl.Entity) error {
 			return errors.New("skipped by filter")
 		}
 	}
+	// References use the source ID, even if a filter changed the ID
+	if eid := ent.EntityID(); eid != sid {
+		copier.sourceIDs.Set(efn, eid, sid)
+	}
 	// Check the entity for errors.
 	valid := true
 	errs := ent.Errors()
@@ -251,6 +316,19 @@ func (copier *Copier) checkEntity(ent tl.Entity) error {
 	} else {
 		copier.duplicateMap.Set(efn, eid, eid)
 	}
+	// Apply severity rules; a reference error may have been downgraded or suppressed
+	errs, warns := copier.Rules.Apply(efn, eid, errs, ent.Warnings())
+	if referr != nil {
+		found := false
+		for _, err := range errs {
+			if err == referr {
+				found = true
+			}
+		}
+		if !found {
+			referr = nil
+		}
+	}
 	// Check error tolerance flags
 	if len(errs) > 0 {
 		if copier.AllowEntityErrors {
@@ -270,7 +348,8 @@ func (copier *Copier) checkEntity(ent tl.Entity) error {
 		}
 	}
 	// Error handler
-	copier.ErrorHandler.HandleEntityErrors(ent, errs, ent.Warnings())
+	setErrorLine(ent, errs, warns)
+	copier.ErrorHandler.HandleEntityErrors(ent, errs, warns)
 	// Continue?
 	if !valid && len(errs) > 0 {
 		return errs[0]
@@ -280,12 +359,58 @@ func (copier *Copier) checkEntity(ent tl.Entity) error {
 	return nil
 }
 
+// ctxErr returns the context error, if the Copier is running with a context that is done.
+func (copier *Copier) ctxErr() error {
+	if copier.ctx == nil {
+		return nil
+	}
+	return copier.ctx.Err()
+}
+
+// setErrorLine sets the source line number of the entity on errors that do not already have one.
+func setErrorLine(ent tl.Entity, errs []error, warns []error) {
+	v, ok := ent.(hasLine)
+	if !ok {
+		return
+	}
+	for _, err := range errs {
+		causes.UpdateLine(err, v.Line())
+	}
+	for _, err := range warns {
+		causes.UpdateLine(err, v.Line())
+	}
+}
+
 //////////////////////////////////
 ////////// Copy Methods //////////
 //////////////////////////////////
 
 // Copy copies Base GTFS entities from the Reader to the Writer, returning the summary as a CopyResult.
 func (copier *Copier) Copy() *CopyResult {
+	return copier.CopyContext(context.Background())
+}
+
+// CopyContext is like Copy, but stops when the context is done and sets the CopyResult WriteError to the context error.
+// The context is also set on the Reader and Writer, if supported, so they can stop reading and writing.
+func (copier *Copier) CopyContext(ctx context.Context) *CopyResult {
+	copier.ctx = ctx
+	if v, ok := copier.Reader.(tl.ContextSetter); ok {
+		v.SetContext(ctx)
+	}
+	if v, ok := copier.Writer.(tl.ContextSetter); ok {
+		v.SetContext(ctx)
+	}
+	copier.result.ErrorLimit = copier.ErrorLimit
+	if copier.DiskCache {
+		cache, err := newDiskCache(copier.CacheDir)
+		if err != nil {
+			copier.result.WriteError = err
+			return copier.result
+		}
+		defer cache.Close()
+		copier.cache = cache
+		copier.geomCache.shapes = cache
+	}
 	// Handle source errors and warnings
 	sourceErrors := map[string][]error{}
 	for _, err := range copier.Reader.ValidateStructure() {
@@ -295,35 +420,82 @@ func (copier *Copier) Copy() *CopyResult {
 		}
 	}
 	for fn, errs := range sourceErrors {
-		copier.ErrorHandler.HandleSourceErrors(fn, errs, nil)
+		errs, warns := copier.Rules.Apply(fn, "", errs, nil)
+		copier.ErrorHandler.HandleSourceErrors(fn, errs, warns)
 	}
 	// Note that order is important!!
-	fns := []func() error{
-		copier.copyAgencies,
-		copier.copyRoutes,
-		copier.copyLevels,
-		copier.copyStops,
-		copier.copyPathways,
-		copier.copyFares,
-		copier.copyCalendars,
-		copier.copyShapes,
-		copier.copyTripsAndStopTimes,
-		copier.copyFrequencies,
-		copier.copyTransfers,
-		copier.copyFeedInfos,
-	}
-	for i := range fns {
-		if err := fns[i](); err != nil {
+	fns := []struct {
+		filenames []string
+		fn        func() error
+	}{
+		{[]string{"agency.txt"}, copier.copyAgencies},
+		{[]string{"routes.txt"}, copier.copyRoutes},
+		{[]string{"levels.txt"}, copier.copyLevels},
+		{[]string{"stops.txt"}, copier.copyStops},
+		{[]string{"pathways.txt"}, copier.copyPathways},
+		{[]string{"fare_attributes.txt", "fare_rules.txt"}, copier.copyFares},
+		{[]string{"calendar.txt", "calendar_dates.txt"}, copier.copyCalendars},
+		{[]string{"shapes.txt"}, copier.copyShapes},
+		{[]string{"trips.txt", "stop_times.txt"}, copier.copyTripsAndStopTimes},
+		{[]string{"frequencies.txt"}, copier.copyFrequencies},
+		{[]string{"transfers.txt"}, copier.copyTransfers},
+		{[]string{"feed_info.txt"}, copier.copyFeedInfos},
+	}
+	if copier.ProgressHandler != nil {
+		filenames := []string{}
+		for _, step := range fns {
+			filenames = append(filenames, step.filenames...)
+		}
+		copier.progress = newProgressTracker(copier.ProgressHandler, copier.ProgressInterval, copier.EstimatedRows, filenames)
+	}
+	for _, step := range fns {
+		if err := ctx.Err(); err != nil {
+			copier.result.WriteError = err
+			return copier.result
+		}
+		filenames, stepFn := step.filenames, step.fn
+		copyGroup := func() error {
+			for _, h := range copier.beforeFileGroupHooks {
+				if err := h.BeforeFileGroup(copier, filenames); err != nil {
+					return err
+				}
+			}
+			if err := stepFn(); err != nil {
+				return err
+			}
+			for _, h := range copier.afterFileGroupHooks {
+				if err := h.AfterFileGroup(copier, filenames); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		for _, h := range copier.fileGroupHooks {
+			h, next := h, copyGroup
+			copyGroup = func() error { return h.FileGroup(copier, filenames, next) }
+		}
+		if err := copyGroup(); err != nil {
 			copier.result.WriteError = err
 			return copier.result
 		}
 	}
+	copier.progress.report()
 	for _, e := range copier.extensions {
+		if err := ctx.Err(); err != nil {
+			copier.result.WriteError = err
+			return copier.result
+		}
 		if err := e.Copy(copier); err != nil {
 			copier.result.WriteError = err
 			return copier.result
 		}
 	}
+	for _, h := range copier.afterCopyHooks {
+		if err := h.AfterCopy(copier); err != nil {
+			copier.result.WriteError = err
+			return copier.result
+		}
+	}
 	return copier.result
 }
 
@@ -334,7 +506,10 @@ func (copier *Copier) Copy() *CopyResult {
 // copyAgencies writes agencies
 func (copier *Copier) copyAgencies() error {
 	firstTimezone := ""
-	for e := range copier.Reader.Agencies() {
+	it := copier.newIterator(&tl.Agency{})
+	defer it.Close()
+	for it.Next() {
+		e := *it.Value().(*tl.Agency)
 		// Check for Timezone consistency - add to feed errors
 		if len(firstTimezone) == 0 {
 			firstTimezone = e.AgencyTimezone
@@ -350,6 +525,9 @@ func (copier *Copier) copyAgencies() error {
 			return err
 		}
 	}
+	if err := it.Err(); err != nil {
+		return err
+	}
 	copier.logCount(&tl.Agency{})
 	return nil
 }
@@ -358,13 +536,18 @@ func (copier *Copier) copyAgencies() error {
 func (copier *Copier) copyLevels() error {
 	// Levels
 	bt := []tl.Entity{}
-	for e := range copier.Reader.Levels() {
-		e := e
+	it := copier.newIterator(&tl.Level{})
+	defer it.Close()
+	for it.Next() {
+		e := *it.Value().(*tl.Level)
 		var err error
 		if bt, err = copier.checkBatch(bt, &e); err != nil {
 			return err
 		}
 	}
+	if err := it.Err(); err != nil {
+		return err
+	}
 	if err := copier.writeBatch(bt); err != nil {
 		return err
 	}
@@ -406,41 +589,47 @@ func (copier *Copier) copyStops() error {
 		return nil
 	}
 
-	// First pass for stations
-	for e := range copier.Reader.Stops() {
-		if e.LocationType == 1 {
-			if err := copyStop(e); err != nil {
-				return err
+	// Read stops and copy the selected location types; progress is only counted in the first pass
+	pass := 0
+	copyPass := func(locationTypes ...int) error {
+		var it tl.EntityIterator
+		if pass == 0 {
+			it = copier.newIterator(&tl.Stop{})
+		} else {
+			it = tl.NewIterator(copier.Reader, &tl.Stop{})
+		}
+		pass++
+		defer it.Close()
+		for it.Next() {
+			e := *it.Value().(*tl.Stop)
+			for _, lt := range locationTypes {
+				if e.LocationType == lt {
+					if err := copyStop(e); err != nil {
+						return err
+					}
+				}
 			}
 		}
+		if err := it.Err(); err != nil {
+			return err
+		}
+		return copier.writeBatch(bt)
 	}
-	if err := copier.writeBatch(bt); err != nil {
+
+	// First pass for stations
+	if err := copyPass(1); err != nil {
 		return err
 	}
 
 	// Second pass for platforms, exits, and generic nodes
 	bt = nil
-	for e := range copier.Reader.Stops() {
-		if e.LocationType == 0 || e.LocationType == 2 || e.LocationType == 3 {
-			if err := copyStop(e); err != nil {
-				return err
-			}
-		}
-	}
-	if err := copier.writeBatch(bt); err != nil {
+	if err := copyPass(0, 2, 3); err != nil {
 		return err
 	}
 
 	// Third pass for boarding areas
 	bt = nil
-	for e := range copier.Reader.Stops() {
-		if e.LocationType == 4 {
-			if err := copyStop(e); err != nil {
-				return err
-			}
-		}
-	}
-	if err := copier.writeBatch(bt); err != nil {
+	if err := copyPass(4); err != nil {
 		return err
 	}
 
@@ -457,7 +646,10 @@ func (copier *Copier) copyStops() error {
 func (copier *Copier) copyFares() error {
 	// FareAttributes
 	bt := []tl.Entity{}
-	for e := range copier.Reader.FareAttributes() {
+	it := copier.newIterator(&tl.FareAttribute{})
+	defer it.Close()
+	for it.Next() {
+		e := *it.Value().(*tl.FareAttribute)
 		// Set default agency
 		if len(e.AgencyID.Key) == 0 {
 			e.AgencyID.Key = copier.DefaultAgencyID
@@ -466,12 +658,14 @@ func (copier *Copier) copyFares() error {
 				e.AddError(causes.NewConditionallyRequiredFieldError("agency_id"))
 			}
 		}
-		e := e
 		var err error
 		if bt, err = copier.checkBatch(bt, &e); err != nil {
 			return err
 		}
 	}
+	if err := it.Err(); err != nil {
+		return err
+	}
 	if err := copier.writeBatch(bt); err != nil {
 		return err
 	}
@@ -479,7 +673,10 @@ func (copier *Copier) copyFares() error {
 
 	// FareRules
 	bt = nil
-	for e := range copier.Reader.FareRules() {
+	it = copier.newIterator(&tl.FareRule{})
+	defer it.Close()
+	for it.Next() {
+		e := *it.Value().(*tl.FareRule)
 		// Explicitly check if the FareID is Marked
 		if !copier.isMarked(&tl.FareAttribute{FareID: e.FareID}) {
 			continue
@@ -501,12 +698,14 @@ func (copier *Copier) copyFares() error {
 		} else if len(e.ContainsID) > 0 && !ok {
 			e.AddError(causes.NewInvalidFarezoneError("contains_id", e.ContainsID))
 		}
-		e := e
 		var err error
 		if bt, err = copier.checkBatch(bt, &e); err != nil {
 			return err
 		}
 	}
+	if err := it.Err(); err != nil {
+		return err
+	}
 	if err := copier.writeBatch(bt); err != nil {
 		return err
 	}
@@ -517,13 +716,18 @@ func (copier *Copier) copyFares() error {
 func (copier *Copier) copyPathways() error {
 	// Pathways
 	bt := []tl.Entity{}
-	for e := range copier.Reader.Pathways() {
-		e := e
+	it := copier.newIterator(&tl.Pathway{})
+	defer it.Close()
+	for it.Next() {
+		e := *it.Value().(*tl.Pathway)
 		var err error
 		if bt, err = copier.checkBatch(bt, &e); err != nil {
 			return err
 		}
 	}
+	if err := it.Err(); err != nil {
+		return err
+	}
 	if err := copier.writeBatch(bt); err != nil {
 		return err
 	}
@@ -534,7 +738,10 @@ func (copier *Copier) copyPathways() error {
 // copyRoutes writes routes
 func (copier *Copier) copyRoutes() error {
 	bt := []tl.Entity{}
-	for e := range copier.Reader.Routes() {
+	it := copier.newIterator(&tl.Route{})
+	defer it.Close()
+	for it.Next() {
+		e := *it.Value().(*tl.Route)
 		var err error
 		// Set default agencyID
 		if len(e.AgencyID) == 0 {
@@ -553,11 +760,13 @@ func (copier *Copier) copyRoutes() error {
 				e.AddError(causes.NewInvalidFieldError("route_type", strconv.Itoa(e.RouteType), fmt.Errorf("cannot convert route_type %d to basic route type", e.RouteType)))
 			}
 		}
-		e := e
 		if bt, err = copier.checkBatch(bt, &e); err != nil {
 			return err
 		}
 	}
+	if err := it.Err(); err != nil {
+		return err
+	}
 	if err := copier.writeBatch(bt); err != nil {
 		return err
 	}
@@ -569,13 +778,18 @@ func (copier *Copier) copyRoutes() error {
 func (copier *Copier) copyCalendars() error {
 	// Calendars
 	bt := []tl.Entity{}
-	for ent := range copier.Reader.Calendars() {
-		ent := ent
+	it := copier.newIterator(&tl.Calendar{})
+	defer it.Close()
+	for it.Next() {
+		ent := *it.Value().(*tl.Calendar)
 		var err error
 		if bt, err = copier.checkBatch(bt, &ent); err != nil {
 			return err
 		}
 	}
+	if err := it.Err(); err != nil {
+		return err
+	}
 	if err := copier.writeBatch(bt); err != nil {
 		return err
 	}
@@ -596,7 +810,10 @@ func (copier *Copier) copyCalendars() error {
 	}
 	dups := map[calkey]int{}
 	bt = nil
-	for ent := range copier.Reader.CalendarDates() {
+	it = copier.newIterator(&tl.CalendarDate{})
+	defer it.Close()
+	for it.Next() {
+		ent := *it.Value().(*tl.CalendarDate)
 		if !copier.isMarked(&tl.Calendar{ServiceID: ent.ServiceID}) {
 			continue
 		}
@@ -615,12 +832,13 @@ func (copier *Copier) copyCalendars() error {
 				copier.EntityMap.Set("calendar.txt", ent.ServiceID, ent.ServiceID)
 			}
 		}
-		ent := ent
 		var err error
 		if bt, err = copier.checkBatch(bt, &ent); err != nil {
 			return err
 		}
-
+	}
+	if err := it.Err(); err != nil {
+		return err
 	}
 	if err := copier.writeBatch(bt); err != nil {
 		return err
@@ -632,13 +850,18 @@ func (copier *Copier) copyCalendars() error {
 // copyFeedInfos writes FeedInfos
 func (copier *Copier) copyFeedInfos() error {
 	bt := []tl.Entity{}
-	for e := range copier.Reader.FeedInfos() {
-		e := e
+	it := copier.newIterator(&tl.FeedInfo{})
+	defer it.Close()
+	for it.Next() {
+		e := *it.Value().(*tl.FeedInfo)
 		var err error
 		if bt, err = copier.checkBatch(bt, &e); err != nil {
 			return err
 		}
 	}
+	if err := it.Err(); err != nil {
+		return err
+	}
 	if err := copier.writeBatch(bt); err != nil {
 		return err
 	}
@@ -649,18 +872,23 @@ func (copier *Copier) copyFeedInfos() error {
 // copyTransfers writes Transfers
 func (copier *Copier) copyTransfers() error {
 	bt := []tl.Entity{}
-	for e := range copier.Reader.Transfers() {
+	it := copier.newIterator(&tl.Transfer{})
+	defer it.Close()
+	for it.Next() {
+		e := *it.Value().(*tl.Transfer)
 		// Check if Transfer stops are marked
 		if !copier.isMarked(&tl.Stop{StopID: e.FromStopID}) && copier.isMarked(&tl.Stop{StopID: e.ToStopID}) {
 			copier.result.SkipEntityMarkedCount["transfers.txt"]++
 			continue
 		}
-		e := e
 		var err error
 		if bt, err = copier.checkBatch(bt, &e); err != nil {
 			return err
 		}
 	}
+	if err := it.Err(); err != nil {
+		return err
+	}
 	if err := copier.writeBatch(bt); err != nil {
 		return err
 	}
@@ -671,14 +899,22 @@ func (copier *Copier) copyTransfers() error {
 // copyShapes writes Shapes
 func (copier *Copier) copyShapes() error {
 	// Not safe for batch copy (currently)
-	for e := range copier.Reader.Shapes() {
+	it := copier.newIterator(&tl.Shape{})
+	defer it.Close()
+	for it.Next() {
+		e := *it.Value().(*tl.Shape)
 		sid := e.EntityID()
 		if _, ok, err := copier.CopyEntity(&e); err != nil {
 			return err
 		} else if ok == nil {
-			copier.geomCache.AddShape(sid, e)
+			if err := copier.geomCache.AddShape(sid, e); err != nil {
+				return err
+			}
 		}
 	}
+	if err := it.Err(); err != nil {
+		return err
+	}
 	copier.logCount(&tl.Shape{})
 	return nil
 }
@@ -686,18 +922,23 @@ func (copier *Copier) copyShapes() error {
 // copyFrequencies writes Frequencies
 func (copier *Copier) copyFrequencies() error {
 	bt := []tl.Entity{}
-	for e := range copier.Reader.Frequencies() {
+	it := copier.newIterator(&tl.Frequency{})
+	defer it.Close()
+	for it.Next() {
+		e := *it.Value().(*tl.Frequency)
 		// Check if Trip is marked
 		if !copier.isMarked(&tl.Trip{TripID: e.TripID}) {
 			copier.result.SkipEntityMarkedCount["frequencies.txt"]++
 			continue
 		}
-		e := e
 		var err error
 		if bt, err = copier.checkBatch(bt, &e); err != nil {
 			return err
 		}
 	}
+	if err := it.Err(); err != nil {
+		return err
+	}
 	if err := copier.writeBatch(bt); err != nil {
 		return err
 	}
@@ -707,24 +948,35 @@ func (copier *Copier) copyFrequencies() error {
 
 // copyTripsAndStopTimes writes Trips and StopTimes
 func (copier *Copier) copyTripsAndStopTimes() error {
-	// Cache all trips in memory
-	// If this becomes an issue, we could do a pass through trips.txt for each stop_times chunk
-	alltripids := map[string]int{}
-	trips := map[string]tl.Trip{}
-	for trip := range copier.Reader.Trips() {
+	// Cache all trips, in memory or on disk if DiskCache is set
+	it := copier.newIterator(&tl.Trip{})
+	defer it.Close()
+	for it.Next() {
+		trip := *it.Value().(*tl.Trip)
 		eid := trip.EntityID()
-		alltripids[eid]++
+		if err := copier.cache.AddTripID(eid); err != nil {
+			return err
+		}
 		// Skip unmarked trips to save work
 		if !copier.isMarked(&trip) {
 			copier.result.SkipEntityMarkedCount["trips.txt"]++
 			continue
 		}
 		// We need to check for duplicate ID errors here because they're put into a map
-		if _, ok := trips[eid]; ok {
-			copier.ErrorHandler.HandleEntityErrors(&trip, []error{causes.NewDuplicateIDError(eid)}, nil)
+		if _, ok, err := copier.cache.GetTrip(eid); err != nil {
+			return err
+		} else if ok {
+			errs, warns := copier.Rules.Apply("trips.txt", eid, []error{causes.NewDuplicateIDError(eid)}, nil)
+			setErrorLine(&trip, errs, warns)
+			copier.ErrorHandler.HandleEntityErrors(&trip, errs, warns)
 			continue
 		}
-		trips[eid] = trip
+		if err := copier.cache.AddTrip(trip); err != nil {
+			return err
+		}
+	}
+	if err := it.Err(); err != nil {
+		return err
 	}
 
 	// Process each set of Trip/StopTimes
@@ -751,6 +1003,22 @@ func (copier *Copier) copyTripsAndStopTimes() error {
 			return err
 		}
 		log.Info("Saved %d stop_times", len(stbt2))
+		// Call hooks with the written entities
+		if len(copier.afterTripBatchHooks) > 0 && (len(tripbt) > 0 || len(stbt2) > 0) {
+			trips := make([]*tl.Trip, 0, len(tripbt))
+			for _, ent := range tripbt {
+				trips = append(trips, ent.(*tl.Trip))
+			}
+			stoptimes := make([]*tl.StopTime, 0, len(stbt2))
+			for _, ent := range stbt2 {
+				stoptimes = append(stoptimes, ent.(*tl.StopTime))
+			}
+			for _, h := range copier.afterTripBatchHooks {
+				if err := h.AfterTripBatch(copier, trips, stoptimes); err != nil {
+					return err
+				}
+			}
+		}
 		//
 		tripbt = nil
 		stbt = nil
@@ -758,100 +1026,135 @@ func (copier *Copier) copyTripsAndStopTimes() error {
 		return nil
 	}
 
-	for stoptimes := range copier.Reader.StopTimesByTripID() {
-		// Write batch
-		if batchCount+len(stoptimes) >= copier.BatchSize {
-			if err := writeBatch(); err != nil {
-				return err
+	// StopTimes are read and assigned stop patterns in order,
+	// then validated, interpolated, and given generated shapes by the workers,
+	// then checked and written in the original order.
+	workers := copier.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	done := make(chan struct{})
+	defer close(done)
+	jobs := make(chan *tripJob, workers)
+	var readErr error
+	go func() {
+		defer close(jobs)
+		stit := copier.newStopTimeIterator()
+		defer stit.Close()
+		for seq := 0; stit.Next(); seq++ {
+			job, err := copier.newTripJob(seq, stit.Value())
+			if err != nil {
+				readErr = err
+				return
+			}
+			select {
+			case jobs <- job:
+			case <-done:
+				return
 			}
 		}
-
-		// Error handling for trips without stop_times is after this block
-		if len(stoptimes) == 0 {
-			continue
-		}
-		// Does this trip exist?
-		tripid := stoptimes[0].TripID
-		if _, ok := alltripids[tripid]; !ok {
-			copier.ErrorHandler.HandleEntityErrors(&stoptimes[0], []error{causes.NewInvalidReferenceError("trip_id", tripid)}, nil)
-			copier.result.SkipEntityReferenceCount["stop_times.txt"] += len(stoptimes)
-			continue
-		}
-		// Is this trip marked?
-		trip, ok := trips[tripid]
-		if !ok { // trip_id exists but is not marked
-			copier.result.SkipEntityMarkedCount["stop_times.txt"] += len(stoptimes)
-			continue
-		}
-		// Mark trip as associated with at least 1 stop_time
-		delete(trips, tripid)
-
-		// Set StopPattern
-		patkey := stopPatternKey(stoptimes)
-		if pat, ok := copier.stopPatterns[patkey]; ok {
-			// log.Debug("trip %s stop_pattern %d", tripid, pat)
-			trip.StopPatternID = pat
-		} else {
-			pat := len(copier.stopPatterns)
-			copier.stopPatterns[patkey] = pat
-			// log.Debug("trip %s stop_pattern new %d", tripid, pat)
-			trip.StopPatternID = pat
-		}
-		// Do we need to create a shape for this trip
-		if trip.ShapeID.IsZero() && copier.CreateMissingShapes {
-			// Note: if the trip has errors, may result in unused shapes!
-			if shapeid, ok := copier.stopPatternShapeIDs[trip.StopPatternID]; ok {
-				trip.ShapeID.Key = shapeid
-				trip.ShapeID.Valid = true
-			} else {
-				if shapeid, err := copier.createMissingShape(fmt.Sprintf("generated-%d-%d", trip.StopPatternID, time.Now().Unix()), stoptimes); err != nil {
-					log.Error("Error: failed to create shape for trip '%s': %s", trip.EntityID(), err)
-					trip.AddError(err)
-				} else {
-					// Set ShapeID
-					copier.stopPatternShapeIDs[trip.StopPatternID] = shapeid
-					trip.ShapeID.Key = shapeid
-					trip.ShapeID.Valid = true
+		readErr = stit.Err()
+	}()
+	results := make(chan *tripJob, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				copier.processTripJob(job)
+				select {
+				case results <- job:
+				case <-done:
+					return
 				}
 			}
-		}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
 
-		// Check StopTime GROUP errors; log errors with trip; can block trip
-		// Example errors: less than 2 stop_times, non-increasing sequences and times, etc.
-		sterrs := tl.ValidateStopTimes(stoptimes)
-		for _, err := range sterrs {
-			trip.AddError(err)
-		}
-		// Interpolate StopTimes if necessary - only if no other errors; log errors with trip
-		if len(sterrs) == 0 && copier.InterpolateStopTimes {
-			if stoptimes2, err := copier.geomCache.InterpolateStopTimes(trip, stoptimes); err != nil {
-				// stwarns = append(stwarns, err)
-				trip.AddWarning(err)
+	pending := map[int]*tripJob{}
+	next := 0
+	failedShapes := map[string]error{}
+	for job := range results {
+		pending[job.seq] = job
+		for {
+			job, ok := pending[next]
+			if !ok {
+				break
+			}
+			delete(pending, next)
+			next++
+			stoptimes := job.stoptimes
+			// Write batch
+			if batchCount+len(stoptimes) >= copier.BatchSize {
+				if err := writeBatch(); err != nil {
+					return err
+				}
+			}
+			// Error handling for trips without stop_times is after this block
+			if len(stoptimes) == 0 {
+				continue
+			}
+			// Does this trip exist?
+			if job.status == tripJobNotFound {
+				tripid := stoptimes[0].TripID
+				errs, warns := copier.Rules.Apply("stop_times.txt", stoptimes[0].EntityID(), []error{causes.NewInvalidReferenceError("trip_id", tripid)}, nil)
+				setErrorLine(&stoptimes[0], errs, warns)
+				copier.ErrorHandler.HandleEntityErrors(&stoptimes[0], errs, warns)
+				copier.result.SkipEntityReferenceCount["stop_times.txt"] += len(stoptimes)
+				continue
+			}
+			// Is this trip marked?
+			if job.status == tripJobUnmarked {
+				copier.result.SkipEntityMarkedCount["stop_times.txt"] += len(stoptimes)
+				continue
+			}
+			// Write the generated shape before the first trip that uses it
+			trip := job.trip
+			if job.createShape {
+				if job.shapeErr == nil {
+					job.shapeErr = copier.createMissingShape(job.shape)
+				}
+				if job.shapeErr != nil {
+					log.Error("Error: failed to create shape for trip '%s': %s", trip.EntityID(), job.shapeErr)
+					failedShapes[job.shape.ShapeID] = job.shapeErr
+				}
+			}
+			if err, ok := failedShapes[trip.ShapeID.Key]; ok && trip.ShapeID.Valid {
+				trip.ShapeID.Key = ""
+				trip.ShapeID.Valid = false
+				trip.AddError(err)
+			}
+			// Validate trip & add to batch
+			if err := copier.checkEntity(&trip); err == nil {
+				tripbt = append(tripbt, &trip)
 			} else {
-				stoptimes = stoptimes2
+				copier.result.SkipEntityReferenceCount["stop_times.txt"] += len(stoptimes)
+				continue
 			}
+			// Add StopTimes to batch -- final validation after writing trips
+			for i := range stoptimes {
+				stbt = append(stbt, stoptimes[i])
+			}
+			batchCount += len(stoptimes)
 		}
-
-		// Validate trip & add to batch
-		if err := copier.checkEntity(&trip); err == nil {
-			tripbt = append(tripbt, &trip)
-		} else {
-			copier.result.SkipEntityReferenceCount["stop_times.txt"] += len(stoptimes)
-			continue
-		}
-		// Add StopTimes to batch -- final validation after writing trips
-		for i := range stoptimes {
-			stbt = append(stbt, stoptimes[i])
-		}
-		batchCount += len(stoptimes)
+	}
+	if readErr != nil {
+		return readErr
 	}
 	// Add any Trips that were not visited/did not have StopTimes
-	for _, trip := range trips {
-		trip := trip
+	if err := copier.cache.EachTrip(func(trip tl.Trip) error {
 		trip.AddError(causes.NewEmptyTripError(0))
 		if err := copier.checkEntity(&trip); err == nil {
 			tripbt = append(tripbt, &trip)
 		}
+		return nil
+	}); err != nil {
+		return err
 	}
 	// Write last entities
 	if err := writeBatch(); err != nil {
@@ -860,6 +1163,102 @@ func (copier *Copier) copyTripsAndStopTimes() error {
 	return nil
 }
 
+// Trip job status values.
+const (
+	tripJobOK = iota
+	tripJobNotFound
+	tripJobUnmarked
+)
+
+// tripJob is a Trip and its StopTimes, passed through the copyTripsAndStopTimes workers.
+type tripJob struct {
+	seq         int
+	status      int
+	trip        tl.Trip
+	stoptimes   []tl.StopTime
+	createShape bool     // the trip is the first to use a generated shape
+	shape       tl.Shape // generated shape
+	shapeErr    error
+}
+
+// newTripJob looks up the Trip for a group of StopTimes and sets the stop pattern and any generated ShapeID.
+// It must be called in order, as stop patterns and generated shapes are numbered by first use.
+func (copier *Copier) newTripJob(seq int, stoptimes []tl.StopTime) (*tripJob, error) {
+	job := tripJob{seq: seq, stoptimes: stoptimes}
+	if len(stoptimes) == 0 {
+		return &job, nil
+	}
+	tripid := stoptimes[0].TripID
+	if ok, err := copier.cache.HasTripID(tripid); err != nil {
+		return nil, err
+	} else if !ok {
+		job.status = tripJobNotFound
+		return &job, nil
+	}
+	trip, ok, err := copier.cache.GetTrip(tripid)
+	if err != nil {
+		return nil, err
+	} else if !ok { // trip_id exists but is not marked
+		job.status = tripJobUnmarked
+		return &job, nil
+	}
+	// Mark trip as associated with at least 1 stop_time
+	if err := copier.cache.DeleteTrip(tripid); err != nil {
+		return nil, err
+	}
+
+	// Set StopPattern
+	if trip.StopPatternID, err = copier.cache.StopPattern(stopPatternKey(stoptimes)); err != nil {
+		return nil, err
+	}
+	// Do we need to create a shape for this trip
+	if trip.ShapeID.IsZero() && copier.CreateMissingShapes {
+		// Note: if the trip has errors, may result in unused shapes!
+		shapeid, ok := copier.stopPatternShapeIDs[trip.StopPatternID]
+		if !ok {
+			shapeid = fmt.Sprintf("generated-%d", trip.StopPatternID)
+			copier.stopPatternShapeIDs[trip.StopPatternID] = shapeid
+			job.createShape = true
+			job.shape.ShapeID = shapeid
+		}
+		trip.ShapeID.Key = shapeid
+		trip.ShapeID.Valid = true
+	}
+	job.trip = trip
+	return &job, nil
+}
+
+// processTripJob creates generated shape geometries, checks StopTime group errors, and interpolates StopTimes.
+// It is safe to call concurrently.
+func (copier *Copier) processTripJob(job *tripJob) {
+	if job.status != tripJobOK || len(job.stoptimes) == 0 {
+		return
+	}
+	if job.createShape {
+		stopids := []string{}
+		for _, st := range job.stoptimes {
+			stopids = append(stopids, st.StopID)
+		}
+		shapeid := job.shape.ShapeID
+		job.shape, job.shapeErr = copier.geomCache.MakeShape(stopids...)
+		job.shape.ShapeID = shapeid
+	}
+	// Check StopTime GROUP errors; log errors with trip; can block trip
+	// Example errors: less than 2 stop_times, non-increasing sequences and times, etc.
+	sterrs := tl.ValidateStopTimes(job.stoptimes)
+	for _, err := range sterrs {
+		job.trip.AddError(err)
+	}
+	// Interpolate StopTimes if necessary - only if no other errors; log errors with trip
+	if len(sterrs) == 0 && copier.InterpolateStopTimes {
+		if stoptimes2, err := copier.geomCache.InterpolateStopTimes(job.trip, job.stoptimes); err != nil {
+			job.trip.AddWarning(err)
+		} else {
+			job.stoptimes = stoptimes2
+		}
+	}
+}
+
 ////////////////////////////////////////////
 ////////// Entity Support Methods //////////
 ////////////////////////////////////////////
@@ -892,29 +1291,24 @@ func (copier *Copier) logCount(ent tl.Entity) {
 	log.Info(outs)
 }
 
-func (copier *Copier) createMissingShape(shapeID string, stoptimes []tl.StopTime) (string, error) {
-	stopids := []string{}
-	for _, st := range stoptimes {
-		stopids = append(stopids, st.StopID)
-	}
-	shape, err := copier.geomCache.MakeShape(stopids...)
-	if err != nil {
-		return "", err
-	}
-	shape.ShapeID = shapeID
+// createMissingShape writes a generated Shape.
+func (copier *Copier) createMissingShape(shape tl.Shape) error {
 	if _, ok, err := copier.CopyEntity(&shape); err != nil {
-		return "", err
+		return err
 	} else if ok == nil {
 		copier.result.GeneratedCount["shapes.txt"]++
 	}
-	return shape.ShapeID, nil
+	return nil
 }
 
 // createMissingCalendars to fully normalize ServiceIDs
 func (copier *Copier) createMissingCalendars() error {
 	// Prepare to create missing Calendars
 	missing := map[string]tl.Calendar{}
-	for e := range copier.Reader.CalendarDates() {
+	it := tl.NewIterator(copier.Reader, &tl.CalendarDate{})
+	defer it.Close()
+	for it.Next() {
+		e := it.Value().(*tl.CalendarDate)
 		cal := tl.Calendar{
 			ServiceID: e.ServiceID,
 			Generated: true,
@@ -943,6 +1337,9 @@ func (copier *Copier) createMissingCalendars() error {
 		}
 		missing[e.ServiceID] = cal
 	}
+	if err := it.Err(); err != nil {
+		return err
+	}
 	// Create the missing Calendars
 	bt := []tl.Entity{}
 	for _, e := range missing {
@@ -0,0 +1,344 @@
+package copier
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/interline-io/transitland-lib/internal/mock"
+	"github.com/interline-io/transitland-lib/tl"
+	"github.com/interline-io/transitland-lib/tlcsv"
+)
+
+func TestCopier_CopyContext(t *testing.T) {
+	reader := mock.NewReader()
+	reader.AgencyList = []tl.Agency{
+		{AgencyID: "a", AgencyName: "test", AgencyURL: "http://example.com", AgencyTimezone: "America/Los_Angeles"},
+	}
+	writer := mock.NewWriter()
+	cp := NewCopier(reader, writer)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result := cp.CopyContext(ctx)
+	if result.WriteError != context.Canceled {
+		t.Errorf("got write error '%v', expected '%v'", result.WriteError, context.Canceled)
+	}
+	if result.EntityCount["agency.txt"] != 0 {
+		t.Errorf("expected no entities to be copied")
+	}
+}
+
+// errorReader returns an error when reading stops.
+type errorReader struct {
+	*mock.Reader
+	err error
+}
+
+func (r *errorReader) Iterate(ent tl.Entity) tl.EntityIterator {
+	if _, ok := ent.(*tl.Stop); ok {
+		return tl.NewEntityIterator(func(send func(tl.Entity) bool) error { return r.err })
+	}
+	return tl.NewIterator(r.Reader, ent)
+}
+
+func (r *errorReader) IterateStopTimesByTripID(tripIDs ...string) tl.StopTimeIterator {
+	return tl.NewStopTimeIterator(r.Reader, tripIDs...)
+}
+
+func TestCopier_ReadError(t *testing.T) {
+	reader := &errorReader{Reader: mock.NewReader(), err: errors.New("read error")}
+	reader.AgencyList = []tl.Agency{
+		{AgencyID: "a", AgencyName: "test", AgencyURL: "http://example.com", AgencyTimezone: "America/Los_Angeles"},
+	}
+	writer := mock.NewWriter()
+	cp := NewCopier(reader, writer)
+	result := cp.Copy()
+	if result.WriteError != reader.err {
+		t.Errorf("got write error '%v', expected '%v'", result.WriteError, reader.err)
+	}
+	if result.EntityCount["agency.txt"] != 1 {
+		t.Errorf("expected agencies to be copied before the error")
+	}
+}
+
+func TestCopier_CreateMissingCalendars(t *testing.T) {
+	reader := mock.NewReader()
+	expect := map[string]time.Time{
+		"a": time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		"b": time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
+		"c": time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for sid, d := range expect {
+		reader.CalendarDateList = append(reader.CalendarDateList, tl.CalendarDate{ServiceID: sid, Date: d, ExceptionType: 1})
+	}
+	writer := mock.NewWriter()
+	cp := NewCopier(reader, writer)
+	cp.NormalizeServiceIDs = true
+	if result := cp.Copy(); result.WriteError != nil {
+		t.Fatal(result.WriteError)
+	}
+	cals := writer.Reader.CalendarList
+	if len(cals) != len(expect) {
+		t.Fatalf("got %d calendars, expected %d", len(cals), len(expect))
+	}
+	for _, cal := range cals {
+		d, ok := expect[cal.ServiceID]
+		if !ok {
+			t.Errorf("unexpected calendar '%s'", cal.ServiceID)
+			continue
+		}
+		delete(expect, cal.ServiceID)
+		if !cal.Generated || !cal.StartDate.Equal(d) || !cal.EndDate.Equal(d) {
+			t.Errorf("calendar '%s': got generated %t start %s end %s, expected %s", cal.ServiceID, cal.Generated, cal.StartDate, cal.EndDate, d)
+		}
+	}
+}
+
+func TestCopier_Workers(t *testing.T) {
+	// Output with multiple workers should match a single worker, in the same order
+	for _, path := range []string{"../test/data/external/caltrain.zip", "../test/data/example"} {
+		t.Run(path, func(t *testing.T) {
+			testCopierOutput(t, path, func(cp *Copier) { cp.Workers = 4 })
+		})
+	}
+}
+
+func TestCopier_DiskCache(t *testing.T) {
+	// Output using the disk cache should match the memory cache
+	for _, path := range []string{"../test/data/external/caltrain.zip", "../test/data/example"} {
+		t.Run(path, func(t *testing.T) {
+			testCopierOutput(t, path, func(cp *Copier) { cp.DiskCache = true })
+		})
+	}
+}
+
+// testCopierOutput checks that the output with the configured options is the same as the defaults.
+func testCopierOutput(t *testing.T, path string, configure func(*Copier)) {
+	copyWith := func(configure func(*Copier)) *mock.Writer {
+		reader, err := tlcsv.NewReader(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := reader.Open(); err != nil {
+			t.Fatal(err)
+		}
+		defer reader.Close()
+		writer := mock.NewWriter()
+		cp := NewCopier(reader, writer)
+		cp.BatchSize = 100
+		cp.InterpolateStopTimes = true
+		cp.CreateMissingShapes = true
+		configure(&cp)
+		if result := cp.Copy(); result.WriteError != nil {
+			t.Fatal(result.WriteError)
+		}
+		return writer
+	}
+	w1 := copyWith(func(*Copier) {})
+	w4 := copyWith(configure)
+	if len(w1.Reader.TripList) == 0 || len(w1.Reader.StopTimeList) == 0 {
+		t.Fatal("expected trips and stop_times")
+	}
+	if len(w4.Reader.TripList) != len(w1.Reader.TripList) {
+		t.Fatalf("got %d trips, expected %d", len(w4.Reader.TripList), len(w1.Reader.TripList))
+	}
+	for i, a := range w1.Reader.TripList {
+		b := w4.Reader.TripList[i]
+		if a.TripID != b.TripID || a.StopPatternID != b.StopPatternID || a.ShapeID.Valid != b.ShapeID.Valid {
+			t.Errorf("trip %d: got %s pattern %d, expected %s pattern %d", i, b.TripID, b.StopPatternID, a.TripID, a.StopPatternID)
+		}
+	}
+	if len(w4.Reader.StopTimeList) != len(w1.Reader.StopTimeList) {
+		t.Fatalf("got %d stop_times, expected %d", len(w4.Reader.StopTimeList), len(w1.Reader.StopTimeList))
+	}
+	for i, a := range w1.Reader.StopTimeList {
+		b := w4.Reader.StopTimeList[i]
+		if a.TripID != b.TripID || a.StopSequence != b.StopSequence || a.ArrivalTime != b.ArrivalTime || a.ShapeDistTraveled != b.ShapeDistTraveled {
+			t.Errorf("stop_time %d: got %s/%d, expected %s/%d", i, b.TripID, b.StopSequence, a.TripID, a.StopSequence)
+		}
+	}
+	if len(w4.Reader.ShapeList) != len(w1.Reader.ShapeList) {
+		t.Errorf("got %d shapes, expected %d", len(w4.Reader.ShapeList), len(w1.Reader.ShapeList))
+	}
+}
+
+// testHooks records the hooks that were called.
+type testHooks struct {
+	before    []string
+	after     []string
+	wrapped   []string
+	trips     int
+	stoptimes int
+	done      bool
+}
+
+func (h *testHooks) BeforeFileGroup(cp *Copier, filenames []string) error {
+	h.before = append(h.before, filenames[0])
+	return nil
+}
+
+func (h *testHooks) AfterFileGroup(cp *Copier, filenames []string) error {
+	h.after = append(h.after, filenames[0])
+	return nil
+}
+
+func (h *testHooks) FileGroup(cp *Copier, filenames []string, copyGroup func() error) error {
+	before, after := len(h.before), len(h.after)
+	if err := copyGroup(); err != nil {
+		return err
+	}
+	if len(h.before) == before+1 && len(h.after) == after+1 {
+		h.wrapped = append(h.wrapped, filenames[0])
+	}
+	return nil
+}
+
+func (h *testHooks) AfterTripBatch(cp *Copier, trips []*tl.Trip, stoptimes []*tl.StopTime) error {
+	h.trips += len(trips)
+	h.stoptimes += len(stoptimes)
+	return nil
+}
+
+func (h *testHooks) AfterCopy(cp *Copier) error {
+	h.done = true
+	return nil
+}
+
+func TestCopier_Hooks(t *testing.T) {
+	reader, err := tlcsv.NewReader("../test/data/external/caltrain.zip")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := reader.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	writer := mock.NewWriter()
+	cp := NewCopier(reader, writer)
+	cp.BatchSize = 100
+	hooks := &testHooks{}
+	if err := cp.AddHook(hooks); err != nil {
+		t.Fatal(err)
+	}
+	if err := cp.AddHook(struct{}{}); err == nil {
+		t.Error("expected an error for a value without hooks")
+	}
+	if result := cp.Copy(); result.WriteError != nil {
+		t.Fatal(result.WriteError)
+	}
+	if len(hooks.before) != 12 || len(hooks.after) != 12 {
+		t.Errorf("got %d before and %d after file group calls, expected 12", len(hooks.before), len(hooks.after))
+	}
+	if len(hooks.wrapped) != 12 {
+		t.Errorf("got %d file groups wrapped around before and after calls, expected 12", len(hooks.wrapped))
+	}
+	if len(hooks.before) > 0 && hooks.before[0] != "agency.txt" {
+		t.Errorf("got first file group %s, expected agency.txt", hooks.before[0])
+	}
+	if hooks.trips != len(writer.Reader.TripList) || hooks.stoptimes != len(writer.Reader.StopTimeList) {
+		t.Errorf("got %d trips and %d stop_times in batches, expected %d and %d", hooks.trips, hooks.stoptimes, len(writer.Reader.TripList), len(writer.Reader.StopTimeList))
+	}
+	if !hooks.done {
+		t.Error("expected AfterCopy to be called")
+	}
+	// Errors stop the copy
+	cp2 := NewCopier(mock.NewReader(), mock.NewWriter())
+	hookErr := errors.New("hook error")
+	cp2.AddHook(&errorHook{err: hookErr})
+	if result := cp2.Copy(); result.WriteError != hookErr {
+		t.Errorf("got write error '%v', expected '%v'", result.WriteError, hookErr)
+	}
+}
+
+type errorHook struct {
+	err error
+}
+
+func (h *errorHook) BeforeFileGroup(cp *Copier, filenames []string) error {
+	return h.err
+}
+
+func TestCopier_Progress(t *testing.T) {
+	copyWith := func(estimated map[string]int) []Progress {
+		reader, err := tlcsv.NewReader("../test/data/external/caltrain.zip")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := reader.Open(); err != nil {
+			t.Fatal(err)
+		}
+		defer reader.Close()
+		cp := NewCopier(reader, mock.NewWriter())
+		cp.ProgressInterval = 0
+		cp.EstimatedRows = estimated
+		ps := []Progress{}
+		cp.ProgressHandler = ProgressFunc(func(p Progress) { ps = append(ps, p) })
+		if result := cp.Copy(); result.WriteError != nil {
+			t.Fatal(result.WriteError)
+		}
+		return ps
+	}
+	// Without estimates, count the rows in each file
+	ps := copyWith(nil)
+	if len(ps) == 0 {
+		t.Fatal("expected progress")
+	}
+	counts := map[string]int{}
+	for _, p := range ps {
+		counts[p.Filename] = p.Count
+		if p.Percent() != 0 {
+			t.Errorf("got %f percent, expected 0 without estimates", p.Percent())
+		}
+	}
+	if counts["stops.txt"] != 64 {
+		t.Errorf("got %d stops.txt rows, expected 64", counts["stops.txt"])
+	}
+	// With estimates, the final progress is 100 percent
+	ps = copyWith(counts)
+	last := ps[len(ps)-1]
+	if last.Percent() != 100 || last.TotalCount != last.TotalEstimate {
+		t.Errorf("got %f percent, %d of %d rows, expected 100 percent", last.Percent(), last.TotalCount, last.TotalEstimate)
+	}
+	if last.Elapsed <= 0 {
+		t.Error("expected elapsed time")
+	}
+}
+
+// routePrefixFilter changes route IDs.
+type routePrefixFilter struct{}
+
+func (*routePrefixFilter) Filter(ent tl.Entity, emap *tl.EntityMap) error {
+	if v, ok := ent.(*tl.Route); ok {
+		v.RouteID = "x:" + v.RouteID
+	}
+	return nil
+}
+
+func TestCopier_FilterChangesID(t *testing.T) {
+	reader, err := tlcsv.NewReader("../test/data/example")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := reader.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	writer := mock.NewWriter()
+	cp := NewCopier(reader, writer)
+	cp.AddEntityFilter(&routePrefixFilter{})
+	result := cp.Copy()
+	if result.WriteError != nil {
+		t.Fatal(result.WriteError)
+	}
+	// References use the source ID and are updated to the new ID
+	if result.EntityCount["trips.txt"] != 11 {
+		t.Errorf("got %d trips, expected 11", result.EntityCount["trips.txt"])
+	}
+	for _, trip := range writer.Reader.TripList {
+		if !strings.HasPrefix(trip.RouteID, "x:") {
+			t.Errorf("trip %s: got route_id %s, expected prefix x:", trip.TripID, trip.RouteID)
+		}
+	}
+}
@@ -5,13 +5,24 @@ import (
 
 	"github.com/interline-io/transitland-lib/internal/log"
 	"github.com/interline-io/transitland-lib/tl"
+	"github.com/interline-io/transitland-lib/tl/causes"
 )
 
+// ErrorKey identifies errors of the same type from the same file.
+type ErrorKey struct {
+	Filename string
+	Code     string
+}
+
 // CopyResult stores Copier results and statistics.
+// Errors and Warnings keep at most ErrorLimit examples for each ErrorKey; ErrorCount and WarningCount keep the totals.
 type CopyResult struct {
 	WriteError                error
 	Errors                    []error
 	Warnings                  []error
+	ErrorLimit                int // -1 for no limit
+	ErrorCount                map[ErrorKey]int
+	WarningCount              map[ErrorKey]int
 	InterpolatedStopTimeCount int
 	EntityCount               map[string]int
 	GeneratedCount            map[string]int
@@ -26,6 +37,9 @@ func NewCopyResult() *CopyResult {
 	return &CopyResult{
 		Errors:                   []error{},
 		Warnings:                 []error{},
+		ErrorLimit:               -1,
+		ErrorCount:               map[ErrorKey]int{},
+		WarningCount:             map[ErrorKey]int{},
 		EntityCount:              map[string]int{},
 		GeneratedCount:           map[string]int{},
 		SkipEntityErrorCount:     map[string]int{},
@@ -37,21 +51,28 @@ func NewCopyResult() *CopyResult {
 
 // HandleSourceErrors .
 func (cr *CopyResult) HandleSourceErrors(fn string, errs []error, warns []error) {
-	for _, err := range errs {
-		cr.Errors = append(cr.Errors, NewCopyError(fn, "", err))
-	}
-	for _, err := range warns {
-		cr.Warnings = append(cr.Warnings, NewCopyError(fn, "", err))
-	}
+	cr.handleErrors(fn, errs, warns, func(err error) error { return NewCopyError(fn, "", err) })
 }
 
 // HandleEntityErrors .
 func (cr *CopyResult) HandleEntityErrors(ent tl.Entity, errs []error, warns []error) {
+	cr.handleErrors(ent.Filename(), errs, warns, func(err error) error { return NewEntityCopyError(ent, err) })
+}
+
+func (cr *CopyResult) handleErrors(fn string, errs []error, warns []error, wrap func(error) error) {
 	for _, err := range errs {
-		cr.Errors = append(cr.Errors, NewCopyError(ent.Filename(), ent.EntityID(), err))
+		key := ErrorKey{Filename: fn, Code: causes.ErrorCode(err)}
+		cr.ErrorCount[key]++
+		if cr.ErrorLimit < 0 || cr.ErrorCount[key] <= cr.ErrorLimit {
+			cr.Errors = append(cr.Errors, wrap(err))
+		}
 	}
 	for _, err := range warns {
-		cr.Warnings = append(cr.Warnings, NewCopyError(ent.Filename(), ent.EntityID(), err))
+		key := ErrorKey{Filename: fn, Code: causes.ErrorCode(err)}
+		cr.WarningCount[key]++
+		if cr.ErrorLimit < 0 || cr.WarningCount[key] <= cr.ErrorLimit {
+			cr.Warnings = append(cr.Warnings, wrap(err))
+		}
 	}
 }
 
@@ -112,6 +133,14 @@ func (cr *CopyResult) DisplaySummary() {
 	for _, k := range sortedKeys(cr.SkipEntityMarkedCount) {
 		log.Info("\t%s: %d", k, cr.SkipEntityMarkedCount[k])
 	}
+	log.Info("Errors:")
+	for _, k := range sortedErrorKeys(cr.ErrorCount) {
+		log.Info("\t%s: %s: %d", k.Filename, k.Code, cr.ErrorCount[k])
+	}
+	log.Info("Warnings:")
+	for _, k := range sortedErrorKeys(cr.WarningCount) {
+		log.Info("\t%s: %s: %d", k.Filename, k.Code, cr.WarningCount[k])
+	}
 }
 
 func sortedKeys(m map[string]int) []string {
@@ -122,3 +151,17 @@ func sortedKeys(m map[string]int) []string {
 	sort.Strings(keys)
 	return keys
 }
+
+func sortedErrorKeys(m map[ErrorKey]int) []ErrorKey {
+	keys := []ErrorKey{}
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].Filename == keys[j].Filename {
+			return keys[i].Code < keys[j].Code
+		}
+		return keys[i].Filename < keys[j].Filename
+	})
+	return keys
+}
@@ -0,0 +1,29 @@
+package copier
+
+import (
+	"testing"
+
+	"github.com/interline-io/transitland-lib/tl"
+	"github.com/interline-io/transitland-lib/tl/causes"
+)
+
+func TestCopyResult_ErrorLimit(t *testing.T) {
+	cr := NewCopyResult()
+	cr.ErrorLimit = 2
+	for i := 0; i < 5; i++ {
+		cr.HandleEntityErrors(&tl.Stop{StopID: "a"}, []error{causes.NewRequiredFieldError("stop_name")}, []error{causes.NewInvalidFieldError("stop_lat", "", nil)})
+	}
+	cr.HandleSourceErrors("stops.txt", []error{causes.NewFileRequiredError("stops.txt")}, nil)
+	if len(cr.Errors) != 3 || len(cr.Warnings) != 2 {
+		t.Errorf("got %d errors %d warnings, expected 3 errors 2 warnings", len(cr.Errors), len(cr.Warnings))
+	}
+	if v := cr.ErrorCount[ErrorKey{Filename: "stops.txt", Code: "RequiredFieldError"}]; v != 5 {
+		t.Errorf("got %d RequiredFieldError, expected 5", v)
+	}
+	if v := cr.ErrorCount[ErrorKey{Filename: "stops.txt", Code: "FileRequiredError"}]; v != 1 {
+		t.Errorf("got %d FileRequiredError, expected 1", v)
+	}
+	if v := cr.WarningCount[ErrorKey{Filename: "stops.txt", Code: "InvalidFieldError"}]; v != 5 {
+		t.Errorf("got %d InvalidFieldError, expected 5", v)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/interline-io/transitland-lib/tl"
 )
@@ -21,12 +22,14 @@ func arePositionsSorted(a []float64) bool {
 	return true
 }
 
-// geomCache helps speed up StopTime interpolating by caching various results
+// geomCache helps speed up StopTime interpolating by caching various results.
+// Stops and shapes must be added before calling MakeShape or InterpolateStopTimes concurrently.
 type geomCache struct {
 	positions map[string][]float64
 	stops     map[string][2]float64
-	shapes    map[string][][2]float64
+	shapes    copierCache // shape geometries may be stored on disk
 	lengths   map[string]float64
+	lock      sync.Mutex // guards positions and lengths
 }
 
 // newGeomCache returns an initialized geomCache
@@ -34,7 +37,7 @@ func newGeomCache() *geomCache {
 	return &geomCache{
 		positions: map[string][]float64{},
 		stops:     map[string][2]float64{},
-		shapes:    map[string][][2]float64{},
+		shapes:    newMemoryCache(),
 		lengths:   map[string]float64{},
 	}
 }
@@ -46,15 +49,15 @@ func (g *geomCache) AddStop(eid string, stop tl.Stop) {
 }
 
 // AddShape adds a Shape to the geometry cache.
-func (g *geomCache) AddShape(eid string, shape tl.Shape) {
+func (g *geomCache) AddShape(eid string, shape tl.Shape) error {
 	if !shape.Geometry.Valid {
-		return
+		return nil
 	}
 	sl := make([][2]float64, shape.Geometry.NumCoords())
 	for i, c := range shape.Geometry.Coords() {
 		sl[i] = [2]float64{c[0], c[1]}
 	}
-	g.shapes[eid] = sl
+	return g.shapes.AddShape(eid, sl)
 }
 
 // MakeShape returns geometry for the given stops.
@@ -86,12 +89,18 @@ func (g *geomCache) InterpolateStopTimes(trip tl.Trip, stoptimes []tl.StopTime)
 		}
 		stopline[i] = point
 	}
-	shapeline := g.shapes[shapeid]
+	shapeline, err := g.shapes.GetShape(shapeid)
+	if err != nil {
+		return stoptimes, err
+	}
 	// Check cache
+	g.lock.Lock()
 	positions, ok := g.positions[k]
+	length, lok := g.lengths[k]
+	g.lock.Unlock()
 	if !ok {
 		positions = linePositions(shapeline, stopline)
-		length := lengthHaversine(shapeline)
+		length = lengthHaversine(shapeline)
 		// Check for simple or fallback positions
 		if !arePositionsSorted(positions) || len(shapeline) == 0 {
 			// log.Debug("positions %f not increasing, falling back to stop positions; shapeline %f stopline %f", positions, shapeline, stopline)
@@ -101,11 +110,11 @@ func (g *geomCache) InterpolateStopTimes(trip tl.Trip, stoptimes []tl.StopTime)
 			}
 			length = lengthHaversine(stopline)
 		}
+		g.lock.Lock()
 		g.positions[k] = positions
 		g.lengths[k] = length
-	}
-	length, ok := g.lengths[k]
-	if !ok {
+		g.lock.Unlock()
+	} else if !lok {
 		return stoptimes, errors.New("could not get length from cache")
 	}
 	if len(stoptimes) != len(positions) {
@@ -0,0 +1,71 @@
+package copier
+
+import (
+	"fmt"
+
+	"github.com/interline-io/transitland-lib/tl"
+)
+
+// Hooks are called at points in the copy process; implement any of the interfaces below and register with AddHook.
+// Extensions and EntityFilters that implement a hook interface are registered automatically.
+// Returning an error stops the copy and sets the CopyResult WriteError.
+
+// BeforeFileGroupHook is called before each group of files is copied, e.g. "trips.txt" and "stop_times.txt".
+type BeforeFileGroupHook interface {
+	BeforeFileGroup(*Copier, []string) error
+}
+
+// AfterFileGroupHook is called after each group of files has been copied.
+type AfterFileGroupHook interface {
+	AfterFileGroup(*Copier, []string) error
+}
+
+// FileGroupHook wraps the copying of each group of files, e.g. to write each group in a transaction.
+// The hook must call the function once and return its error; BeforeFileGroup and AfterFileGroup hooks are called inside it.
+type FileGroupHook interface {
+	FileGroup(*Copier, []string, func() error) error
+}
+
+// AfterTripBatchHook is called after each batch of Trips and their StopTimes has been written.
+// The entities have been updated with the IDs assigned by the Writer.
+type AfterTripBatchHook interface {
+	AfterTripBatch(*Copier, []*tl.Trip, []*tl.StopTime) error
+}
+
+// AfterCopyHook is called after all files and extensions have been copied.
+type AfterCopyHook interface {
+	AfterCopy(*Copier) error
+}
+
+// AddHook adds a value that implements one or more hook interfaces.
+func (copier *Copier) AddHook(hook interface{}) error {
+	if !copier.addHook(hook) {
+		return fmt.Errorf("%T does not implement any copier hooks", hook)
+	}
+	return nil
+}
+
+func (copier *Copier) addHook(hook interface{}) bool {
+	found := false
+	if v, ok := hook.(BeforeFileGroupHook); ok {
+		copier.beforeFileGroupHooks = append(copier.beforeFileGroupHooks, v)
+		found = true
+	}
+	if v, ok := hook.(AfterFileGroupHook); ok {
+		copier.afterFileGroupHooks = append(copier.afterFileGroupHooks, v)
+		found = true
+	}
+	if v, ok := hook.(FileGroupHook); ok {
+		copier.fileGroupHooks = append(copier.fileGroupHooks, v)
+		found = true
+	}
+	if v, ok := hook.(AfterTripBatchHook); ok {
+		copier.afterTripBatchHooks = append(copier.afterTripBatchHooks, v)
+		found = true
+	}
+	if v, ok := hook.(AfterCopyHook); ok {
+		copier.afterCopyHooks = append(copier.afterCopyHooks, v)
+		found = true
+	}
+	return found
+}
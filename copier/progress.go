@@ -0,0 +1,173 @@
+package copier
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/interline-io/transitland-lib/tl"
+)
+
+// Progress describes how much of the source has been read.
+type Progress struct {
+	Filename      string        // current file
+	Count         int           // rows read from the current file
+	Total         int           // estimated rows in the current file, or 0 if unknown
+	TotalCount    int           // rows read from all files
+	TotalEstimate int           // estimated rows in all files, or 0 if unknown
+	Elapsed       time.Duration // time since the copy started
+}
+
+// Percent returns the estimated percentage of all rows that have been read, or 0 if unknown.
+func (p Progress) Percent() float64 {
+	if p.TotalEstimate <= 0 {
+		return 0
+	}
+	pct := 100 * float64(p.TotalCount) / float64(p.TotalEstimate)
+	if pct > 100 {
+		pct = 100
+	}
+	return pct
+}
+
+// Remaining returns the estimated time until all rows have been read, or 0 if unknown.
+func (p Progress) Remaining() time.Duration {
+	pct := p.Percent()
+	if pct <= 0 {
+		return 0
+	}
+	return time.Duration(float64(p.Elapsed) * (100 - pct) / pct)
+}
+
+// String returns a summary such as "stop_times.txt: 1000/2000 rows, 50.0% of all files, 10s elapsed, 10s remaining".
+func (p Progress) String() string {
+	s := fmt.Sprintf("%s: %d", p.Filename, p.Count)
+	if p.Total > 0 {
+		s += fmt.Sprintf("/%d", p.Total)
+	}
+	s += " rows"
+	if p.TotalEstimate > 0 {
+		s += fmt.Sprintf(", %0.1f%% of all files", p.Percent())
+	}
+	s += fmt.Sprintf(", %s elapsed", p.Elapsed.Round(time.Second))
+	if p.TotalEstimate > 0 {
+		s += fmt.Sprintf(", %s remaining", p.Remaining().Round(time.Second))
+	}
+	return s
+}
+
+// ProgressHandler is called periodically while copying.
+// It may be called from a different goroutine than Copy, but calls are never concurrent.
+type ProgressHandler interface {
+	HandleProgress(Progress)
+}
+
+// ProgressFunc is a function that can be used as a ProgressHandler.
+type ProgressFunc func(Progress)
+
+// HandleProgress calls the function.
+func (f ProgressFunc) HandleProgress(p Progress) {
+	f(p)
+}
+
+// progressTracker counts rows and calls the ProgressHandler at most once per interval.
+type progressTracker struct {
+	handler  ProgressHandler
+	interval time.Duration
+	start    time.Time
+	last     time.Time
+	totals   map[string]int
+	progress Progress
+}
+
+func newProgressTracker(handler ProgressHandler, interval time.Duration, totals map[string]int, filenames []string) *progressTracker {
+	pt := &progressTracker{
+		handler:  handler,
+		interval: interval,
+		start:    time.Now(),
+		last:     time.Now(),
+		totals:   totals,
+	}
+	for _, fn := range filenames {
+		pt.progress.TotalEstimate += totals[fn]
+	}
+	return pt
+}
+
+// add counts rows read from a file.
+func (pt *progressTracker) add(filename string, count int) {
+	if pt == nil || count == 0 {
+		return
+	}
+	if filename != pt.progress.Filename {
+		pt.progress.Filename = filename
+		pt.progress.Count = 0
+		pt.progress.Total = pt.totals[filename]
+	}
+	pt.progress.Count += count
+	pt.progress.TotalCount += count
+	if now := time.Now(); now.Sub(pt.last) >= pt.interval {
+		pt.last = now
+		pt.report()
+	}
+}
+
+// report calls the handler with the current progress.
+func (pt *progressTracker) report() {
+	if pt == nil {
+		return
+	}
+	pt.progress.Elapsed = time.Since(pt.start)
+	pt.handler.HandleProgress(pt.progress)
+}
+
+// progressIterator counts the rows read by an EntityIterator.
+type progressIterator struct {
+	tl.EntityIterator
+	pt *progressTracker
+}
+
+func (it progressIterator) Next() bool {
+	if !it.EntityIterator.Next() {
+		return false
+	}
+	ent := it.Value()
+	count := 1
+	if v, ok := ent.(*tl.Shape); ok {
+		// Each point is a row in shapes.txt
+		count = v.Geometry.NumCoords()
+	}
+	it.pt.add(ent.Filename(), count)
+	return true
+}
+
+// progressStopTimeIterator counts the rows read by a StopTimeIterator.
+type progressStopTimeIterator struct {
+	tl.StopTimeIterator
+	pt *progressTracker
+}
+
+func (it progressStopTimeIterator) Next() bool {
+	if !it.StopTimeIterator.Next() {
+		return false
+	}
+	it.pt.add("stop_times.txt", len(it.Value()))
+	return true
+}
+
+// newIterator returns an EntityIterator for the Reader that counts progress, if a ProgressHandler is set.
+func (copier *Copier) newIterator(ent tl.Entity) tl.EntityIterator {
+	it := tl.NewIterator(copier.Reader, ent)
+	if copier.progress == nil {
+		return it
+	}
+	return progressIterator{EntityIterator: it, pt: copier.progress}
+}
+
+// newStopTimeIterator returns a StopTimeIterator for the Reader that counts progress, if a ProgressHandler is set.
+func (copier *Copier) newStopTimeIterator() tl.StopTimeIterator {
+	it := tl.NewStopTimeIterator(copier.Reader)
+	if copier.progress == nil {
+		return it
+	}
+	return progressStopTimeIterator{StopTimeIterator: it, pt: copier.progress}
+}
@@ -0,0 +1,103 @@
+package copier
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/interline-io/transitland-lib/tl/causes"
+)
+
+// Rule severity levels.
+const (
+	RuleError    = "error"
+	RuleWarning  = "warning"
+	RuleSuppress = "suppress"
+)
+
+// Rule sets the severity of errors and warnings that match an error code, filename, and entity ID.
+// Empty values match anything.
+type Rule struct {
+	Code     string `json:"code"`
+	Filename string `json:"filename"`
+	EntityID string `json:"entity_id"`
+	Severity string `json:"severity"`
+}
+
+// Match checks if the rule applies to an error.
+func (rule *Rule) Match(filename string, eid string, err error) bool {
+	if rule.Filename != "" && rule.Filename != filename {
+		return false
+	} else if rule.EntityID != "" && rule.EntityID != eid {
+		return false
+	} else if rule.Code != "" && rule.Code != causes.ErrorCode(err) {
+		return false
+	}
+	return true
+}
+
+// RuleSet is an ordered list of Rules; the first matching Rule is used.
+type RuleSet struct {
+	Rules []Rule `json:"rules"`
+}
+
+// LoadRuleSet reads a RuleSet from a JSON file.
+func LoadRuleSet(filename string) (*RuleSet, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return ParseRuleSet(data)
+}
+
+// ParseRuleSet parses a RuleSet from JSON and checks each Rule has a valid severity.
+func ParseRuleSet(data []byte) (*RuleSet, error) {
+	rs := RuleSet{}
+	if err := json.Unmarshal(data, &rs); err != nil {
+		return nil, err
+	}
+	for i, rule := range rs.Rules {
+		switch rule.Severity {
+		case RuleError, RuleWarning, RuleSuppress:
+		default:
+			return nil, fmt.Errorf("rule %d: invalid severity '%s'", i, rule.Severity)
+		}
+	}
+	return &rs, nil
+}
+
+// Severity returns the severity of the first matching Rule, or the provided default.
+func (rs *RuleSet) Severity(filename string, eid string, err error, severity string) string {
+	if rs == nil {
+		return severity
+	}
+	for i := range rs.Rules {
+		if rs.Rules[i].Match(filename, eid, err) {
+			return rs.Rules[i].Severity
+		}
+	}
+	return severity
+}
+
+// Apply sorts errors and warnings by the severity of their matching Rules, dropping any that are suppressed.
+func (rs *RuleSet) Apply(filename string, eid string, errs []error, warns []error) ([]error, []error) {
+	if rs == nil || len(rs.Rules) == 0 {
+		return errs, warns
+	}
+	var outerrs, outwarns []error
+	check := func(err error, severity string) {
+		switch rs.Severity(filename, eid, err, severity) {
+		case RuleError:
+			outerrs = append(outerrs, err)
+		case RuleWarning:
+			outwarns = append(outwarns, err)
+		}
+	}
+	for _, err := range errs {
+		check(err, RuleError)
+	}
+	for _, err := range warns {
+		check(err, RuleWarning)
+	}
+	return outerrs, outwarns
+}
@@ -0,0 +1,72 @@
+package copier
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/interline-io/transitland-lib/internal/mock"
+	"github.com/interline-io/transitland-lib/tl"
+	"github.com/interline-io/transitland-lib/tl/causes"
+)
+
+func TestParseRuleSet(t *testing.T) {
+	if _, err := ParseRuleSet([]byte(`{"rules":[{"code":"RequiredFieldError","severity":"warning"}]}`)); err != nil {
+		t.Error(err)
+	}
+	if _, err := ParseRuleSet([]byte(`{"rules":[{"code":"RequiredFieldError","severity":"ignore"}]}`)); err == nil {
+		t.Errorf("expected error for invalid severity")
+	}
+}
+
+func TestRuleSet_Apply(t *testing.T) {
+	rs := &RuleSet{Rules: []Rule{
+		{Code: "RequiredFieldError", Filename: "stops.txt", EntityID: "a", Severity: RuleSuppress},
+		{Code: "RequiredFieldError", Filename: "stops.txt", Severity: RuleWarning},
+		{Filename: "trips.txt", Severity: RuleError},
+	}}
+	reqerr := causes.NewRequiredFieldError("stop_name")
+	tcs := []struct {
+		name      string
+		filename  string
+		eid       string
+		errs      []error
+		warns     []error
+		expectErr int
+		expectWrn int
+	}{
+		{"suppress", "stops.txt", "a", []error{reqerr}, nil, 0, 0},
+		{"downgrade", "stops.txt", "b", []error{reqerr}, nil, 0, 1},
+		{"no match", "routes.txt", "b", []error{reqerr}, nil, 1, 0},
+		{"promote", "trips.txt", "c", nil, []error{errors.New("test")}, 1, 0},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			errs, warns := rs.Apply(tc.filename, tc.eid, tc.errs, tc.warns)
+			if len(errs) != tc.expectErr || len(warns) != tc.expectWrn {
+				t.Errorf("got %d errors %d warnings, expected %d errors %d warnings", len(errs), len(warns), tc.expectErr, tc.expectWrn)
+			}
+		})
+	}
+	// A nil RuleSet passes everything through
+	var nilrs *RuleSet
+	if errs, _ := nilrs.Apply("stops.txt", "a", []error{reqerr}, nil); len(errs) != 1 {
+		t.Errorf("expected nil RuleSet to keep errors")
+	}
+}
+
+func TestCopier_Rules(t *testing.T) {
+	reader := mock.NewReader()
+	reader.AgencyList = []tl.Agency{
+		{AgencyID: "a", AgencyURL: "http://example.com", AgencyTimezone: "America/Los_Angeles"},
+	}
+	writer := mock.NewWriter()
+	cp := NewCopier(reader, writer)
+	cp.Rules = &RuleSet{Rules: []Rule{{Code: "RequiredFieldError", Filename: "agency.txt", Severity: RuleWarning}}}
+	result := cp.Copy()
+	if result.EntityCount["agency.txt"] != 1 {
+		t.Errorf("expected agency to be copied")
+	}
+	if len(result.Errors) != 0 || len(result.Warnings) != 1 {
+		t.Errorf("got %d errors %d warnings, expected 0 errors 1 warning", len(result.Errors), len(result.Warnings))
+	}
+}
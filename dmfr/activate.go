@@ -0,0 +1,210 @@
+package dmfr
+
+import (
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/interline-io/transitland-lib/internal/log"
+	"github.com/interline-io/transitland-lib/tl"
+	"github.com/interline-io/transitland-lib/tldb"
+)
+
+// ActivateOptions sets options for selecting the active feed version of each feed by service window.
+type ActivateOptions struct {
+	FeedIDs []string  // only these feeds, by Onestop ID; all feeds if empty
+	Now     time.Time // the date to select service for; defaults to today
+	DryRun  bool      // do not change the active feed versions
+}
+
+// ActivateResult is the feed version selected for a feed.
+type ActivateResult struct {
+	FeedID                int
+	OnestopID             string
+	PreviousFeedVersionID int    // 0 if the feed had no active feed version
+	FeedVersionID         int    // 0 if no feed version was selected; the active feed version is not changed
+	Reason                string // why the feed version was selected
+}
+
+// Changed returns true if the selected feed version is not already active.
+func (r ActivateResult) Changed() bool {
+	return r.FeedVersionID != 0 && r.FeedVersionID != r.PreviousFeedVersionID
+}
+
+// activateCandidate is a successfully imported feed version and its service window.
+type activateCandidate struct {
+	ID                   int
+	FeedID               int
+	OnestopID            string
+	FetchedAt            time.Time
+	EarliestCalendarDate time.Time
+	LatestCalendarDate   time.Time
+	windows              [][2]time.Time // service level date ranges with service
+}
+
+// covers returns true if the feed version has service in the week containing the date.
+// Without service levels, the calendar date range is used.
+func (c *activateCandidate) covers(date time.Time) bool {
+	if len(c.windows) == 0 {
+		return !c.EarliestCalendarDate.After(date) && !c.LatestCalendarDate.Before(date)
+	}
+	for _, w := range c.windows {
+		if !w[0].After(date) && !w[1].Before(date) {
+			return true
+		}
+	}
+	return false
+}
+
+// nextService returns the first date of service after the date, or a zero time.
+func (c *activateCandidate) nextService(date time.Time) time.Time {
+	next := time.Time{}
+	if len(c.windows) == 0 {
+		if c.EarliestCalendarDate.After(date) {
+			next = c.EarliestCalendarDate
+		}
+		return next
+	}
+	for _, w := range c.windows {
+		if w[0].After(date) && (next.IsZero() || w[0].Before(next)) {
+			next = w[0]
+		}
+	}
+	return next
+}
+
+// SelectActiveFeedVersions selects the active feed version for each feed with a successfully imported feed version.
+// The most recently fetched feed version with service on the date is selected.
+// If no feed version has service on the date, the feed version with the earliest upcoming service is selected.
+// Otherwise, no feed version is selected and the active feed version should be left unchanged.
+func SelectActiveFeedVersions(adapter tldb.Adapter, opts ActivateOptions) ([]ActivateResult, error) {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now().UTC()
+	}
+	now = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	// Successfully imported feed versions, most recently fetched first
+	candidates := []*activateCandidate{}
+	q := adapter.Sqrl().
+		Select(
+			"feed_versions.id",
+			"feed_versions.feed_id",
+			"current_feeds.onestop_id",
+			"feed_versions.fetched_at",
+			"feed_versions.earliest_calendar_date",
+			"feed_versions.latest_calendar_date",
+		).
+		From("feed_versions").
+		Join("current_feeds ON current_feeds.id = feed_versions.feed_id").
+		Join("feed_version_gtfs_imports ON feed_version_gtfs_imports.feed_version_id = feed_versions.id").
+		Where(sq.Eq{"feed_version_gtfs_imports.success": true}).
+		OrderBy("feed_versions.feed_id", "feed_versions.fetched_at DESC", "feed_versions.id DESC")
+	if len(opts.FeedIDs) > 0 {
+		q = q.Where(sq.Eq{"current_feeds.onestop_id": opts.FeedIDs})
+	}
+	qstr, args, err := q.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	if err := adapter.Select(&candidates, qstr, args...); err != nil {
+		return nil, err
+	}
+	// Feed-wide service levels
+	levels := []FeedVersionServiceLevel{}
+	qstr, args, err = adapter.Sqrl().
+		Select("feed_version_service_levels.*").
+		From("feed_version_service_levels").
+		Join("feed_version_gtfs_imports ON feed_version_gtfs_imports.feed_version_id = feed_version_service_levels.feed_version_id").
+		Where(sq.Eq{"feed_version_gtfs_imports.success": true}).
+		Where(sq.Eq{"feed_version_service_levels.route_id": nil}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+	if err := adapter.Select(&levels, qstr, args...); err != nil {
+		return nil, err
+	}
+	windows := map[int][][2]time.Time{}
+	for _, sl := range levels {
+		if sl.Monday+sl.Tuesday+sl.Wednesday+sl.Thursday+sl.Friday+sl.Saturday+sl.Sunday > 0 {
+			windows[sl.FeedVersionID] = append(windows[sl.FeedVersionID], [2]time.Time{sl.StartDate, sl.EndDate})
+		}
+	}
+	// Current active feed versions
+	states := []FeedState{}
+	if err := adapter.Select(&states, "SELECT * FROM feed_states WHERE feed_version_id IS NOT NULL"); err != nil {
+		return nil, err
+	}
+	active := map[int]int{}
+	for _, state := range states {
+		active[state.FeedID] = int(state.FeedVersionID.Int64)
+	}
+	// Select for each feed
+	results := []ActivateResult{}
+	for i := 0; i < len(candidates); {
+		feedID := candidates[i].FeedID
+		r := ActivateResult{FeedID: feedID, OnestopID: candidates[i].OnestopID, PreviousFeedVersionID: active[feedID]}
+		var next time.Time
+		for ; i < len(candidates) && candidates[i].FeedID == feedID; i++ {
+			c := candidates[i]
+			c.windows = windows[c.ID]
+			if r.Reason == "current service" {
+				continue
+			}
+			if c.covers(now) {
+				r.FeedVersionID = c.ID
+				r.Reason = "current service"
+			} else if n := c.nextService(now); !n.IsZero() && (next.IsZero() || n.Before(next)) {
+				next = n
+				r.FeedVersionID = c.ID
+				r.Reason = "next service on " + n.Format("2006-01-02")
+			}
+		}
+		if r.FeedVersionID == 0 {
+			r.Reason = "no current or upcoming service"
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// MainActivate selects and activates the feed version for each feed; see SelectActiveFeedVersions.
+// Each feed is updated in a separate Tx. Feed versions that are already active are not activated again.
+func MainActivate(adapter tldb.Adapter, opts ActivateOptions) ([]ActivateResult, error) {
+	results, err := SelectActiveFeedVersions(adapter, opts)
+	if err != nil || opts.DryRun {
+		return results, err
+	}
+	for _, r := range results {
+		if !r.Changed() {
+			continue
+		}
+		err := adapter.Tx(func(atx tldb.Adapter) error {
+			return setActiveFeedVersion(atx, r.FeedID, r.FeedVersionID)
+		})
+		if err != nil {
+			return results, err
+		}
+		log.Info("Activated feed version %d for feed %s: %s", r.FeedVersionID, r.OnestopID, r.Reason)
+	}
+	return results, nil
+}
+
+// setActiveFeedVersion activates the feed version and saves it in the feed state.
+// It must be run inside a transaction.
+func setActiveFeedVersion(atx tldb.Adapter, feedID int, fvid int) error {
+	if err := ActivateFeedVersion(atx, fvid); err != nil {
+		return err
+	}
+	state := FeedState{FeedID: feedID}
+	if err := atx.Get(&state, `SELECT * FROM feed_states WHERE feed_id = ?`, feedID); err == sql.ErrNoRows {
+		if state.ID, err = atx.Insert(&state); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	}
+	state.FeedVersionID = tl.OptionalKey{NullInt64: sql.NullInt64{Int64: int64(fvid), Valid: true}}
+	state.UpdateTimestamps()
+	return atx.Update(&state, "feed_version_id", "updated_at")
+}
@@ -0,0 +1,67 @@
+package dmfr
+
+import (
+	"flag"
+	"os"
+	"time"
+
+	"github.com/interline-io/transitland-lib/internal/log"
+	"github.com/interline-io/transitland-lib/tldb"
+)
+
+// ActivateCommand activates the feed version of each feed with service on the current date.
+type ActivateCommand struct {
+	DBURL           string
+	ActivateOptions ActivateOptions
+	adapter         tldb.Adapter
+}
+
+// Parse command line options.
+func (cmd *ActivateCommand) Parse(args []string) error {
+	feedIDs := arrayFlags{}
+	date := ""
+	fl := flag.NewFlagSet("activate", flag.ExitOnError)
+	fl.Usage = func() {
+		log.Print("Usage: activate [-feed onestop_id...] [-date YYYY-MM-DD]")
+		fl.PrintDefaults()
+	}
+	fl.StringVar(&cmd.DBURL, "dburl", "", "Database URL (default: $DMFR_DATABASE_URL)")
+	fl.Var(&feedIDs, "feed", "Only activate a feed version for this feed; may be specified multiple times")
+	fl.StringVar(&date, "date", "", "Select feed versions with service on this date, YYYY-MM-DD (default: today)")
+	fl.BoolVar(&cmd.ActivateOptions.DryRun, "dryrun", false, "Print the selected feed versions; do not activate them")
+	fl.Parse(args)
+	if cmd.DBURL == "" {
+		cmd.DBURL = os.Getenv("DMFR_DATABASE_URL")
+	}
+	cmd.ActivateOptions.FeedIDs = feedIDs
+	if date != "" {
+		var err error
+		if cmd.ActivateOptions.Now, err = time.Parse("2006-01-02", date); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run this command.
+func (cmd *ActivateCommand) Run() error {
+	if cmd.adapter == nil {
+		writer := mustGetWriter(cmd.DBURL, false)
+		cmd.adapter = writer.Adapter
+		defer writer.Close()
+	}
+	results, err := MainActivate(cmd.adapter, cmd.ActivateOptions)
+	for _, r := range results {
+		action, fvid := "unchanged", r.FeedVersionID
+		if fvid == 0 {
+			fvid = r.PreviousFeedVersionID
+		}
+		if r.Changed() && cmd.ActivateOptions.DryRun {
+			action = "activate"
+		} else if r.Changed() {
+			action = "activated"
+		}
+		log.Print("%s\t%d -> %d\t%s: %s", r.OnestopID, r.PreviousFeedVersionID, fvid, action, r.Reason)
+	}
+	return err
+}
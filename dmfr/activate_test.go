@@ -0,0 +1,138 @@
+package dmfr
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/interline-io/transitland-lib/internal/testdb"
+	"github.com/interline-io/transitland-lib/tldb"
+)
+
+func testDate(s string) time.Time {
+	t, _ := time.Parse("2006-01-02", s)
+	return t
+}
+
+// setupActivate creates successfully imported feed versions fetched on consecutive days, oldest first, with the given calendar date ranges.
+func setupActivate(t *testing.T, atx tldb.Adapter, ranges [][2]string) (Feed, []int) {
+	fvs := []testFeedVersion{}
+	for _, r := range ranges {
+		fvs = append(fvs, testFeedVersion{Import: "success", EarliestCalendarDate: testDate(r[0]), LatestCalendarDate: testDate(r[1])})
+	}
+	return createTestFeedVersions(t, atx, testDate("2019-12-01"), fvs)
+}
+
+func TestSelectActiveFeedVersions(t *testing.T) {
+	ranges := [][2]string{
+		{"2020-01-01", "2020-01-31"},
+		{"2020-02-01", "2020-02-29"},
+		{"2020-01-15", "2020-03-31"}, // overlaps, fetched last
+	}
+	tcs := []struct {
+		name   string
+		now    string
+		expect int // index in ranges, or -1
+	}{
+		{"only", "2020-01-10", 0},
+		{"overlap prefers newest fetch", "2020-01-20", 2},
+		{"newest covers", "2020-02-10", 2},
+		{"upcoming", "2019-12-15", 0},
+		{"expired", "2021-01-01", -1},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
+				f, fvids := setupActivate(t, atx, ranges)
+				results, err := SelectActiveFeedVersions(atx, ActivateOptions{Now: testDate(tc.now)})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(results) != 1 || results[0].FeedID != f.ID {
+					t.Fatalf("got %v, expected one result for feed %d", results, f.ID)
+				}
+				expect := 0
+				if tc.expect >= 0 {
+					expect = fvids[tc.expect]
+				}
+				if got := results[0].FeedVersionID; got != expect {
+					t.Errorf("got feed version %d, expected %d (%s)", got, expect, results[0].Reason)
+				}
+				return nil
+			})
+		})
+	}
+	t.Run("service levels", func(t *testing.T) {
+		testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
+			// Service levels take precedence over the calendar date range
+			_, fvids := setupActivate(t, atx, [][2]string{{"2020-01-01", "2020-01-31"}, {"2020-01-01", "2020-12-31"}})
+			testdb.ShouldInsert(t, atx, &FeedVersionServiceLevel{FeedVersionID: fvids[1], StartDate: testDate("2020-01-06"), EndDate: testDate("2020-01-12"), Monday: 3600})
+			testdb.ShouldInsert(t, atx, &FeedVersionServiceLevel{FeedVersionID: fvids[1], StartDate: testDate("2020-06-01"), EndDate: testDate("2020-06-07"), Monday: 3600})
+			for now, expect := range map[string]int{"2020-01-08": fvids[1], "2020-01-20": fvids[0]} {
+				results, err := SelectActiveFeedVersions(atx, ActivateOptions{Now: testDate(now)})
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(results) != 1 || results[0].FeedVersionID != expect {
+					t.Errorf("%s: got %v, expected feed version %d", now, results, expect)
+				}
+			}
+			return nil
+		})
+	})
+}
+
+func TestMainActivate(t *testing.T) {
+	ranges := [][2]string{{"2020-01-01", "2020-01-31"}, {"2020-02-01", "2020-02-29"}}
+	getActive := func(atx tldb.Adapter, feedID int) int {
+		fvid := sql.NullInt64{}
+		atx.Get(&fvid, "SELECT feed_version_id FROM feed_states WHERE feed_id = ?", feedID)
+		return int(fvid.Int64)
+	}
+	t.Run("Activate", func(t *testing.T) {
+		testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
+			f, fvids := setupActivate(t, atx, ranges)
+			adapter := &testdb.AdapterIgnoreTx{Adapter: atx}
+			if _, err := MainActivate(adapter, ActivateOptions{Now: testDate("2020-01-10")}); err != nil {
+				t.Fatal(err)
+			}
+			if got := getActive(atx, f.ID); got != fvids[0] {
+				t.Errorf("got active feed version %d, expected %d", got, fvids[0])
+			}
+			results, err := MainActivate(adapter, ActivateOptions{Now: testDate("2020-02-10")})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(results) != 1 || results[0].PreviousFeedVersionID != fvids[0] || !results[0].Changed() {
+				t.Errorf("got %v, expected change from feed version %d", results, fvids[0])
+			}
+			if got := getActive(atx, f.ID); got != fvids[1] {
+				t.Errorf("got active feed version %d, expected %d", got, fvids[1])
+			}
+			// Expired; the active feed version is not changed
+			if _, err := MainActivate(adapter, ActivateOptions{Now: testDate("2021-01-01")}); err != nil {
+				t.Fatal(err)
+			}
+			if got := getActive(atx, f.ID); got != fvids[1] {
+				t.Errorf("got active feed version %d, expected %d", got, fvids[1])
+			}
+			return nil
+		})
+	})
+	t.Run("DryRun", func(t *testing.T) {
+		testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
+			f, fvids := setupActivate(t, atx, ranges)
+			results, err := MainActivate(&testdb.AdapterIgnoreTx{Adapter: atx}, ActivateOptions{Now: testDate("2020-01-10"), DryRun: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(results) != 1 || results[0].FeedVersionID != fvids[0] {
+				t.Errorf("got %v, expected feed version %d", results, fvids[0])
+			}
+			if got := getActive(atx, f.ID); got != 0 {
+				t.Errorf("got active feed version %d, expected none", got)
+			}
+			return nil
+		})
+	})
+}
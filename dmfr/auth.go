@@ -1,6 +1,7 @@
 package dmfr
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -16,7 +17,7 @@ import (
 	"github.com/jlaffaye/ftp"
 )
 
-func downloadHTTP(ustr string, fn string, secret Secret, auth tl.FeedAuthorization) error {
+func downloadHTTP(ctx context.Context, ustr string, fn string, secret Secret, auth tl.FeedAuthorization) error {
 	w, err := os.Create(fn)
 	if err != nil {
 		return err
@@ -30,6 +31,7 @@ func downloadHTTP(ustr string, fn string, secret Secret, auth tl.FeedAuthorizati
 	if err != nil {
 		return err
 	}
+	req = req.WithContext(ctx)
 	if auth.Type == "basic_auth" {
 		req.SetBasicAuth(secret.Username, secret.Password)
 	} else if auth.Type == "header" {
@@ -45,7 +47,7 @@ func downloadHTTP(ustr string, fn string, secret Secret, auth tl.FeedAuthorizati
 	return nil
 }
 
-func downloadFTP(ustr string, fn string, secret Secret, auth tl.FeedAuthorization) error {
+func downloadFTP(ctx context.Context, ustr string, fn string, secret Secret, auth tl.FeedAuthorization) error {
 	w, err := os.Create(fn)
 	if err != nil {
 		return err
@@ -60,7 +62,7 @@ func downloadFTP(ustr string, fn string, secret Secret, auth tl.FeedAuthorizatio
 	if p == "" {
 		p = "21"
 	}
-	c, err := ftp.Dial(fmt.Sprintf("%s:%s", u.Hostname(), p), ftp.DialWithTimeout(600*time.Second))
+	c, err := ftp.Dial(fmt.Sprintf("%s:%s", u.Hostname(), p), ftp.DialWithTimeout(600*time.Second), ftp.DialWithContext(ctx))
 	if err != nil {
 		return err
 	}
@@ -82,8 +84,8 @@ func downloadFTP(ustr string, fn string, secret Secret, auth tl.FeedAuthorizatio
 	return nil
 }
 
-func downloadS3(ustr string, fn string, secret Secret, auth tl.FeedAuthorization) error {
-	awscmd := exec.Command("aws", "s3", "cp", ustr, fn)
+func downloadS3(ctx context.Context, ustr string, fn string, secret Secret, auth tl.FeedAuthorization) error {
+	awscmd := exec.CommandContext(ctx, "aws", "s3", "cp", ustr, fn)
 	if secret.AWSAccessKeyID != "" || secret.AWSSecretAccessKey != "" {
 		env := []string{
 			fmt.Sprintf("AWS_ACCESS_KEY_ID=%s", secret.AWSAccessKeyID),
@@ -100,6 +102,12 @@ func downloadS3(ustr string, fn string, secret Secret, auth tl.FeedAuthorization
 
 // AuthenticatedRequest fetches a url using a secret and auth description. Returns temp file path or error.
 func AuthenticatedRequest(address string, secret Secret, auth tl.FeedAuthorization) (string, error) {
+	return AuthenticatedRequestContext(context.Background(), address, secret, auth)
+}
+
+// AuthenticatedRequestContext is like AuthenticatedRequest, but stops the request when the context is done.
+// Requests also time out after 600 seconds.
+func AuthenticatedRequestContext(ctx context.Context, address string, secret Secret, auth tl.FeedAuthorization) (string, error) {
 	u, err := url.Parse(address)
 	if err != nil {
 		return "", err
@@ -115,7 +123,9 @@ func AuthenticatedRequest(address string, secret Secret, auth tl.FeedAuthorizati
 		u.Path = strings.ReplaceAll(u.Path, "{}", secret.Key)
 	}
 	// prepare worker
-	ch := make(chan error)
+	ctx, cancel := context.WithTimeout(ctx, 600*time.Second)
+	defer cancel()
+	ch := make(chan error, 1)
 	ustr := u.String()
 	tmpfile, err := ioutil.TempFile("", "fetch")
 	if err != nil {
@@ -126,29 +136,26 @@ func AuthenticatedRequest(address string, secret Secret, auth tl.FeedAuthorizati
 	go func() {
 		var err error
 		if u.Scheme == "http" || u.Scheme == "https" {
-			err = downloadHTTP(ustr, tmpfilepath, secret, auth)
+			err = downloadHTTP(ctx, ustr, tmpfilepath, secret, auth)
 		} else if u.Scheme == "ftp" {
-			err = downloadFTP(ustr, tmpfilepath, secret, auth)
+			err = downloadFTP(ctx, ustr, tmpfilepath, secret, auth)
 		} else if u.Scheme == "s3" {
-			err = downloadS3(ustr, tmpfilepath, secret, auth)
+			err = downloadS3(ctx, ustr, tmpfilepath, secret, auth)
 		} else {
 			err = errors.New("unknown handler")
 		}
 		ch <- err
 	}()
-	// prepare timeout
-	timeout := make(chan bool, 1)
-	go func() {
-		time.Sleep(600 * time.Second)
-		timeout <- true
-	}()
 	select {
 	case a := <-ch:
 		if a != nil {
 			return "", a
 		}
-	case <-timeout:
-		return "", errors.New("operation timed out")
+	case <-ctx.Done():
+		if ctx.Err() == context.DeadlineExceeded {
+			return "", errors.New("operation timed out")
+		}
+		return "", ctx.Err()
 	}
 	return tmpfilepath, nil
 }
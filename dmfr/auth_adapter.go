@@ -1,6 +1,7 @@
 package dmfr
 
 import (
+	"context"
 	"errors"
 	"net/url"
 	"os"
@@ -18,13 +19,18 @@ type AuthenticatedURLAdapter struct {
 
 // Download the URL to a temporary file and set the correct adapter
 func (adapter *AuthenticatedURLAdapter) Download(address string, auth tl.FeedAuthorization, secret Secret) error {
+	return adapter.DownloadContext(context.Background(), address, auth, secret)
+}
+
+// DownloadContext is like Download, but stops the download when the context is done.
+func (adapter *AuthenticatedURLAdapter) DownloadContext(ctx context.Context, address string, auth tl.FeedAuthorization, secret Secret) error {
 	// Handle fragments
 	u, err := url.Parse(address)
 	if err != nil {
 		return err
 	}
 	// Download feed
-	tmpfile, err := AuthenticatedRequest(address, secret, auth)
+	tmpfile, err := AuthenticatedRequestContext(ctx, address, secret, auth)
 	if err != nil {
 		return err
 	}
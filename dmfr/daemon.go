@@ -0,0 +1,228 @@
+package dmfr
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/interline-io/transitland-lib/internal/log"
+	"github.com/interline-io/transitland-lib/tl"
+	"github.com/interline-io/transitland-lib/tldb"
+)
+
+// DaemonOptions sets options for continuously fetching and importing feeds.
+type DaemonOptions struct {
+	FetchOptions  FetchOptions  // options for each fetch; FeedID and FeedURL are ignored
+	ImportOptions ImportOptions // options for each import; FeedVersionID is ignored
+	FeedIDs       []string      // only these feeds, by Onestop ID; default all feeds
+	Interval      time.Duration // fetch interval for feeds without FeedState.FetchInterval
+	Poll          time.Duration // how often to check for feeds that are due
+	Workers       int           // maximum number of feeds fetched and imported at the same time
+	Activate      bool          // select the active feed version by service window after each import; see MainActivate
+}
+
+// DaemonResult is the result of fetching and importing a feed.
+type DaemonResult struct {
+	FeedID       string // Onestop ID
+	FetchResult  FetchResult
+	ImportResult ImportResult
+	Imported     bool // a feed version was imported, successfully or not
+	Activated    int  // the feed version activated after the import, if changed
+	Error        error
+}
+
+type daemonFeed struct {
+	OnestopID     string
+	LastFetchedAt tl.OptionalTime
+	FetchInterval sql.NullInt64
+}
+
+// FindDueFeeds returns the feeds that are due to be fetched, by Onestop ID, least recently fetched first.
+// A feed is due if it has not been fetched, or if its fetch interval has passed since it was last fetched.
+// The fetch interval is FeedState.FetchInterval in seconds, or opts.Interval if not set; feeds with an interval of 0 or less are not fetched.
+func FindDueFeeds(adapter tldb.Adapter, opts DaemonOptions, now time.Time) ([]string, error) {
+	feeds := []daemonFeed{}
+	q := adapter.Sqrl().
+		Select("current_feeds.onestop_id", "feed_states.last_fetched_at", "feed_states.fetch_interval").
+		From("current_feeds").
+		LeftJoin("feed_states ON feed_states.feed_id = current_feeds.id").
+		Where("current_feeds.deleted_at IS NULL").
+		Where(sq.Eq{"current_feeds.spec": "gtfs"}).
+		OrderBy("current_feeds.id")
+	if len(opts.FeedIDs) > 0 {
+		q = q.Where(sq.Eq{"current_feeds.onestop_id": opts.FeedIDs})
+	}
+	qstr, args, err := q.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	if err := adapter.Select(&feeds, qstr, args...); err != nil {
+		return nil, err
+	}
+	due := []daemonFeed{}
+	for _, feed := range feeds {
+		interval := opts.Interval
+		if feed.FetchInterval.Valid {
+			interval = time.Duration(feed.FetchInterval.Int64) * time.Second
+		}
+		if interval <= 0 {
+			continue
+		}
+		if !feed.LastFetchedAt.Valid || !feed.LastFetchedAt.Time.Add(interval).After(now) {
+			due = append(due, feed)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		a, b := due[i].LastFetchedAt, due[j].LastFetchedAt
+		if !a.Valid || !b.Valid {
+			return !a.Valid && b.Valid
+		}
+		return a.Time.Before(b.Time)
+	})
+	ret := []string{}
+	for _, feed := range due {
+		ret = append(ret, feed.OnestopID)
+	}
+	return ret, nil
+}
+
+// MainDaemon fetches and imports feeds as they become due, until stop is done; see FindDueFeeds and DaemonFetchFeed.
+// Due feeds are checked when MainDaemon starts and then every opts.Poll.
+// After stop is done, no new feeds are started and MainDaemon returns when the running feeds are finished.
+// Cancelling ctx also cancels the running fetches and imports, which are rolled back.
+// Each result is passed to handler, if not nil.
+func MainDaemon(ctx context.Context, stop context.Context, adapter tldb.Adapter, opts DaemonOptions, handler func(DaemonResult)) error {
+	workers := opts.Workers
+	if workers < 1 {
+		workers = 1
+	}
+	poll := opts.Poll
+	if poll <= 0 {
+		poll = time.Minute
+	}
+	ticker := time.NewTicker(poll)
+	defer ticker.Stop()
+	running := map[string]bool{}
+	results := make(chan DaemonResult)
+	stopped := stop.Done()
+	launch := true
+	for {
+		// Only start feeds on a poll, not after each result,
+		// so that a feed that keeps failing is not started again right away.
+		if launch && stopped != nil && len(running) < workers {
+			due, err := FindDueFeeds(adapter, opts, time.Now().UTC())
+			if err != nil {
+				// Try again at the next poll
+				log.Error("Could not find feeds to fetch: %s", err.Error())
+			}
+			for _, feedID := range due {
+				if len(running) >= workers {
+					break
+				}
+				if running[feedID] {
+					continue
+				}
+				running[feedID] = true
+				go func(feedID string) {
+					results <- DaemonFetchFeed(ctx, adapter, opts, feedID)
+				}(feedID)
+			}
+		}
+		launch = false
+		if stopped == nil && len(running) == 0 {
+			return nil
+		}
+		select {
+		case r := <-results:
+			delete(running, r.FeedID)
+			if handler != nil {
+				handler(r)
+			}
+		case <-ticker.C:
+			launch = true
+		case <-stopped:
+			log.Info("Stopping; waiting for %d running feeds", len(running))
+			stopped = nil
+		}
+	}
+}
+
+// DaemonFetchFeed fetches a feed, imports the fetched feed version if it has not been imported, and optionally selects the active feed version.
+// The import also updates the tables derived from the feed version; see AfterFeedVersionImport.
+// A feed version that was fetched but not imported, e.g. because the daemon was stopped, is imported when the same file is fetched again.
+// Failed or abandoned imports are only run again if opts.ImportOptions allows it; see MainImportFeedVersionContext.
+// If the fetch fails with an error, the fetch is rolled back but the attempt is still saved in the feed state,
+// so the feed is not due again until its fetch interval has passed.
+func DaemonFetchFeed(ctx context.Context, adapter tldb.Adapter, opts DaemonOptions, feedID string) DaemonResult {
+	r := DaemonResult{FeedID: feedID}
+	fetchOpts := opts.FetchOptions
+	fetchOpts.FeedID = feedID
+	fetchOpts.FeedURL = ""
+	fetchOpts.FetchedAt = time.Now().UTC()
+	r.Error = adapter.Tx(func(atx tldb.Adapter) error {
+		var err error
+		r.FetchResult, err = DatabaseFetchContext(ctx, atx, fetchOpts)
+		return err
+	})
+	if r.Error != nil && ctx.Err() == nil {
+		if err := saveFetchError(adapter, feedID, fetchOpts.FetchedAt, r.Error); err != nil {
+			log.Error("Could not save fetch error for feed %s: %s", feedID, err.Error())
+		}
+	}
+	fvid := r.FetchResult.FeedVersion.ID
+	if r.Error != nil || r.FetchResult.FetchError != nil || fvid == 0 {
+		return r
+	}
+	// Check for an existing import
+	checkfvi := FeedVersionImport{}
+	if err := adapter.Get(&checkfvi, `SELECT id, success, in_progress, updated_at FROM feed_version_gtfs_imports WHERE feed_version_id = ?`, fvid); err == sql.ErrNoRows {
+		// ok
+	} else if err != nil {
+		r.Error = err
+		return r
+	} else if !opts.ImportOptions.canRetry(checkfvi, time.Now()) {
+		return r
+	}
+	importOpts := opts.ImportOptions
+	importOpts.FeedVersionID = fvid
+	r.Imported = true
+	r.ImportResult, r.Error = MainImportFeedVersionContext(ctx, adapter, importOpts)
+	if r.Error != nil || !r.ImportResult.FeedVersionImport.Success || !opts.Activate {
+		return r
+	}
+	activated, err := MainActivate(adapter, ActivateOptions{FeedIDs: []string{feedID}})
+	if err != nil {
+		r.Error = err
+		return r
+	}
+	for _, a := range activated {
+		if a.Changed() {
+			r.Activated = a.FeedVersionID
+		}
+	}
+	return r
+}
+
+// saveFetchError saves a failed fetch attempt in the feed state.
+func saveFetchError(adapter tldb.Adapter, feedID string, fetchedAt time.Time, fetchErr error) error {
+	return adapter.Tx(func(atx tldb.Adapter) error {
+		tlfeed := Feed{}
+		if err := atx.Get(&tlfeed, `SELECT * FROM current_feeds WHERE onestop_id = ?`, feedID); err != nil {
+			return err
+		}
+		tlstate := FeedState{FeedID: tlfeed.ID}
+		if err := atx.Get(&tlstate, `SELECT * FROM feed_states WHERE feed_id = ?`, tlfeed.ID); err == sql.ErrNoRows {
+			if tlstate.ID, err = atx.Insert(&tlstate); err != nil {
+				return err
+			}
+		} else if err != nil {
+			return err
+		}
+		tlstate.LastFetchedAt = tl.OptionalTime{Time: fetchedAt, Valid: true}
+		tlstate.LastFetchError = fetchErr.Error()
+		tlstate.UpdateTimestamps()
+		return atx.Update(&tlstate, "last_fetched_at", "last_fetch_error", "updated_at")
+	})
+}
@@ -0,0 +1,99 @@
+package dmfr
+
+import (
+	"flag"
+	"os"
+	"time"
+
+	"github.com/interline-io/transitland-lib/copier"
+	"github.com/interline-io/transitland-lib/internal/log"
+	"github.com/interline-io/transitland-lib/tldb"
+)
+
+// DaemonCommand continuously fetches and imports feeds.
+type DaemonCommand struct {
+	DBURL         string
+	DaemonOptions DaemonOptions
+	adapter       tldb.Adapter
+}
+
+// Parse command line options.
+func (cmd *DaemonCommand) Parse(args []string) error {
+	extflags := arrayFlags{}
+	secretsFile := ""
+	rulesfile := ""
+	fl := flag.NewFlagSet("daemon", flag.ExitOnError)
+	fl.Usage = func() {
+		log.Print("Usage: daemon [feed_id...]")
+		fl.PrintDefaults()
+	}
+	fl.StringVar(&cmd.DBURL, "dburl", "", "Database URL (default: $DMFR_DATABASE_URL)")
+	fl.StringVar(&cmd.DaemonOptions.FetchOptions.Directory, "gtfsdir", ".", "GTFS Directory")
+	fl.StringVar(&cmd.DaemonOptions.FetchOptions.S3, "s3", "", "Upload GTFS files to S3 bucket/prefix")
+	fl.StringVar(&secretsFile, "secrets", "", "Path to DMFR Secrets file")
+	fl.BoolVar(&cmd.DaemonOptions.FetchOptions.IgnoreDuplicateContents, "ignore-duplicate-contents", false, "Allow duplicate internal SHA1 contents")
+	fl.DurationVar(&cmd.DaemonOptions.Interval, "interval", 24*time.Hour, "Fetch interval for feeds without a fetch_interval in feed_states")
+	fl.DurationVar(&cmd.DaemonOptions.Poll, "poll", time.Minute, "How often to check for feeds that are due")
+	fl.IntVar(&cmd.DaemonOptions.Workers, "workers", 1, "Maximum number of feeds fetched and imported at the same time")
+	fl.BoolVar(&cmd.DaemonOptions.Activate, "activate", false, "Select the active feed version by service window after each import")
+	fl.Var(&extflags, "ext", "Include GTFS Extension")
+	fl.BoolVar(&cmd.DaemonOptions.ImportOptions.InterpolateStopTimes, "interpolate-stop-times", false, "Interpolate missing StopTime arrival/departure values")
+	fl.BoolVar(&cmd.DaemonOptions.ImportOptions.CreateMissingShapes, "create-missing-shapes", false, "Create missing Shapes from Trip stop-to-stop geometries")
+	fl.StringVar(&rulesfile, "rules", "", "JSON file with rules to change the severity of errors and warnings")
+	fl.BoolVar(&cmd.DaemonOptions.ImportOptions.Resumable, "resumable", false, "Commit each file separately, and resume failed imports from the last completed file")
+	fl.DurationVar(&cmd.DaemonOptions.ImportOptions.StaleImport, "stale-import", 0, "With -resumable, also resume imports that are marked in progress but have not been updated for this long")
+	fl.IntVar(&cmd.DaemonOptions.ImportOptions.TripWorkers, "trip-workers", 1, "Number of workers used to validate and interpolate trips and stop_times in each feed version")
+	fl.Parse(args)
+	if cmd.DBURL == "" {
+		cmd.DBURL = os.Getenv("DMFR_DATABASE_URL")
+	}
+	cmd.DaemonOptions.FeedIDs = fl.Args()
+	cmd.DaemonOptions.ImportOptions.Directory = cmd.DaemonOptions.FetchOptions.Directory
+	cmd.DaemonOptions.ImportOptions.S3 = cmd.DaemonOptions.FetchOptions.S3
+	cmd.DaemonOptions.ImportOptions.Extensions = extflags
+	if secretsFile != "" {
+		if err := cmd.DaemonOptions.FetchOptions.Secrets.Load(secretsFile); err != nil {
+			return err
+		}
+	}
+	if rulesfile != "" {
+		rules, err := copier.LoadRuleSet(rulesfile)
+		if err != nil {
+			return err
+		}
+		cmd.DaemonOptions.ImportOptions.Rules = rules
+	}
+	return nil
+}
+
+// Run this command.
+func (cmd *DaemonCommand) Run() error {
+	if cmd.adapter == nil {
+		writer := mustGetWriter(cmd.DBURL, true)
+		cmd.adapter = writer.Adapter
+		defer writer.Close()
+	}
+	ctx, stop, cancel := signalStopContext()
+	defer cancel()
+	log.Info("Starting daemon: interval %s, poll %s, workers %d", cmd.DaemonOptions.Interval, cmd.DaemonOptions.Poll, cmd.DaemonOptions.Workers)
+	return MainDaemon(ctx, stop, cmd.adapter, cmd.DaemonOptions, logDaemonResult)
+}
+
+func logDaemonResult(r DaemonResult) {
+	fv := r.FetchResult.FeedVersion
+	fvi := r.ImportResult.FeedVersionImport
+	if r.Error != nil {
+		log.Error("Feed %s: critical error: %s", r.FeedID, r.Error.Error())
+	} else if r.FetchResult.FetchError != nil {
+		log.Error("Feed %s: url: %s fetch error: %s", r.FeedID, fv.URL, r.FetchResult.FetchError.Error())
+	} else if !r.Imported {
+		log.Info("Feed %s: url: %s found sha1: %s (id:%d)", r.FeedID, fv.URL, fv.SHA1, fv.ID)
+	} else if fvi.Success {
+		log.Info("Feed %s: url: %s imported sha1: %s (id:%d): count %v errors: %v referrors: %v", r.FeedID, fv.URL, fv.SHA1, fv.ID, fvi.EntityCount, fvi.SkipEntityErrorCount, fvi.SkipEntityReferenceCount)
+	} else {
+		log.Error("Feed %s: url: %s import sha1: %s (id:%d) error: %s", r.FeedID, fv.URL, fv.SHA1, fv.ID, fvi.ExceptionLog)
+	}
+	if r.Activated > 0 {
+		log.Info("Feed %s: activated feed version %d", r.FeedID, r.Activated)
+	}
+}
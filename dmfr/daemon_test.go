@@ -0,0 +1,313 @@
+package dmfr
+
+import (
+	"context"
+	"database/sql"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/interline-io/transitland-lib/internal/testdb"
+	"github.com/interline-io/transitland-lib/tl"
+	"github.com/interline-io/transitland-lib/tldb"
+)
+
+// gtfsFeed creates a feed with spec "gtfs", which is required for scheduled fetches.
+func gtfsFeed(atx tldb.Adapter, url string) Feed {
+	tlfeed := Feed{}
+	tlfeed.FeedID = url
+	tlfeed.Spec = "gtfs"
+	tlfeed.URLs.StaticCurrent = url
+	tlfeed.ID = testdb.MustInsert(atx, &tlfeed)
+	return tlfeed
+}
+
+// openDaemonTestAdapter creates a SQLite database in dir.
+// Unlike testdb.AdapterIgnoreTx, failed transactions are rolled back.
+func openDaemonTestAdapter(t *testing.T, dir string) tldb.Adapter {
+	adapter := &tldb.SQLiteAdapter{DBURL: "sqlite3://" + filepath.Join(dir, "daemon.db")}
+	if err := adapter.Open(); err != nil {
+		t.Fatal(err)
+	}
+	if err := adapter.Create(); err != nil {
+		t.Fatal(err)
+	}
+	return adapter
+}
+
+// daemonErrorTestCases are feeds that fail to fetch: the server returns an error, or the fetched file cannot be saved.
+var daemonErrorTestCases = []struct {
+	name       string
+	status     int
+	fetchError bool // FetchResult.FetchError, otherwise DaemonResult.Error
+}{
+	{"fetch error", http.StatusInternalServerError, true},
+	{"error", http.StatusOK, false},
+}
+
+// daemonErrorTestServer serves ExampleZip with the given status and counts the requests.
+func daemonErrorTestServer(t *testing.T, status int, requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		if status != http.StatusOK {
+			http.Error(w, "error", status)
+			return
+		}
+		buf, err := ioutil.ReadFile(ExampleZip.URL)
+		if err != nil {
+			t.Error(err)
+		}
+		w.Write(buf)
+	}))
+}
+
+func TestFindDueFeeds(t *testing.T) {
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	states := []struct {
+		feedID      string
+		lastFetched time.Duration // before now; 0 if not fetched
+		interval    int64         // seconds; -1 to use the default interval
+	}{
+		{"fetched-recently", time.Hour, -1},
+		{"fetched-yesterday", 25 * time.Hour, -1},
+		{"short-interval", time.Hour, 60},
+		{"disabled", 0, 0},
+		{"never-fetched", 0, -1},
+	}
+	testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
+		gtfsFeed(atx, "no-state")
+		for _, s := range states {
+			f := gtfsFeed(atx, s.feedID)
+			state := FeedState{FeedID: f.ID}
+			if s.lastFetched > 0 {
+				state.LastFetchedAt = tl.OptionalTime{Time: now.Add(-s.lastFetched), Valid: true}
+			}
+			if s.interval >= 0 {
+				state.FetchInterval = sql.NullInt64{Int64: s.interval, Valid: true}
+			}
+			testdb.ShouldInsert(t, atx, &state)
+		}
+		t.Run("default", func(t *testing.T) {
+			due, err := FindDueFeeds(atx, DaemonOptions{Interval: 24 * time.Hour}, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, expect := strings.Join(due, ","), strings.Join([]string{"no-state", "never-fetched", "fetched-yesterday", "short-interval"}, ","); got != expect {
+				t.Errorf("got %s, expected %s", got, expect)
+			}
+		})
+		t.Run("FeedIDs", func(t *testing.T) {
+			due, err := FindDueFeeds(atx, DaemonOptions{Interval: 24 * time.Hour, FeedIDs: []string{"fetched-recently", "short-interval"}}, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, expect := strings.Join(due, ","), "short-interval"; got != expect {
+				t.Errorf("got %s, expected %s", got, expect)
+			}
+		})
+		t.Run("disabled by default", func(t *testing.T) {
+			due, err := FindDueFeeds(atx, DaemonOptions{}, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, expect := strings.Join(due, ","), "short-interval"; got != expect {
+				t.Errorf("got %s, expected %s", got, expect)
+			}
+		})
+		return nil
+	})
+}
+
+func TestDaemonFetchFeed(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf, err := ioutil.ReadFile(ExampleZip.URL)
+		if err != nil {
+			t.Error(err)
+		}
+		w.Write(buf)
+	}))
+	defer ts.Close()
+	tmpdir, err := ioutil.TempDir("", "gtfs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpdir)
+	testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
+		atx2 := &testdb.AdapterIgnoreTx{Adapter: atx}
+		feed := gtfsFeed(atx, ts.URL)
+		opts := DaemonOptions{
+			FetchOptions:  FetchOptions{Directory: tmpdir},
+			ImportOptions: ImportOptions{Directory: tmpdir},
+		}
+		// New feed version is fetched and imported
+		r := DaemonFetchFeed(context.Background(), atx2, opts, feed.FeedID)
+		if r.Error != nil {
+			t.Fatal(r.Error)
+		}
+		fvid := r.FetchResult.FeedVersion.ID
+		if fvid == 0 || r.FetchResult.FoundSHA1 {
+			t.Fatalf("expected new feed version")
+		}
+		if !r.Imported || !r.ImportResult.FeedVersionImport.Success {
+			t.Fatalf("expected successful import, got %s", r.ImportResult.FeedVersionImport.ExceptionLog)
+		}
+		fvi := FeedVersionImport{}
+		testdb.ShouldGet(t, atx, &fvi, `SELECT * FROM feed_version_gtfs_imports WHERE feed_version_id = ?`, fvid)
+		if !fvi.Success {
+			t.Errorf("expected success")
+		}
+		// Same file is not imported again
+		r = DaemonFetchFeed(context.Background(), atx2, opts, feed.FeedID)
+		if r.Error != nil {
+			t.Fatal(r.Error)
+		}
+		if !r.FetchResult.FoundSHA1 || r.FetchResult.FeedVersion.ID != fvid {
+			t.Errorf("expected existing feed version %d", fvid)
+		}
+		if r.Imported {
+			t.Errorf("expected no import")
+		}
+		// Fetch is recorded in the feed state
+		state := FeedState{}
+		testdb.ShouldGet(t, atx, &state, `SELECT * FROM feed_states WHERE feed_id = ?`, feed.ID)
+		if !state.LastFetchedAt.Valid {
+			t.Errorf("expected last_fetched_at")
+		}
+		if due, err := FindDueFeeds(atx, DaemonOptions{Interval: time.Hour}, time.Now().UTC()); err != nil {
+			t.Error(err)
+		} else if len(due) != 0 {
+			t.Errorf("got %v, expected no feeds due", due)
+		}
+		return nil
+	})
+}
+
+func TestMainDaemon(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf, err := ioutil.ReadFile(ExampleZip.URL)
+		if err != nil {
+			t.Error(err)
+		}
+		w.Write(buf)
+	}))
+	defer ts.Close()
+	tmpdir, err := ioutil.TempDir("", "gtfs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpdir)
+	testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
+		atx2 := &testdb.AdapterIgnoreTx{Adapter: atx}
+		feed := gtfsFeed(atx, ts.URL)
+		opts := DaemonOptions{
+			FetchOptions:  FetchOptions{Directory: tmpdir},
+			ImportOptions: ImportOptions{Directory: tmpdir},
+			Interval:      time.Hour,
+			Poll:          10 * time.Millisecond,
+		}
+		// Stop after the first result; MainDaemon returns once the running feeds are finished
+		stop, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		time.AfterFunc(10*time.Second, cancel)
+		results := []DaemonResult{}
+		handler := func(r DaemonResult) {
+			results = append(results, r)
+			cancel()
+		}
+		if err := MainDaemon(context.Background(), stop, atx2, opts, handler); err != nil {
+			t.Fatal(err)
+		}
+		if len(results) != 1 {
+			t.Fatalf("got %d results, expected 1", len(results))
+		}
+		if r := results[0]; r.FeedID != feed.FeedID || r.Error != nil || !r.Imported {
+			t.Errorf("expected feed %s to be fetched and imported, got %v", feed.FeedID, r.Error)
+		}
+		return nil
+	})
+}
+
+func TestDaemonFetchFeed_Error(t *testing.T) {
+	for _, tc := range daemonErrorTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			requests := int32(0)
+			ts := daemonErrorTestServer(t, tc.status, &requests)
+			defer ts.Close()
+			tmpdir, err := ioutil.TempDir("", "gtfs")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(tmpdir)
+			adapter := openDaemonTestAdapter(t, tmpdir)
+			defer adapter.Close()
+			feed := gtfsFeed(adapter, ts.URL)
+			// The fetched file cannot be saved to a missing directory
+			opts := DaemonOptions{FetchOptions: FetchOptions{Directory: filepath.Join(tmpdir, "missing")}}
+			r := DaemonFetchFeed(context.Background(), adapter, opts, feed.FeedID)
+			if tc.fetchError && r.FetchResult.FetchError == nil {
+				t.Errorf("expected fetch error")
+			} else if !tc.fetchError && r.Error == nil {
+				t.Errorf("expected error")
+			}
+			if r.Imported {
+				t.Errorf("expected no import")
+			}
+			// The attempt is saved, so the feed is not due again until the interval has passed
+			state := FeedState{}
+			testdb.ShouldGet(t, adapter, &state, `SELECT * FROM feed_states WHERE feed_id = ?`, feed.ID)
+			if !state.LastFetchedAt.Valid || state.LastFetchError == "" {
+				t.Errorf("expected last_fetched_at and last_fetch_error, got %v, '%s'", state.LastFetchedAt, state.LastFetchError)
+			}
+			if due, err := FindDueFeeds(adapter, DaemonOptions{Interval: time.Hour}, time.Now().UTC()); err != nil {
+				t.Error(err)
+			} else if len(due) != 0 {
+				t.Errorf("got %v, expected no feeds due", due)
+			}
+		})
+	}
+}
+
+func TestMainDaemon_Error(t *testing.T) {
+	for _, tc := range daemonErrorTestCases {
+		t.Run(tc.name, func(t *testing.T) {
+			requests := int32(0)
+			ts := daemonErrorTestServer(t, tc.status, &requests)
+			defer ts.Close()
+			tmpdir, err := ioutil.TempDir("", "gtfs")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(tmpdir)
+			adapter := openDaemonTestAdapter(t, tmpdir)
+			defer adapter.Close()
+			gtfsFeed(adapter, ts.URL)
+			opts := DaemonOptions{
+				FetchOptions: FetchOptions{Directory: filepath.Join(tmpdir, "missing")},
+				Interval:     time.Hour,
+				Poll:         10 * time.Millisecond,
+			}
+			// Run for several polls; the failed feed is not started again
+			stop, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+			results := []DaemonResult{}
+			handler := func(r DaemonResult) {
+				results = append(results, r)
+			}
+			if err := MainDaemon(context.Background(), stop, adapter, opts, handler); err != nil {
+				t.Fatal(err)
+			}
+			if len(results) != 1 {
+				t.Errorf("got %d results, expected 1", len(results))
+			}
+			if got := atomic.LoadInt32(&requests); got != 1 {
+				t.Errorf("got %d requests, expected 1", got)
+			}
+		})
+	}
+}
@@ -1,11 +1,15 @@
 package dmfr
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
 
 	"github.com/interline-io/transitland-lib/tldb"
 )
@@ -27,6 +31,14 @@ func (cmd *Command) Run(args []string) error {
 		log.Print("  import")
 		log.Print("  fetch")
 		log.Print("  recalculate")
+		log.Print("  migrate")
+		log.Print("  export")
+		log.Print("  unimport")
+		log.Print("  delete")
+		log.Print("  gc")
+		log.Print("  activate")
+		log.Print("  daemon")
+		log.Print("  worker")
 		fl.PrintDefaults()
 	}
 	fl.Parse(args)
@@ -53,6 +65,22 @@ func (cmd *Command) Run(args []string) error {
 		r = &FetchCommand{}
 	case "recalculate":
 		r = &RecalculateCommand{}
+	case "migrate":
+		r = &MigrateCommand{}
+	case "export":
+		r = &ExportCommand{}
+	case "unimport":
+		r = &UnimportCommand{}
+	case "delete":
+		r = &DeleteCommand{}
+	case "gc":
+		r = &GCCommand{}
+	case "activate":
+		r = &ActivateCommand{}
+	case "daemon":
+		r = &DaemonCommand{}
+	case "worker":
+		r = &WorkerCommand{}
 	default:
 		return fmt.Errorf("Invalid command: %q", subc)
 	}
@@ -92,6 +120,49 @@ func (i *arrayFlags) Set(value string) error {
 	return nil
 }
 
+// signalContext returns a context that is cancelled on SIGINT or SIGTERM.
+func signalContext() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		select {
+		case sig := <-c:
+			log.Printf("Received %s, cancelling", sig)
+			cancel()
+		case <-ctx.Done():
+		}
+		signal.Stop(c)
+	}()
+	return ctx, cancel
+}
+
+// signalStopContext returns a stop context that is done on the first SIGINT or SIGTERM, and a context that is cancelled on the second.
+// Long running commands stop starting new work when stop is done, and cancel the running work when ctx is cancelled.
+func signalStopContext() (context.Context, context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stop, stopCancel := context.WithCancel(ctx)
+	c := make(chan os.Signal, 2)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		defer signal.Stop(c)
+		for _, f := range []context.CancelFunc{stopCancel, cancel} {
+			select {
+			case sig := <-c:
+				if stop.Err() == nil {
+					log.Printf("Received %s, stopping after running tasks", sig)
+				} else {
+					log.Printf("Received %s, cancelling", sig)
+				}
+				f()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ctx, stop, cancel
+}
+
 // mustGetWriter opens & creates a db writer, panic on failure
 func mustGetWriter(dburl string, create bool) *tldb.Writer {
 	// Writer
@@ -0,0 +1,68 @@
+package dmfr
+
+import (
+	"context"
+	"errors"
+
+	"github.com/interline-io/transitland-lib/copier"
+	"github.com/interline-io/transitland-lib/tl"
+	"github.com/interline-io/transitland-lib/tlcsv"
+	"github.com/interline-io/transitland-lib/tldb"
+)
+
+// ExportOptions sets options for exporting a feed version.
+type ExportOptions struct {
+	FeedVersionIDs    []int
+	Filter            tldb.ReaderFilter
+	WriteExtraColumns bool
+}
+
+// MainExportFeedVersion writes imported feed versions from the database, e.g. to a GTFS zip.
+// References are written using the original GTFS IDs instead of database IDs, and shapes.txt is created from the Shape geometries.
+// Calendars that were generated during import are not written; references to them keep their service_id.
+// Multiple feed versions are written as a single merged feed; see tldb.MergedReader.
+// The Filter selects a subset of the feed versions; see tldb.ReaderFilter.
+func MainExportFeedVersion(ctx context.Context, adapter tldb.Adapter, writer tl.Writer, opts ExportOptions) (*copier.CopyResult, error) {
+	if len(opts.FeedVersionIDs) == 0 {
+		return nil, errors.New("no feed versions to export")
+	}
+	for _, fvid := range opts.FeedVersionIDs {
+		fv := tl.FeedVersion{}
+		fv.ID = fvid
+		if err := adapter.Find(&fv); err != nil {
+			return nil, err
+		}
+	}
+	var reader tl.Reader = &tldb.Reader{Adapter: adapter, PageSize: 1000, FeedVersionIDs: opts.FeedVersionIDs, Filter: opts.Filter}
+	if len(opts.FeedVersionIDs) > 1 {
+		mr := tldb.NewMergedReader(adapter, opts.FeedVersionIDs)
+		mr.Filter = opts.Filter
+		if errs := mr.ValidateStructure(); len(errs) > 0 {
+			return nil, errs[0]
+		}
+		reader = mr
+	}
+	if v, ok := writer.(*tlcsv.Writer); ok {
+		v.WriteExtraColumns = opts.WriteExtraColumns
+	}
+	cp := copier.NewCopier(reader, writer)
+	cp.AddEntityFilter(&generatedCalendarFilter{})
+	result := cp.CopyContext(ctx)
+	if result.WriteError != nil {
+		return result, result.WriteError
+	}
+	return result, nil
+}
+
+// generatedCalendarFilter skips Calendars created during import, but maps their ID to the original service_id.
+type generatedCalendarFilter struct{}
+
+// Filter skips generated Calendars.
+func (*generatedCalendarFilter) Filter(ent tl.Entity, emap *tl.EntityMap) error {
+	v, ok := ent.(*tl.Calendar)
+	if !ok || !v.Generated {
+		return nil
+	}
+	emap.Set("calendar.txt", v.EntityID(), v.ServiceID)
+	return errors.New("generated calendar")
+}
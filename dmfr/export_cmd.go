@@ -0,0 +1,186 @@
+package dmfr
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/interline-io/transitland-lib/internal/log"
+	"github.com/interline-io/transitland-lib/tlcsv"
+	"github.com/interline-io/transitland-lib/tldb"
+)
+
+// ExportCommand writes imported feed versions to a GTFS zip or directory.
+type ExportCommand struct {
+	DBURL         string
+	FVIDs         arrayFlags
+	FeedIDs       arrayFlags
+	Latest        bool
+	Active        bool
+	OutputPath    string
+	ExportOptions ExportOptions
+	adapter       tldb.Adapter
+}
+
+// Parse command line options.
+func (cmd *ExportCommand) Parse(args []string) error {
+	routeIDs := arrayFlags{}
+	agencyIDs := arrayFlags{}
+	bbox := ""
+	startDate := ""
+	endDate := ""
+	fl := flag.NewFlagSet("export", flag.ExitOnError)
+	fl.Usage = func() {
+		log.Print("Usage: export [-fvid N | -feed onestop_id | -active] <output>")
+		fl.PrintDefaults()
+	}
+	fl.StringVar(&cmd.DBURL, "dburl", "", "Database URL (default: $DMFR_DATABASE_URL)")
+	fl.Var(&cmd.FVIDs, "fvid", "Export feed version ID; may be specified multiple times")
+	fl.Var(&cmd.FeedIDs, "feed", "Export the active feed version for this feed; may be specified multiple times")
+	fl.BoolVar(&cmd.Latest, "latest", false, "With -feed, export the most recently fetched successfully imported feed version instead of the active feed version")
+	fl.BoolVar(&cmd.Active, "active", false, "Export the active feed versions for all feeds")
+	fl.Var(&routeIDs, "route", "Only export trips on this route, by GTFS route_id; may be specified multiple times")
+	fl.Var(&agencyIDs, "agency", "Only export trips on routes operated by this agency, by GTFS agency_id; may be specified multiple times")
+	fl.StringVar(&bbox, "bbox", "", "Only export trips that visit a stop inside this bounding box, and stops inside it: min_lon,min_lat,max_lon,max_lat")
+	fl.StringVar(&startDate, "start-date", "", "Only export trips with service that may be active on or after this date, YYYY-MM-DD")
+	fl.StringVar(&endDate, "end-date", "", "Only export trips with service that may be active on or before this date, YYYY-MM-DD")
+	fl.BoolVar(&cmd.ExportOptions.WriteExtraColumns, "write-extra-columns", false, "Include extra columns that are not part of the GTFS specification when writing GTFS files")
+	fl.Parse(args)
+	if cmd.DBURL == "" {
+		cmd.DBURL = os.Getenv("DMFR_DATABASE_URL")
+	}
+	if fl.NArg() != 1 {
+		fl.Usage()
+		return errors.New("requires output path")
+	}
+	cmd.OutputPath = fl.Arg(0)
+	if len(cmd.FVIDs) == 0 && len(cmd.FeedIDs) == 0 && !cmd.Active {
+		return errors.New("requires -fvid, -feed, or -active")
+	}
+	if cmd.Latest && len(cmd.FeedIDs) == 0 {
+		return errors.New("-latest requires -feed")
+	}
+	var err error
+	if cmd.ExportOptions.FeedVersionIDs, err = parseFVIDs(cmd.FVIDs); err != nil {
+		return err
+	}
+	filter := &cmd.ExportOptions.Filter
+	filter.RouteIDs = routeIDs
+	filter.AgencyIDs = agencyIDs
+	if bbox != "" {
+		b, err := parseBoundingBox(bbox)
+		if err != nil {
+			return err
+		}
+		filter.BoundingBox = b
+	}
+	if startDate != "" {
+		if filter.StartDate, err = time.Parse("2006-01-02", startDate); err != nil {
+			return fmt.Errorf("invalid start date: %s", startDate)
+		}
+	}
+	if endDate != "" {
+		if filter.EndDate, err = time.Parse("2006-01-02", endDate); err != nil {
+			return fmt.Errorf("invalid end date: %s", endDate)
+		}
+	}
+	return nil
+}
+
+// parseBoundingBox parses a bounding box from "min_lon,min_lat,max_lon,max_lat".
+func parseBoundingBox(value string) ([]float64, error) {
+	b := []float64{0, 0, 0, 0}
+	split := strings.Split(value, ",")
+	if len(split) != 4 {
+		return nil, fmt.Errorf("invalid bounding box: %s", value)
+	}
+	for i, v := range split {
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bounding box: %s", value)
+		}
+		b[i] = f
+	}
+	if b[0] > b[2] || b[1] > b[3] {
+		return nil, fmt.Errorf("invalid bounding box: %s; min must be less than max", value)
+	}
+	return b, nil
+}
+
+// Run this command.
+func (cmd *ExportCommand) Run() error {
+	if cmd.adapter == nil {
+		writer := mustGetWriter(cmd.DBURL, false)
+		cmd.adapter = writer.Adapter
+		defer writer.Close()
+	}
+	for _, feedID := range cmd.FeedIDs {
+		fvid, err := findFeedVersionID(cmd.adapter, feedID, cmd.Latest)
+		if err != nil {
+			return err
+		}
+		cmd.ExportOptions.FeedVersionIDs = append(cmd.ExportOptions.FeedVersionIDs, fvid)
+	}
+	if cmd.Active {
+		fvids := []int{}
+		if err := cmd.adapter.Select(&fvids, "SELECT feed_version_id FROM feed_states WHERE feed_version_id IS NOT NULL ORDER BY feed_version_id"); err != nil {
+			return err
+		}
+		cmd.ExportOptions.FeedVersionIDs = append(cmd.ExportOptions.FeedVersionIDs, fvids...)
+	}
+	writer, err := tlcsv.NewWriter(cmd.OutputPath)
+	if err != nil {
+		return err
+	}
+	if err := writer.Open(); err != nil {
+		return err
+	}
+	ctx, cancel := signalContext()
+	defer cancel()
+	log.Print("Exporting feed versions %v to %s", cmd.ExportOptions.FeedVersionIDs, cmd.OutputPath)
+	result, err := MainExportFeedVersion(ctx, cmd.adapter, writer, cmd.ExportOptions)
+	if err != nil {
+		writer.Close()
+		return err
+	}
+	result.DisplaySummary()
+	return writer.Close()
+}
+
+// findFeedVersionID returns the active feed version for a feed, or the most recently fetched successfully imported feed version if latest is true.
+func findFeedVersionID(adapter tldb.Adapter, feedID string, latest bool) (int, error) {
+	q := adapter.Sqrl().
+		Select("feed_versions.id").
+		From("feed_versions").
+		Join("current_feeds ON current_feeds.id = feed_versions.feed_id").
+		Where("current_feeds.onestop_id = ?", feedID)
+	if latest {
+		q = q.
+			Join("feed_version_gtfs_imports ON feed_version_gtfs_imports.feed_version_id = feed_versions.id").
+			Where(sq.Eq{"feed_version_gtfs_imports.success": true}).
+			OrderBy("feed_versions.fetched_at DESC", "feed_versions.id DESC").
+			Limit(1)
+	} else {
+		q = q.Join("feed_states ON feed_states.feed_version_id = feed_versions.id")
+	}
+	fvids := []int{}
+	qstr, args, err := q.ToSql()
+	if err != nil {
+		return 0, err
+	}
+	if err := adapter.Select(&fvids, qstr, args...); err != nil {
+		return 0, err
+	}
+	if len(fvids) == 0 {
+		if latest {
+			return 0, fmt.Errorf("no successfully imported feed versions for feed '%s'", feedID)
+		}
+		return 0, fmt.Errorf("no active feed version for feed '%s'", feedID)
+	}
+	return fvids[0], nil
+}
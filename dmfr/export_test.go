@@ -0,0 +1,189 @@
+package dmfr
+
+import (
+	"context"
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/interline-io/transitland-lib/internal/testdb"
+	"github.com/interline-io/transitland-lib/internal/testutil"
+	"github.com/interline-io/transitland-lib/tl"
+	"github.com/interline-io/transitland-lib/tlcsv"
+	"github.com/interline-io/transitland-lib/tldb"
+)
+
+func TestMainExportFeedVersion(t *testing.T) {
+	testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
+		fv := tl.FeedVersion{}
+		fv.File = testutil.ExampleDir.URL
+		fvid := testdb.ShouldInsert(t, atx, &fv)
+		atx2 := testdb.AdapterIgnoreTx{Adapter: atx}
+		if _, err := MainImportFeedVersion(&atx2, ImportOptions{FeedVersionID: fvid}); err != nil {
+			t.Fatal(err)
+		}
+		// Export
+		tmpdir, err := ioutil.TempDir("", "gtfs")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(tmpdir)
+		writer, err := tlcsv.NewWriter(tmpdir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := writer.Open(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := MainExportFeedVersion(context.Background(), &atx2, writer, ExportOptions{FeedVersionIDs: []int{fvid}}); err != nil {
+			t.Fatal(err)
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+		// Check results
+		reader, err := tlcsv.NewReader(tmpdir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := reader.Open(); err != nil {
+			t.Fatal(err)
+		}
+		defer reader.Close()
+		testutil.CheckReader(t, testutil.ExampleDir, reader)
+		stops := map[string]bool{}
+		for stop := range reader.Stops() {
+			stops[stop.StopID] = true
+			if stop.StopLat == 0 || stop.StopLon == 0 {
+				t.Errorf("stop '%s': expected coordinates, got %f, %f", stop.StopID, stop.StopLat, stop.StopLon)
+			}
+		}
+		trips := map[string]bool{}
+		for trip := range reader.Trips() {
+			trips[trip.TripID] = true
+		}
+		for st := range reader.StopTimes() {
+			if !stops[st.StopID] || !trips[st.TripID] {
+				t.Errorf("stop_time references unknown stop '%s' or trip '%s'", st.StopID, st.TripID)
+			}
+		}
+		return nil
+	})
+}
+
+func TestFindFeedVersionID(t *testing.T) {
+	testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
+		f := caltrain(atx, "test")
+		fv1 := tl.FeedVersion{FeedID: f.ID}
+		fv1.FetchedAt = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		fvid1 := testdb.ShouldInsert(t, atx, &fv1)
+		fv2 := tl.FeedVersion{FeedID: f.ID}
+		fv2.FetchedAt = time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC)
+		fvid2 := testdb.ShouldInsert(t, atx, &fv2)
+		testdb.ShouldInsert(t, atx, &FeedVersionImport{FeedVersionID: fvid2, Success: true})
+		// Most recently fetched, but not successfully imported
+		fv3 := tl.FeedVersion{FeedID: f.ID}
+		fv3.FetchedAt = time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+		fvid3 := testdb.ShouldInsert(t, atx, &fv3)
+		testdb.ShouldInsert(t, atx, &FeedVersionImport{FeedVersionID: fvid3, Success: false})
+		if _, err := findFeedVersionID(atx, "test", false); err == nil {
+			t.Error("expected error for feed without active feed version")
+		}
+		testdb.ShouldInsert(t, atx, &FeedState{FeedID: f.ID, FeedVersionID: tl.OptionalKey{NullInt64: sql.NullInt64{Int64: int64(fvid1), Valid: true}}})
+		if fvid, err := findFeedVersionID(atx, "test", false); err != nil {
+			t.Error(err)
+		} else if fvid != fvid1 {
+			t.Errorf("got %d, expected active feed version %d", fvid, fvid1)
+		}
+		if fvid, err := findFeedVersionID(atx, "test", true); err != nil {
+			t.Error(err)
+		} else if fvid != fvid2 {
+			t.Errorf("got %d, expected latest feed version %d", fvid, fvid2)
+		}
+		if _, err := findFeedVersionID(atx, "unknown", true); err == nil {
+			t.Error("expected error for unknown feed")
+		}
+		return nil
+	})
+}
+
+func TestMainExportFeedVersion_Merged(t *testing.T) {
+	testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
+		atx2 := testdb.AdapterIgnoreTx{Adapter: atx}
+		fvids := []int{}
+		for _, feedid := range []string{"f-a", "f-b"} {
+			f := caltrain(atx, feedid)
+			fv := tl.FeedVersion{FeedID: f.ID}
+			fv.File = testutil.ExampleDir.URL
+			fv.SHA1 = feedid
+			fvid := testdb.ShouldInsert(t, atx, &fv)
+			if _, err := MainImportFeedVersion(&atx2, ImportOptions{FeedVersionID: fvid}); err != nil {
+				t.Fatal(err)
+			}
+			fvids = append(fvids, fvid)
+		}
+		tmpdir, err := ioutil.TempDir("", "gtfs")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(tmpdir)
+		writer, err := tlcsv.NewWriter(tmpdir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := writer.Open(); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := MainExportFeedVersion(context.Background(), &atx2, writer, ExportOptions{FeedVersionIDs: fvids}); err != nil {
+			t.Fatal(err)
+		}
+		if err := writer.Close(); err != nil {
+			t.Fatal(err)
+		}
+		// Check results
+		reader, err := tlcsv.NewReader(tmpdir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := reader.Open(); err != nil {
+			t.Fatal(err)
+		}
+		defer reader.Close()
+		// The agency is the same in both feeds
+		fe := testutil.ReaderTester{Counts: map[string]int{}, EntityIDs: map[string][]string{}}
+		for fn, count := range testutil.ExampleDir.Counts {
+			fe.Counts[fn] = count * 2
+		}
+		fe.Counts["agency.txt"] = 1
+		delete(fe.Counts, "feed_info.txt")
+		fe.EntityIDs["agency.txt"] = []string{"f-a:DTA"}
+		fe.EntityIDs["stops.txt"] = []string{"f-a:FUR_CREEK_RES", "f-b:FUR_CREEK_RES"}
+		fe.EntityIDs["trips.txt"] = []string{"f-a:AB1", "f-b:AB1"}
+		testutil.CheckReader(t, fe, reader)
+		if _, err := os.Stat(filepath.Join(tmpdir, "feed_info.txt")); !os.IsNotExist(err) {
+			t.Error("expected no feed_info.txt")
+		}
+		for route := range reader.Routes() {
+			if route.AgencyID != "f-a:DTA" {
+				t.Errorf("route '%s': got agency_id '%s', expected 'f-a:DTA'", route.RouteID, route.AgencyID)
+			}
+		}
+		return nil
+	})
+}
+
+func TestParseBoundingBox(t *testing.T) {
+	if b, err := parseBoundingBox("-122.5, 37.7,-122.3,37.8"); err != nil {
+		t.Error(err)
+	} else if b[0] != -122.5 || b[1] != 37.7 || b[2] != -122.3 || b[3] != 37.8 {
+		t.Errorf("got %v", b)
+	}
+	for _, v := range []string{"", "1,2,3", "a,b,c,d", "-122.3,37.7,-122.5,37.8"} {
+		if _, err := parseBoundingBox(v); err == nil {
+			t.Errorf("expected error for '%s'", v)
+		}
+	}
+}
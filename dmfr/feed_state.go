@@ -17,6 +17,7 @@ type FeedState struct {
 	LastSuccessfulFetchAt tl.OptionalTime
 	FeedPriority          sql.NullInt64
 	FeedRealtimeEnabled   bool
+	FetchInterval         sql.NullInt64 // seconds between scheduled fetches; see MainDaemon
 	tl.Timestamps
 }
 
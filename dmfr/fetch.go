@@ -1,6 +1,7 @@
 package dmfr
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -42,6 +43,12 @@ type FetchResult struct {
 // An error return from this function is a serious failure.
 // Saves FeedState.LastFetchError for regular failures.
 func DatabaseFetch(atx tldb.Adapter, opts FetchOptions) (FetchResult, error) {
+	return DatabaseFetchContext(context.Background(), atx, opts)
+}
+
+// DatabaseFetchContext is like DatabaseFetch, but stops when the context is done.
+// Cancellation is returned as a serious failure, so that any transaction is rolled back.
+func DatabaseFetchContext(ctx context.Context, atx tldb.Adapter, opts FetchOptions) (FetchResult, error) {
 	fr := FetchResult{}
 	// Get feed, create if not present and FeedCreate is specified
 	tlfeed := Feed{}
@@ -78,7 +85,7 @@ func DatabaseFetch(atx tldb.Adapter, opts FetchOptions) (FetchResult, error) {
 		return fr, err
 	}
 	// Start fetching
-	fr, err := fetchAndCreateFeedVersion(atx, tlfeed, opts)
+	fr, err := fetchAndCreateFeedVersion(ctx, atx, tlfeed, opts)
 	if err != nil {
 		return fr, err
 	}
@@ -100,7 +107,7 @@ func DatabaseFetch(atx tldb.Adapter, opts FetchOptions) (FetchResult, error) {
 // Returns an error if a serious failure occurs, such as database or filesystem access.
 // Sets FetchResult.FetchError if a regular failure occurs, such as a 404.
 // feed is an argument to provide the ID, File, and Authorization.
-func fetchAndCreateFeedVersion(atx tldb.Adapter, feed tl.Feed, opts FetchOptions) (FetchResult, error) {
+func fetchAndCreateFeedVersion(ctx context.Context, atx tldb.Adapter, feed tl.Feed, opts FetchOptions) (FetchResult, error) {
 	fr := FetchResult{}
 	if opts.FeedURL == "" {
 		fr.FetchError = errors.New("no url")
@@ -131,7 +138,10 @@ func fetchAndCreateFeedVersion(atx tldb.Adapter, feed tl.Feed, opts FetchOptions
 	// Override the default URLAdapter
 	if u.Scheme == "http" || u.Scheme == "https" || u.Scheme == "ftp" || u.Scheme == "s3" {
 		aa := AuthenticatedURLAdapter{}
-		if err := aa.Download(opts.FeedURL, feed.Authorization, secret); err != nil {
+		if err := aa.DownloadContext(ctx, opts.FeedURL, feed.Authorization, secret); err != nil {
+			if ctx.Err() != nil {
+				return fr, ctx.Err()
+			}
 			fr.FetchError = err
 			return fr, nil
 		}
@@ -168,8 +178,12 @@ func fetchAndCreateFeedVersion(atx tldb.Adapter, feed tl.Feed, opts FetchOptions
 		return fr, err
 	}
 	// Upload file or copy to output directory
+	if err := ctx.Err(); err != nil {
+		return fr, err
+	}
 	if opts.S3 != "" {
-		awscmd := exec.Command(
+		awscmd := exec.CommandContext(
+			ctx,
 			"aws",
 			"s3",
 			"cp",
@@ -1,12 +1,14 @@
 package dmfr
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"os"
 	"sync"
 	"time"
 
+	sq "github.com/Masterminds/squirrel"
 	"github.com/interline-io/transitland-lib/internal/log"
 	"github.com/interline-io/transitland-lib/tldb"
 )
@@ -18,6 +20,7 @@ type FetchCommand struct {
 	Limit        int
 	DBURL        string
 	DryRun       bool
+	Enqueue      bool
 	FeedIDs      []string
 	adapter      tldb.Adapter
 }
@@ -42,6 +45,7 @@ func (cmd *FetchCommand) Parse(args []string) error {
 	fl.BoolVar(&cmd.DryRun, "dry-run", false, "Dry run; print feeds that would be imported and exit")
 	fl.BoolVar(&cmd.FetchOptions.IgnoreDuplicateContents, "ignore-duplicate-contents", false, "Allow duplicate internal SHA1 contents")
 	fl.StringVar(&cmd.FetchOptions.S3, "s3", "", "Upload GTFS files to S3 bucket/prefix")
+	fl.BoolVar(&cmd.Enqueue, "enqueue", false, "Add fetch jobs to the job queue instead of fetching; see worker")
 	fl.Parse(args)
 	if cmd.DBURL == "" {
 		cmd.DBURL = os.Getenv("DMFR_DATABASE_URL")
@@ -62,6 +66,9 @@ func (cmd *FetchCommand) Parse(args []string) error {
 	if cmd.FetchOptions.FeedURL != "" && len(cmd.FeedIDs) != 1 {
 		return errors.New("you must specify exactly one feed_id when using -fetch-url")
 	}
+	if cmd.Enqueue && (cmd.FetchOptions.FeedURL != "" || cmd.FetchOptions.FeedCreate || fetchedAt != "") {
+		return errors.New("cannot use -enqueue with -feed-url, -create-feed, or -fetched-at")
+	}
 	return nil
 }
 
@@ -95,18 +102,40 @@ func (cmd *FetchCommand) Run() error {
 			cmd.FeedIDs = append(cmd.FeedIDs, feed.FeedID)
 		}
 	}
+	if cmd.Enqueue && !cmd.DryRun {
+		qstr, qargs, err := cmd.adapter.Sqrl().
+			Select("id").
+			From("current_feeds").
+			Where(sq.Eq{"onestop_id": cmd.FeedIDs}).
+			OrderBy("id").
+			ToSql()
+		if err != nil {
+			return err
+		}
+		feedIDs := []int{}
+		if err := cmd.adapter.Select(&feedIDs, qstr, qargs...); err != nil {
+			return err
+		}
+		jobs := []Job{}
+		for _, feedID := range feedIDs {
+			jobs = append(jobs, NewFetchJob(feedID))
+		}
+		return enqueueJobs(cmd.adapter, jobs)
+	}
 	///////////////
 	// Here we go
 	log.Info("Fetching %d feeds", len(cmd.FeedIDs))
 	fetchNew := 0
 	fetchFound := 0
 	fetchErrs := 0
+	ctx, cancel := signalContext()
+	defer cancel()
 	var wg sync.WaitGroup
 	jobs := make(chan FetchOptions, len(cmd.FeedIDs))
 	results := make(chan FetchResult, len(cmd.FeedIDs))
 	for w := 0; w < cmd.Workers; w++ {
 		wg.Add(1)
-		go fetchWorker(w, cmd.adapter, cmd.DryRun, jobs, results, &wg)
+		go fetchWorker(ctx, w, cmd.adapter, cmd.DryRun, jobs, results, &wg)
 	}
 	for _, feedid := range cmd.FeedIDs {
 		opts := FetchOptions{
@@ -139,10 +168,14 @@ func (cmd *FetchCommand) Run() error {
 	return nil
 }
 
-func fetchWorker(id int, adapter tldb.Adapter, DryRun bool, jobs <-chan FetchOptions, results chan<- FetchResult, wg *sync.WaitGroup) {
+func fetchWorker(ctx context.Context, id int, adapter tldb.Adapter, DryRun bool, jobs <-chan FetchOptions, results chan<- FetchResult, wg *sync.WaitGroup) {
 	for opts := range jobs {
 		// Get FeedID for pretty printing.
 		osid := opts.FeedID
+		if ctx.Err() != nil {
+			log.Info("Feed %s: cancelled", osid)
+			continue
+		}
 		log.Info("Feed %s: start", osid)
 		if DryRun {
 			log.Info("Feed %s: dry-run", osid)
@@ -152,7 +185,7 @@ func fetchWorker(id int, adapter tldb.Adapter, DryRun bool, jobs <-chan FetchOpt
 		t := time.Now()
 		err := adapter.Tx(func(atx tldb.Adapter) error {
 			var fe error
-			fr, fe = DatabaseFetch(atx, opts)
+			fr, fe = DatabaseFetchContext(ctx, atx, opts)
 			return fe
 		})
 		t2 := float64(time.Now().UnixNano()-t.UnixNano()) / 1e9 // 1000000000.0
@@ -1,6 +1,7 @@
 package dmfr
 
 import (
+	"context"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
@@ -190,7 +191,7 @@ func Test_fetchAndCreateFeedVersion(t *testing.T) {
 		defer os.RemoveAll(tmpdir) // clean up
 		url := ts.URL
 		feed := caltrain(atx, url)
-		fr, err := fetchAndCreateFeedVersion(atx, feed, FetchOptions{FeedURL: url, Directory: tmpdir})
+		fr, err := fetchAndCreateFeedVersion(context.Background(), atx, feed, FetchOptions{FeedURL: url, Directory: tmpdir})
 		if err != nil {
 			t.Error(err)
 			return err
@@ -227,7 +228,7 @@ func TestfetchAndCreateFeedVersion_404(t *testing.T) {
 	testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
 		url := ts.URL
 		feed := caltrain(atx, url)
-		fr, err := fetchAndCreateFeedVersion(atx, feed, FetchOptions{FeedURL: url, Directory: ""})
+		fr, err := fetchAndCreateFeedVersion(context.Background(), atx, feed, FetchOptions{FeedURL: url, Directory: ""})
 		if err != nil {
 			t.Error(err)
 			return err
@@ -259,7 +260,7 @@ func TestfetchAndCreateFeedVersion_Exists(t *testing.T) {
 	testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
 		url := ts.URL
 		feed := caltrain(atx, url)
-		fr, err := fetchAndCreateFeedVersion(atx, feed, FetchOptions{FeedURL: url, Directory: ""})
+		fr, err := fetchAndCreateFeedVersion(context.Background(), atx, feed, FetchOptions{FeedURL: url, Directory: ""})
 		if err != nil {
 			t.Error(err)
 		}
@@ -269,7 +270,7 @@ func TestfetchAndCreateFeedVersion_Exists(t *testing.T) {
 		if fr.FeedVersion.SHA1 != ExampleZip.SHA1 {
 			t.Errorf("got %s expect %s", fr.FeedVersion.SHA1, ExampleZip.SHA1)
 		}
-		fr2, err2 := fetchAndCreateFeedVersion(atx, feed, FetchOptions{FeedURL: url, Directory: ""})
+		fr2, err2 := fetchAndCreateFeedVersion(context.Background(), atx, feed, FetchOptions{FeedURL: url, Directory: ""})
 		if err2 != nil {
 			t.Error(err2)
 			return err2
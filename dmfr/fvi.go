@@ -15,9 +15,11 @@ type FeedVersionImport struct {
 	FeedVersionID             int
 	ImportLog                 string
 	ExceptionLog              string
-	ImportLevel               int  // deprecated
-	Success                   bool // Finished, Success Yes/No
-	InProgress                bool // In Progress
+	ImportLevel               int     // deprecated
+	Success                   bool    // Finished, Success Yes/No
+	InProgress                bool    // In Progress
+	Progress                  float64 // Estimated percent complete, while in progress
+	Checkpoint                string  // Completed stages of a resumable import
 	InterpolatedStopTimeCount int
 	EntityCount               EntityCounter
 	WarningCount              EntityCounter
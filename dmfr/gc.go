@@ -0,0 +1,147 @@
+package dmfr
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/interline-io/transitland-lib/internal/log"
+	"github.com/interline-io/transitland-lib/tldb"
+)
+
+// GCOptions sets the retention policy for feed versions.
+// A feed version is kept if any of the policies applies; the active feed version is always kept.
+type GCOptions struct {
+	KeepLatest      int       // keep the latest N successfully imported feed versions of each feed; 0 to disable
+	KeepServiceDays int       // keep feed versions with service in the last or next N days; 0 to disable
+	Now             time.Time // the current date for KeepServiceDays; defaults to today
+	Unimport        bool      // only unimport feed versions, instead of deleting them and their files
+	DryRun          bool      // do not remove anything
+	Extensions      []string
+	Directory       string
+	S3              string
+}
+
+// GCFeedVersion is a feed version and the result of the retention policy.
+type GCFeedVersion struct {
+	ID                   int
+	FeedID               int
+	OnestopID            string
+	SHA1                 string
+	FetchedAt            time.Time
+	EarliestCalendarDate time.Time
+	LatestCalendarDate   time.Time
+	Success              sql.NullBool
+	InProgress           sql.NullBool
+	Keep                 bool
+	Reason               string // why the feed version is kept
+}
+
+// FindGCFeedVersions applies the retention policy to all feed versions, ordered by feed and most recently fetched first.
+func FindGCFeedVersions(adapter tldb.Adapter, opts GCOptions) ([]GCFeedVersion, error) {
+	fvs := []GCFeedVersion{}
+	q := adapter.Sqrl().
+		Select(
+			"feed_versions.id",
+			"feed_versions.feed_id",
+			"current_feeds.onestop_id",
+			"feed_versions.sha1",
+			"feed_versions.fetched_at",
+			"feed_versions.earliest_calendar_date",
+			"feed_versions.latest_calendar_date",
+			"feed_version_gtfs_imports.success",
+			"feed_version_gtfs_imports.in_progress",
+		).
+		From("feed_versions").
+		Join("current_feeds ON current_feeds.id = feed_versions.feed_id").
+		LeftJoin("feed_version_gtfs_imports ON feed_version_gtfs_imports.feed_version_id = feed_versions.id").
+		OrderBy("feed_versions.feed_id", "feed_versions.fetched_at DESC", "feed_versions.id DESC")
+	qstr, args, err := q.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	if err := adapter.Select(&fvs, qstr, args...); err != nil {
+		return nil, err
+	}
+	activeIDs := []int{}
+	if err := adapter.Select(&activeIDs, "SELECT feed_version_id FROM feed_states WHERE feed_version_id IS NOT NULL"); err != nil {
+		return nil, err
+	}
+	active := map[int]bool{}
+	for _, fvid := range activeIDs {
+		active[fvid] = true
+	}
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now().UTC()
+	}
+	now = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	serviceStart := now.AddDate(0, 0, -opts.KeepServiceDays)
+	serviceEnd := now.AddDate(0, 0, opts.KeepServiceDays)
+	imported := map[int]int{} // imported feed versions seen for each feed
+	for i := range fvs {
+		fv := &fvs[i]
+		keep := ""
+		if active[fv.ID] {
+			keep = "active"
+		} else if fv.InProgress.Valid && fv.InProgress.Bool {
+			keep = "import in progress"
+		} else if fv.Success.Valid && fv.Success.Bool && imported[fv.FeedID] < opts.KeepLatest {
+			keep = "latest imported"
+		} else if !fv.Success.Valid && imported[fv.FeedID] == 0 {
+			// Newer than any imported feed version of the feed; may still be imported
+			keep = "not imported yet"
+		} else if opts.KeepServiceDays > 0 && !fv.EarliestCalendarDate.After(serviceEnd) && !fv.LatestCalendarDate.Before(serviceStart) {
+			keep = "service in range"
+		}
+		if fv.Success.Valid && fv.Success.Bool {
+			imported[fv.FeedID]++
+		}
+		fv.Keep = keep != ""
+		fv.Reason = keep
+	}
+	return fvs, nil
+}
+
+// MainGC removes the feed versions that are not kept by the retention policy.
+// Each feed version is removed in a separate Tx; see MainUnimportFeedVersion and MainDeleteFeedVersion.
+// A feed version that became active after the retention policy was applied is skipped.
+// The removed feed versions are returned, or the feed versions that would be removed if opts.DryRun is set.
+func MainGC(ctx context.Context, adapter tldb.Adapter, opts GCOptions) ([]GCFeedVersion, error) {
+	ret := []GCFeedVersion{}
+	fvs, err := FindGCFeedVersions(adapter, opts)
+	if err != nil {
+		return ret, err
+	}
+	for _, fv := range fvs {
+		if fv.Keep {
+			continue
+		}
+		if opts.Unimport && !fv.Success.Valid {
+			// Nothing to unimport
+			continue
+		}
+		if opts.DryRun {
+			ret = append(ret, fv)
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return ret, err
+		}
+		uopts := UnimportOptions{FeedVersionID: fv.ID, Extensions: opts.Extensions}
+		if opts.Unimport {
+			err = MainUnimportFeedVersion(adapter, uopts)
+		} else {
+			err = MainDeleteFeedVersion(ctx, adapter, DeleteOptions{UnimportOptions: uopts, DeleteFile: true, Directory: opts.Directory, S3: opts.S3})
+		}
+		if _, ok := err.(activeFeedVersionError); ok {
+			log.Info("Skipped feed version %d (%s %s): it is now active", fv.ID, fv.OnestopID, fv.SHA1)
+			continue
+		} else if err != nil {
+			return ret, err
+		}
+		log.Info("Removed feed version %d (%s %s)", fv.ID, fv.OnestopID, fv.SHA1)
+		ret = append(ret, fv)
+	}
+	return ret, nil
+}
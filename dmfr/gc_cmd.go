@@ -0,0 +1,77 @@
+package dmfr
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/interline-io/transitland-lib/internal/log"
+	"github.com/interline-io/transitland-lib/tldb"
+)
+
+// GCCommand removes feed versions that are not kept by a retention policy.
+type GCCommand struct {
+	DBURL     string
+	GCOptions GCOptions
+	adapter   tldb.Adapter
+}
+
+// Parse command line options.
+func (cmd *GCCommand) Parse(args []string) error {
+	extflags := arrayFlags{}
+	fl := flag.NewFlagSet("gc", flag.ExitOnError)
+	fl.Usage = func() {
+		log.Print("Usage: gc [-keep-latest N] [-keep-service-days N]")
+		fl.PrintDefaults()
+	}
+	fl.StringVar(&cmd.DBURL, "dburl", "", "Database URL (default: $DMFR_DATABASE_URL)")
+	fl.IntVar(&cmd.GCOptions.KeepLatest, "keep-latest", 2, "Keep the latest N successfully imported feed versions of each feed")
+	fl.IntVar(&cmd.GCOptions.KeepServiceDays, "keep-service-days", 0, "Keep feed versions with service in the last or next N days")
+	fl.BoolVar(&cmd.GCOptions.Unimport, "unimport", false, "Only unimport feed versions; do not delete feed versions or files")
+	fl.BoolVar(&cmd.GCOptions.DryRun, "dryrun", false, "Print feed versions and the retention policy result; do not remove anything")
+	fl.Var(&extflags, "ext", "Also delete entities written by this GTFS Extension")
+	fl.StringVar(&cmd.GCOptions.Directory, "gtfsdir", ".", "GTFS Directory")
+	fl.StringVar(&cmd.GCOptions.S3, "s3", "", "GTFS files in S3 bucket/prefix")
+	fl.Parse(args)
+	if cmd.DBURL == "" {
+		cmd.DBURL = os.Getenv("DMFR_DATABASE_URL")
+	}
+	cmd.GCOptions.Extensions = extflags
+	if cmd.GCOptions.KeepLatest < 0 || cmd.GCOptions.KeepServiceDays < 0 {
+		return fmt.Errorf("-keep-latest and -keep-service-days must not be negative")
+	}
+	return nil
+}
+
+// Run this command.
+func (cmd *GCCommand) Run() error {
+	if cmd.adapter == nil {
+		writer := mustGetWriter(cmd.DBURL, false)
+		cmd.adapter = writer.Adapter
+		defer writer.Close()
+	}
+	if cmd.GCOptions.DryRun {
+		fvs, err := FindGCFeedVersions(cmd.adapter, cmd.GCOptions)
+		if err != nil {
+			return err
+		}
+		for _, fv := range fvs {
+			action := "keep: " + fv.Reason
+			if !fv.Keep && cmd.GCOptions.Unimport && !fv.Success.Valid {
+				action = "keep: not imported"
+			} else if !fv.Keep && cmd.GCOptions.Unimport {
+				action = "unimport"
+			} else if !fv.Keep {
+				action = "delete"
+			}
+			log.Print("%d\t%s\t%s\t%s\t%s", fv.ID, fv.OnestopID, fv.SHA1, fv.FetchedAt.Format(time.RFC3339), action)
+		}
+		return nil
+	}
+	ctx, cancel := signalContext()
+	defer cancel()
+	removed, err := MainGC(ctx, cmd.adapter, cmd.GCOptions)
+	log.Print("Removed %d feed versions", len(removed))
+	return err
+}
@@ -0,0 +1,208 @@
+package dmfr
+
+import (
+	"context"
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/interline-io/transitland-lib/internal/testdb"
+	"github.com/interline-io/transitland-lib/tl"
+	"github.com/interline-io/transitland-lib/tldb"
+)
+
+// testFeedVersion describes a feed version created by createTestFeedVersions.
+type testFeedVersion struct {
+	Import               string // success, failed, in_progress, or empty if not imported
+	EarliestCalendarDate time.Time
+	LatestCalendarDate   time.Time
+}
+
+// createTestFeedVersions creates a feed and its feed versions, fetched on consecutive days starting from fetched, oldest first.
+func createTestFeedVersions(t *testing.T, atx tldb.Adapter, fetched time.Time, fvs []testFeedVersion) (Feed, []int) {
+	f := caltrain(atx, "test")
+	fvids := []int{}
+	for i, tfv := range fvs {
+		fv := tl.FeedVersion{FeedID: f.ID}
+		fv.SHA1 = "test" + string(rune('a'+i))
+		fv.FetchedAt = fetched.AddDate(0, 0, i)
+		fv.EarliestCalendarDate = tfv.EarliestCalendarDate
+		fv.LatestCalendarDate = tfv.LatestCalendarDate
+		fvid := testdb.ShouldInsert(t, atx, &fv)
+		fvids = append(fvids, fvid)
+		switch tfv.Import {
+		case "success":
+			testdb.ShouldInsert(t, atx, &FeedVersionImport{FeedVersionID: fvid, Success: true})
+		case "failed":
+			testdb.ShouldInsert(t, atx, &FeedVersionImport{FeedVersionID: fvid, Success: false})
+		case "in_progress":
+			testdb.ShouldInsert(t, atx, &FeedVersionImport{FeedVersionID: fvid, InProgress: true})
+		}
+	}
+	return f, fvids
+}
+
+// setupGC creates feed versions fetched on consecutive days, oldest first, with the given import status.
+func setupGC(t *testing.T, atx tldb.Adapter, imports []string, activeIdx int) (Feed, []int) {
+	fetched := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	fvs := []testFeedVersion{}
+	for i, status := range imports {
+		fvs = append(fvs, testFeedVersion{
+			Import:               status,
+			EarliestCalendarDate: fetched.AddDate(0, 0, i),
+			LatestCalendarDate:   fetched.AddDate(0, 0, i+30),
+		})
+	}
+	f, fvids := createTestFeedVersions(t, atx, fetched, fvs)
+	if activeIdx >= 0 {
+		testdb.ShouldInsert(t, atx, &FeedState{FeedID: f.ID, FeedVersionID: tl.OptionalKey{NullInt64: sql.NullInt64{Int64: int64(fvids[activeIdx]), Valid: true}}})
+	}
+	return f, fvids
+}
+
+func TestFindGCFeedVersions(t *testing.T) {
+	// Feed versions, oldest first
+	imports := []string{"success", "success", "failed", "success", "in_progress", "success", "", ""}
+	tcs := []struct {
+		name   string
+		opts   GCOptions
+		active int
+		keep   []bool
+	}{
+		{"keep latest 1", GCOptions{KeepLatest: 1}, -1, []bool{false, false, false, false, true, true, true, true}},
+		{"keep latest 2", GCOptions{KeepLatest: 2}, -1, []bool{false, false, false, true, true, true, true, true}},
+		{"keep latest 0", GCOptions{}, -1, []bool{false, false, false, false, true, false, true, true}},
+		{"active", GCOptions{KeepLatest: 1}, 0, []bool{true, false, false, false, true, true, true, true}},
+		{"service days", GCOptions{KeepServiceDays: 1, Now: time.Date(2020, 2, 2, 0, 0, 0, 0, time.UTC)}, -1, []bool{false, true, true, true, true, true, true, true}},
+		{"service days none", GCOptions{KeepServiceDays: 1, Now: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)}, -1, []bool{false, false, false, false, true, false, true, true}},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
+				_, fvids := setupGC(t, atx, imports, tc.active)
+				fvs, err := FindGCFeedVersions(atx, tc.opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				keep := map[int]bool{}
+				for _, fv := range fvs {
+					keep[fv.ID] = fv.Keep
+				}
+				for i, fvid := range fvids {
+					if keep[fvid] != tc.keep[i] {
+						t.Errorf("feed version %d (%s): got keep %t, expected %t", i, imports[i], keep[fvid], tc.keep[i])
+					}
+				}
+				return nil
+			})
+		})
+	}
+}
+
+func TestMainGC(t *testing.T) {
+	imports := []string{"success", "success", "success"}
+	t.Run("Delete", func(t *testing.T) {
+		testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
+			_, fvids := setupGC(t, atx, imports, -1)
+			dir, err := ioutil.TempDir("", "gc")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(dir)
+			fn := filepath.Join(dir, "testa.zip")
+			if err := ioutil.WriteFile(fn, []byte("test"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			removed, err := MainGC(context.Background(), &testdb.AdapterIgnoreTx{Adapter: atx}, GCOptions{KeepLatest: 2, Directory: dir})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(removed) != 1 || removed[0].ID != fvids[0] {
+				t.Fatalf("got %v, expected to remove feed version %d", removed, fvids[0])
+			}
+			count := 0
+			testdb.ShouldGet(t, atx, &count, "SELECT count(*) FROM feed_versions WHERE id = ?", fvids[0])
+			if count != 0 {
+				t.Error("expected feed version to be deleted")
+			}
+			if _, err := os.Stat(fn); !os.IsNotExist(err) {
+				t.Errorf("expected %s to be deleted", fn)
+			}
+			return nil
+		})
+	})
+	t.Run("Unimport", func(t *testing.T) {
+		testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
+			_, fvids := setupGC(t, atx, imports, -1)
+			if _, err := MainGC(context.Background(), &testdb.AdapterIgnoreTx{Adapter: atx}, GCOptions{KeepLatest: 1, Unimport: true}); err != nil {
+				t.Fatal(err)
+			}
+			for i, fvid := range fvids {
+				if count, exp := countRows(t, atx, "feed_version_gtfs_imports", fvid), map[bool]int{true: 1, false: 0}[i == 2]; count != exp {
+					t.Errorf("feed version %d: got %d imports, expected %d", i, count, exp)
+				}
+				count := 0
+				testdb.ShouldGet(t, atx, &count, "SELECT count(*) FROM feed_versions WHERE id = ?", fvid)
+				if count != 1 {
+					t.Errorf("feed version %d: expected feed version to be kept", i)
+				}
+			}
+			return nil
+		})
+	})
+	t.Run("DryRun", func(t *testing.T) {
+		testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
+			_, fvids := setupGC(t, atx, imports, -1)
+			removed, err := MainGC(context.Background(), &testdb.AdapterIgnoreTx{Adapter: atx}, GCOptions{KeepLatest: 1, DryRun: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(removed) != 2 {
+				t.Errorf("got %d feed versions, expected 2", len(removed))
+			}
+			for _, fvid := range fvids {
+				if countRows(t, atx, "feed_version_gtfs_imports", fvid) != 1 {
+					t.Errorf("feed version %d: expected import to be kept", fvid)
+				}
+			}
+			return nil
+		})
+	})
+	t.Run("BecameActive", func(t *testing.T) {
+		testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
+			f, fvids := setupGC(t, atx, imports, -1)
+			// The oldest feed version is activated after the retention policy was applied
+			adapter := &activateOnTx{Adapter: &testdb.AdapterIgnoreTx{Adapter: atx}, activate: func() {
+				testdb.ShouldInsert(t, atx, &FeedState{FeedID: f.ID, FeedVersionID: tl.OptionalKey{NullInt64: sql.NullInt64{Int64: int64(fvids[0]), Valid: true}}})
+			}}
+			removed, err := MainGC(context.Background(), adapter, GCOptions{KeepLatest: 1, Unimport: true})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(removed) != 1 || removed[0].ID != fvids[1] {
+				t.Fatalf("got %v, expected to remove feed version %d", removed, fvids[1])
+			}
+			if countRows(t, atx, "feed_version_gtfs_imports", fvids[0]) != 1 {
+				t.Error("expected active feed version to be kept")
+			}
+			return nil
+		})
+	})
+}
+
+// activateOnTx calls activate before the first Tx.
+type activateOnTx struct {
+	tldb.Adapter
+	activate func()
+}
+
+func (a *activateOnTx) Tx(cb func(tldb.Adapter) error) error {
+	if a.activate != nil {
+		a.activate()
+		a.activate = nil
+	}
+	return a.Adapter.Tx(cb)
+}
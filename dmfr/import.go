@@ -1,17 +1,19 @@
 package dmfr
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
+	sq "github.com/Masterminds/squirrel"
 	"github.com/interline-io/transitland-lib/copier"
 	"github.com/interline-io/transitland-lib/ext"
 	"github.com/interline-io/transitland-lib/internal/log"
 	"github.com/interline-io/transitland-lib/tl"
-	"github.com/interline-io/transitland-lib/tl/causes"
 	"github.com/interline-io/transitland-lib/tlcsv"
 	"github.com/interline-io/transitland-lib/tldb"
 )
@@ -25,6 +27,13 @@ type ImportOptions struct {
 	Activate             bool
 	CreateMissingShapes  bool
 	InterpolateStopTimes bool
+	Rules                *copier.RuleSet
+	TripWorkers          int
+	DiskCache            bool
+	ProgressHandler      copier.ProgressHandler
+	Resumable            bool
+	ResumeInProgress     bool
+	StaleImport          time.Duration
 }
 
 // ImportResult contains the results of a feed import.
@@ -32,10 +41,6 @@ type ImportResult struct {
 	FeedVersionImport FeedVersionImport
 }
 
-type canContext interface {
-	Context() *causes.Context
-}
-
 func copyResultCounts(result copier.CopyResult) FeedVersionImport {
 	fvi := FeedVersionImport{}
 	fvi.EntityCount = EntityCounter{}
@@ -64,12 +69,8 @@ func copyResultCounts(result copier.CopyResult) FeedVersionImport {
 	for k, v := range result.SkipEntityMarkedCount {
 		fvi.SkipEntityMarkedCount[k] = v
 	}
-	for _, e := range result.Warnings {
-		fn := ""
-		if a, ok := e.(canContext); ok {
-			fn = a.Context().Filename
-		}
-		fvi.WarningCount[fn]++
+	for k, v := range result.WarningCount {
+		fvi.WarningCount[k.Filename] += v
 	}
 	return fvi
 }
@@ -108,6 +109,15 @@ func FindImportableFeeds(adapter tldb.Adapter) ([]int, error) {
 
 // MainImportFeedVersion create FVI and run Copier inside a Tx.
 func MainImportFeedVersion(adapter tldb.Adapter, opts ImportOptions) (ImportResult, error) {
+	return MainImportFeedVersionContext(context.Background(), adapter, opts)
+}
+
+// MainImportFeedVersionContext is like MainImportFeedVersion, but stops when the context is done.
+// A cancelled import is rolled back and the FVI is saved as failed.
+// If opts.Resumable is set, the import is written in stages and a failed import is resumed; see importFeedVersionStaged.
+// Imports still marked in progress, e.g. after a crash, are only resumed if opts.ResumeInProgress is set,
+// or if they have not been updated for opts.StaleImport; see ImportOptions.canRetry.
+func MainImportFeedVersionContext(ctx context.Context, adapter tldb.Adapter, opts ImportOptions) (ImportResult, error) {
 	// Get FV
 	fvi := FeedVersionImport{FeedVersionID: opts.FeedVersionID, InProgress: true}
 	fv := tl.FeedVersion{ID: opts.FeedVersionID}
@@ -115,9 +125,17 @@ func MainImportFeedVersion(adapter tldb.Adapter, opts ImportOptions) (ImportResu
 		return ImportResult{FeedVersionImport: fvi}, err
 	}
 	// Check FVI
-	checkfviid := 0
-	if err := adapter.Get(&checkfviid, `SELECT id FROM feed_version_gtfs_imports WHERE feed_version_id = ?`, fv.ID); err == sql.ErrNoRows {
+	checkfvi := FeedVersionImport{}
+	if err := adapter.Get(&checkfvi, `SELECT id, success, in_progress, checkpoint, created_at, updated_at FROM feed_version_gtfs_imports WHERE feed_version_id = ?`, fv.ID); err == sql.ErrNoRows {
 		// ok
+	} else if err == nil && opts.canRetry(checkfvi, time.Now()) {
+		// Resume a failed or abandoned import
+		if checkfvi.InProgress {
+			log.Info("Resuming import in progress since %s", checkfvi.UpdatedAt.Format(time.RFC3339))
+		}
+		fvi.ID = checkfvi.ID
+		fvi.CreatedAt = checkfvi.CreatedAt
+		fvi.Checkpoint = checkfvi.Checkpoint
 	} else if err == nil {
 		fvi.ExceptionLog = "FeedVersionImport record already exists, skipping"
 		return ImportResult{FeedVersionImport: fvi}, nil
@@ -125,9 +143,14 @@ func MainImportFeedVersion(adapter tldb.Adapter, opts ImportOptions) (ImportResu
 		// Serious error
 		return ImportResult{FeedVersionImport: fvi}, err
 	}
-	// Create FVI
+	// Create or update FVI
 	fvi.UpdateTimestamps()
-	if fviid, err := adapter.Insert(&fvi); err == nil {
+	if fvi.ID > 0 {
+		if err := adapter.Update(&fvi); err != nil {
+			log.Error("Error updating FeedVersionImport: %s", err.Error())
+			return ImportResult{FeedVersionImport: fvi}, err
+		}
+	} else if fviid, err := adapter.Insert(&fvi); err == nil {
 		// note: handle OK first
 		fvi.ID = fviid
 	} else {
@@ -135,47 +158,38 @@ func MainImportFeedVersion(adapter tldb.Adapter, opts ImportOptions) (ImportResu
 		log.Error("Error creating FeedVersionImport: %s", err.Error())
 		return ImportResult{FeedVersionImport: fvi}, err
 	}
-	// Import
-	fviresult := FeedVersionImport{} // keep result
-	errImport := adapter.Tx(func(atx tldb.Adapter) error {
-		var err error
-		fviresult, err = ImportFeedVersion(atx, fv, opts)
-		if err != nil {
-			return err
-		}
-		required := []string{"agency.txt", "routes.txt", "stops.txt", "trips.txt", "stop_times.txt"}
-		for _, fn := range required {
-			if c := fviresult.EntityCount[fn]; c == 0 {
-				return fmt.Errorf("failed to import any entities from required file '%s'", fn)
+	// Save progress outside the import transaction, so it is visible to other connections.
+	// SQLite allows only one writer, and the import transaction holds the write lock.
+	saveProgress := adapter.DBX().DriverName() == "postgres"
+	importOpts := opts
+	importOpts.ProgressHandler = copier.ProgressFunc(func(p copier.Progress) {
+		fvi.Progress = p.Percent()
+		if saveProgress {
+			q := adapter.Sqrl().Update(fvi.TableName()).Set("progress", fvi.Progress).Set("updated_at", time.Now()).Where(sq.Eq{"id": fvi.ID})
+			if _, err := q.Exec(); err != nil {
+				log.Error("Error saving FeedVersionImport progress: %s", err.Error())
 			}
 		}
-		// Update route_stops, agency_geometries, etc...
-		log.Info("Finalizing import")
-		if err := AfterFeedVersionImport(atx, fv.ID); err != nil {
-			return fmt.Errorf("error finalizing import: %s", err.Error())
+		if opts.ProgressHandler != nil {
+			opts.ProgressHandler.HandleProgress(p)
 		}
-		if opts.Activate {
-			log.Info("Activating feed version")
-			if err := ActivateFeedVersion(atx, opts.FeedVersionID); err != nil {
-				return fmt.Errorf("error activating feed version: %s", err.Error())
+	})
+	// Import
+	fviresult := FeedVersionImport{} // keep result
+	var errImport error
+	if opts.Resumable {
+		fviresult, errImport = importFeedVersionStaged(ctx, adapter, fv, &fvi, importOpts)
+	} else {
+		errImport = adapter.Tx(func(atx tldb.Adapter) error {
+			var err error
+			fviresult, err = ImportFeedVersionContext(ctx, atx, fv, importOpts)
+			if err != nil {
+				return err
 			}
-		}
-		// Update FVI with results, inside tx
-		fviresult.ID = fvi.ID
-		fviresult.CreatedAt = fvi.CreatedAt
-		fviresult.FeedVersionID = opts.FeedVersionID
-		fviresult.ImportLevel = 4
-		fviresult.Success = true
-		fviresult.InProgress = false
-		fviresult.ExceptionLog = ""
-		fviresult.UpdateTimestamps()
-		if err := atx.Update(&fviresult); err != nil {
-			// Serious error
-			log.Error("Error saving FeedVersionImport: %s", err.Error())
+			fviresult, err = finishImport(ctx, atx, fvi, fviresult, opts)
 			return err
-		}
-		return err
-	})
+		})
+	}
 	// FVI error handling has to be outside of above tx, which will have aborted
 	if errImport != nil {
 		fvi.Success = false
@@ -192,8 +206,73 @@ func MainImportFeedVersion(adapter tldb.Adapter, opts ImportOptions) (ImportResu
 	return ImportResult{FeedVersionImport: fviresult}, nil
 }
 
+// canRetry returns true if an unsuccessful import can be resumed.
+// Imports in progress may have been abandoned by a crashed process; they are resumed with ResumeInProgress,
+// or if they have not been updated for StaleImport.
+func (opts ImportOptions) canRetry(fvi FeedVersionImport, now time.Time) bool {
+	if !opts.Resumable || fvi.Success {
+		return false
+	}
+	if !fvi.InProgress || opts.ResumeInProgress {
+		return true
+	}
+	return opts.StaleImport > 0 && fvi.UpdatedAt.Before(now.Add(-opts.StaleImport))
+}
+
+// finishImport checks the import results, finalizes and optionally activates the feed version, and saves the FVI as successful.
+// It must be run inside a transaction.
+func finishImport(ctx context.Context, atx tldb.Adapter, fvi FeedVersionImport, fviresult FeedVersionImport, opts ImportOptions) (FeedVersionImport, error) {
+	required := []string{"agency.txt", "routes.txt", "stops.txt", "trips.txt", "stop_times.txt"}
+	for _, fn := range required {
+		if c := fviresult.EntityCount[fn]; c == 0 {
+			return fviresult, fmt.Errorf("failed to import any entities from required file '%s'", fn)
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return fviresult, err
+	}
+	// Update route_stops, agency_geometries, etc...
+	log.Info("Finalizing import")
+	if err := AfterFeedVersionImport(atx, fvi.FeedVersionID); err != nil {
+		return fviresult, fmt.Errorf("error finalizing import: %s", err.Error())
+	}
+	if opts.Activate {
+		log.Info("Activating feed version")
+		if err := ActivateFeedVersion(atx, fvi.FeedVersionID); err != nil {
+			return fviresult, fmt.Errorf("error activating feed version: %s", err.Error())
+		}
+	}
+	// Update FVI with results, inside tx
+	fviresult.ID = fvi.ID
+	fviresult.CreatedAt = fvi.CreatedAt
+	fviresult.FeedVersionID = fvi.FeedVersionID
+	fviresult.ImportLevel = 4
+	fviresult.Success = true
+	fviresult.InProgress = false
+	fviresult.Progress = 100
+	fviresult.Checkpoint = fvi.Checkpoint
+	fviresult.ExceptionLog = ""
+	fviresult.UpdateTimestamps()
+	if err := atx.Update(&fviresult); err != nil {
+		// Serious error
+		log.Error("Error saving FeedVersionImport: %s", err.Error())
+		return fviresult, err
+	}
+	return fviresult, nil
+}
+
 // ImportFeedVersion .
 func ImportFeedVersion(atx tldb.Adapter, fv tl.FeedVersion, opts ImportOptions) (FeedVersionImport, error) {
+	return ImportFeedVersionContext(context.Background(), atx, fv, opts)
+}
+
+// ImportFeedVersionContext is like ImportFeedVersion, but stops copying when the context is done.
+func ImportFeedVersionContext(ctx context.Context, atx tldb.Adapter, fv tl.FeedVersion, opts ImportOptions) (FeedVersionImport, error) {
+	return importFeedVersion(ctx, atx, fv, opts, nil)
+}
+
+// importFeedVersion copies the feed version; configure, if not nil, is called before copying.
+func importFeedVersion(ctx context.Context, atx tldb.Adapter, fv tl.FeedVersion, opts ImportOptions, configure func(*copier.Copier) error) (FeedVersionImport, error) {
 	fvi := FeedVersionImport{FeedVersionID: fv.ID}
 	// Get Reader
 	url := fv.File
@@ -228,12 +307,35 @@ func ImportFeedVersion(atx tldb.Adapter, fv tl.FeedVersion, opts ImportOptions)
 	// Settable options
 	cp.CreateMissingShapes = opts.CreateMissingShapes
 	cp.InterpolateStopTimes = opts.InterpolateStopTimes
+	cp.Rules = opts.Rules
+	if opts.TripWorkers > 0 {
+		cp.Workers = opts.TripWorkers
+	}
+	cp.DiskCache = opts.DiskCache
+	if opts.ProgressHandler != nil {
+		// Estimate totals from the feed version file infos, if available
+		fvfis := []FeedVersionFileInfo{}
+		if err := atx.Select(&fvfis, "SELECT * FROM feed_version_file_infos WHERE feed_version_id = ?", fv.ID); err != nil {
+			return fvi, err
+		}
+		cp.EstimatedRows = map[string]int{}
+		for _, fvfi := range fvfis {
+			cp.EstimatedRows[fvfi.Name] = int(fvfi.Rows)
+		}
+		cp.ProgressHandler = opts.ProgressHandler
+	}
 	// Non-settable options
 	cp.AllowEntityErrors = false
 	cp.AllowReferenceErrors = false
 	cp.NormalizeServiceIDs = true
+	cp.ErrorLimit = 0 // only counts are saved
+	if configure != nil {
+		if err := configure(&cp); err != nil {
+			return fvi, err
+		}
+	}
 	// Go
-	cpresult := cp.Copy()
+	cpresult := cp.CopyContext(ctx)
 	if cpresult == nil {
 		return fvi, errors.New("copy result was nil")
 	}
@@ -2,6 +2,7 @@ package dmfr
 
 import (
 	"bufio"
+	"context"
 	"flag"
 	"os"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/interline-io/transitland-lib/copier"
 	"github.com/interline-io/transitland-lib/internal/log"
 	"github.com/interline-io/transitland-lib/tldb"
 )
@@ -22,6 +24,8 @@ type ImportCommand struct {
 	FetchedSince  string
 	Latest        bool
 	DryRun        bool
+	Enqueue       bool
+	Progress      bool
 	FeedIDs       []string
 	FVIDs         arrayFlags
 	FVSHA1        arrayFlags
@@ -34,6 +38,7 @@ func (cmd *ImportCommand) Parse(args []string) error {
 	extflags := arrayFlags{}
 	fvidfile := ""
 	fvsha1file := ""
+	rulesfile := ""
 	fl := flag.NewFlagSet("import", flag.ExitOnError)
 	fl.Usage = func() {
 		log.Print("Usage: import [feedids...]")
@@ -55,12 +60,27 @@ func (cmd *ImportCommand) Parse(args []string) error {
 	fl.BoolVar(&cmd.ImportOptions.Activate, "activate", false, "Set as active feed version after import")
 	fl.BoolVar(&cmd.ImportOptions.InterpolateStopTimes, "interpolate-stop-times", false, "Interpolate missing StopTime arrival/departure values")
 	fl.BoolVar(&cmd.ImportOptions.CreateMissingShapes, "create-missing-shapes", false, "Create missing Shapes from Trip stop-to-stop geometries")
+	fl.StringVar(&rulesfile, "rules", "", "JSON file with rules to change the severity of errors and warnings")
+	fl.BoolVar(&cmd.Progress, "progress", false, "Log progress while importing each feed version")
+	fl.BoolVar(&cmd.Enqueue, "enqueue", false, "Add import jobs to the job queue instead of importing; import options are set on the worker")
+	fl.BoolVar(&cmd.ImportOptions.Resumable, "resumable", false, "Commit each file separately, and resume failed imports from the last completed file")
+	fl.BoolVar(&cmd.ImportOptions.ResumeInProgress, "resume-in-progress", false, "With -resumable, also resume imports that are marked in progress, e.g. after a crash")
+	fl.DurationVar(&cmd.ImportOptions.StaleImport, "stale-import", 0, "With -resumable, also resume imports that are marked in progress but have not been updated for this long")
+	fl.BoolVar(&cmd.ImportOptions.DiskCache, "disk-cache", false, "Cache trips, shapes, and stop patterns in a temporary file instead of memory")
+	fl.IntVar(&cmd.ImportOptions.TripWorkers, "trip-workers", 1, "Number of workers used to validate and interpolate trips and stop_times in each feed version")
 	fl.Parse(args)
 	cmd.FeedIDs = fl.Args()
 	if cmd.DBURL == "" {
 		cmd.DBURL = os.Getenv("DMFR_DATABASE_URL")
 	}
 	cmd.ImportOptions.Extensions = extflags
+	if rulesfile != "" {
+		rules, err := copier.LoadRuleSet(rulesfile)
+		if err != nil {
+			return err
+		}
+		cmd.ImportOptions.Rules = rules
+	}
 	if fvidfile != "" {
 		lines, err := getFileLines(fvidfile)
 		if err != nil {
@@ -99,8 +119,25 @@ func (cmd *ImportCommand) Run() error {
 		From("feed_versions").
 		Join("current_feeds ON current_feeds.id = feed_versions.feed_id").
 		LeftJoin("feed_version_gtfs_imports ON feed_versions.id = feed_version_gtfs_imports.feed_version_id").
-		Where("feed_version_gtfs_imports.id IS NULL").
 		OrderBy("feed_versions.id")
+	if cmd.ImportOptions.Resumable {
+		// Also retry failed imports, and abandoned imports that are still in progress
+		retry := sq.Or{
+			sq.Eq{"feed_version_gtfs_imports.id": nil},
+			sq.Eq{"feed_version_gtfs_imports.success": false, "feed_version_gtfs_imports.in_progress": false},
+		}
+		if cmd.ImportOptions.ResumeInProgress {
+			retry = append(retry, sq.Eq{"feed_version_gtfs_imports.success": false})
+		} else if cmd.ImportOptions.StaleImport > 0 {
+			retry = append(retry, sq.And{
+				sq.Eq{"feed_version_gtfs_imports.success": false, "feed_version_gtfs_imports.in_progress": true},
+				sq.Lt{"feed_version_gtfs_imports.updated_at": time.Now().Add(-cmd.ImportOptions.StaleImport)},
+			})
+		}
+		q = q.Where(retry)
+	} else {
+		q = q.Where("feed_version_gtfs_imports.id IS NULL")
+	}
 	if cmd.Latest {
 		// Only fetch latest feed version for each feed
 		q = q.
@@ -142,13 +179,20 @@ func (cmd *ImportCommand) Run() error {
 	if err != nil {
 		return err
 	}
+	if cmd.Enqueue && !cmd.DryRun {
+		jobs := []Job{}
+		for _, fvid := range qrs {
+			jobs = append(jobs, NewImportJob(fvid))
+		}
+		return enqueueJobs(cmd.Adapter, jobs)
+	}
 	///////////////
 	// Here we go
 	log.Info("Importing %d feed versions", len(qrs))
 	jobs := make(chan ImportOptions, len(qrs))
 	results := make(chan ImportResult, len(qrs))
 	for _, fvid := range qrs {
-		jobs <- ImportOptions{
+		opts := ImportOptions{
 			FeedVersionID:        fvid,
 			Directory:            cmd.ImportOptions.Directory,
 			S3:                   cmd.ImportOptions.S3,
@@ -156,20 +200,35 @@ func (cmd *ImportCommand) Run() error {
 			Activate:             cmd.ImportOptions.Activate,
 			InterpolateStopTimes: cmd.ImportOptions.InterpolateStopTimes,
 			CreateMissingShapes:  cmd.ImportOptions.CreateMissingShapes,
+			Rules:                cmd.ImportOptions.Rules,
+			TripWorkers:          cmd.ImportOptions.TripWorkers,
+			DiskCache:            cmd.ImportOptions.DiskCache,
+			Resumable:            cmd.ImportOptions.Resumable,
+			ResumeInProgress:     cmd.ImportOptions.ResumeInProgress,
+			StaleImport:          cmd.ImportOptions.StaleImport,
 		}
+		if cmd.Progress {
+			fvid := fvid
+			opts.ProgressHandler = copier.ProgressFunc(func(p copier.Progress) {
+				log.Info("FeedVersion %d: %s", fvid, p)
+			})
+		}
+		jobs <- opts
 	}
 	close(jobs)
-	// Start workers
+	// Start workers; stop on interrupt
+	ctx, cancel := signalContext()
+	defer cancel()
 	var wg sync.WaitGroup
 	for w := 0; w < cmd.Workers; w++ {
 		wg.Add(1)
-		go dmfrImportWorker(w, cmd.Adapter, cmd.DryRun, jobs, results, &wg)
+		go dmfrImportWorker(ctx, w, cmd.Adapter, cmd.DryRun, jobs, results, &wg)
 	}
 	wg.Wait()
 	return nil
 }
 
-func dmfrImportWorker(id int, adapter tldb.Adapter, dryrun bool, jobs <-chan ImportOptions, results chan<- ImportResult, wg *sync.WaitGroup) {
+func dmfrImportWorker(ctx context.Context, id int, adapter tldb.Adapter, dryrun bool, jobs <-chan ImportOptions, results chan<- ImportResult, wg *sync.WaitGroup) {
 	type qr struct {
 		FeedVersionID   int
 		FeedID          int
@@ -177,6 +236,10 @@ func dmfrImportWorker(id int, adapter tldb.Adapter, dryrun bool, jobs <-chan Imp
 		FeedVersionSHA1 string
 	}
 	for opts := range jobs {
+		if ctx.Err() != nil {
+			log.Info("FeedVersion %d: cancelled", opts.FeedVersionID)
+			continue
+		}
 		q := qr{}
 		if err := adapter.Get(&q, "SELECT feed_versions.id as feed_version_id, feed_versions.feed_id as feed_id, feed_versions.sha1 as feed_version_sha1, current_feeds.onestop_id as feed_onestop_id FROM feed_versions INNER JOIN current_feeds ON current_feeds.id = feed_versions.feed_id WHERE feed_versions.id = ?", opts.FeedVersionID); err != nil {
 			log.Error("Could not get details for FeedVersion %d", opts.FeedVersionID)
@@ -188,7 +251,7 @@ func dmfrImportWorker(id int, adapter tldb.Adapter, dryrun bool, jobs <-chan Imp
 		}
 		log.Info("Feed %s (id:%d): FeedVersion %s (id:%d): begin", q.FeedOnestopID, q.FeedID, q.FeedVersionSHA1, q.FeedVersionID)
 		t := time.Now()
-		result, err := MainImportFeedVersion(adapter, opts)
+		result, err := MainImportFeedVersionContext(ctx, adapter, opts)
 		t2 := float64(time.Now().UnixNano()-t.UnixNano()) / 1e9 // 1000000000.0
 		if err != nil {
 			log.Error("Feed %s (id:%d): FeedVersion %s (id:%d): critical failure, rolled back: %s (t:%0.2fs)", q.FeedOnestopID, q.FeedID, q.FeedVersionSHA1, q.FeedVersionID, result.FeedVersionImport.ExceptionLog, t2)
@@ -0,0 +1,235 @@
+package dmfr
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/interline-io/transitland-lib/copier"
+	"github.com/interline-io/transitland-lib/ext"
+	"github.com/interline-io/transitland-lib/internal/log"
+	"github.com/interline-io/transitland-lib/tl"
+	"github.com/interline-io/transitland-lib/tldb"
+)
+
+// importStages are the groups of files written by the Copier, in order, named by their first file.
+// Each stage lists the entities it writes; generated Shapes are written with Trips.
+var importStages = []struct {
+	name     string
+	entities []tl.Entity
+}{
+	{"agency.txt", []tl.Entity{&tl.Agency{}}},
+	{"routes.txt", []tl.Entity{&tl.Route{}}},
+	{"levels.txt", []tl.Entity{&tl.Level{}}},
+	{"stops.txt", []tl.Entity{&tl.Stop{}}},
+	{"pathways.txt", []tl.Entity{&tl.Pathway{}}},
+	{"fare_attributes.txt", []tl.Entity{&tl.FareAttribute{}, &tl.FareRule{}}},
+	{"calendar.txt", []tl.Entity{&tl.Calendar{}, &tl.CalendarDate{}}},
+	{"shapes.txt", []tl.Entity{&tl.Shape{}}},
+	{"trips.txt", []tl.Entity{&tl.Trip{}, &tl.StopTime{}}},
+	{"frequencies.txt", []tl.Entity{&tl.Frequency{}}},
+	{"transfers.txt", []tl.Entity{&tl.Transfer{}}},
+	{"feed_info.txt", []tl.Entity{&tl.FeedInfo{}}},
+}
+
+// resumeIDColumns are the columns used to find the database IDs of entities that may be referenced by later files.
+var resumeIDColumns = map[string]string{
+	"agency.txt":          "agency_id",
+	"routes.txt":          "route_id",
+	"levels.txt":          "level_id",
+	"stops.txt":           "stop_id",
+	"fare_attributes.txt": "fare_id",
+	"calendar.txt":        "service_id",
+	"shapes.txt":          "shape_id",
+	"trips.txt":           "trip_id",
+}
+
+type hasTableName interface {
+	TableName() string
+}
+
+// importFeedVersionStaged copies the feed version one stage at a time, committing each stage with the FVI Checkpoint.
+// Entities written by incomplete stages of an earlier attempt are deleted first.
+// Completed stages are read and validated again to restore the Copier state, but not written.
+// The feed version is finalized, activated, and marked successful in a single transaction after all stages are complete.
+func importFeedVersionStaged(ctx context.Context, adapter tldb.Adapter, fv tl.FeedVersion, fvi *FeedVersionImport, opts ImportOptions) (FeedVersionImport, error) {
+	completed := map[string]bool{}
+	for _, stage := range strings.Split(fvi.Checkpoint, ",") {
+		if stage != "" {
+			completed[stage] = true
+		}
+	}
+	if len(completed) > 0 {
+		log.Info("Resuming import after stages: %s", fvi.Checkpoint)
+	}
+	if err := adapter.Tx(func(atx tldb.Adapter) error {
+		return deleteIncompleteStages(atx, fv.ID, completed, opts.Extensions)
+	}); err != nil {
+		return FeedVersionImport{}, err
+	}
+	fviresult, err := importFeedVersion(ctx, adapter, fv, opts, func(cp *copier.Copier) error {
+		writer, ok := cp.Writer.(*tldb.Writer)
+		if !ok {
+			return fmt.Errorf("resumable imports require a database writer")
+		}
+		rw := &resumeWriter{Writer: writer, fvi: fvi, adapter: adapter, completed: completed}
+		cp.Writer = rw
+		return cp.AddHook(rw)
+	})
+	if err != nil {
+		return fviresult, err
+	}
+	err = adapter.Tx(func(atx tldb.Adapter) error {
+		var err error
+		fviresult, err = finishImport(ctx, atx, *fvi, fviresult, opts)
+		return err
+	})
+	return fviresult, err
+}
+
+// deleteIncompleteStages deletes the entities written by stages that are not complete, and by extensions.
+func deleteIncompleteStages(atx tldb.Adapter, fvid int, completed map[string]bool, extensions []string) error {
+	tables := []string{}
+	for _, extName := range extensions {
+		e, err := ext.GetExtension(extName)
+		if err != nil {
+			return err
+		}
+		ents := e.Entities()
+		for i := len(ents) - 1; i >= 0; i-- {
+			if v, ok := ents[i].(hasTableName); ok {
+				tables = append(tables, v.TableName())
+			}
+		}
+	}
+	// Delete in reverse order, since later entities reference earlier entities
+	for i := len(importStages) - 1; i >= 0; i-- {
+		stage := importStages[i]
+		if completed[stage.name] {
+			continue
+		}
+		if stage.name == "trips.txt" && completed["shapes.txt"] {
+			// Shapes generated from trips
+			q := atx.Sqrl().Delete("gtfs_shapes").Where(sq.Eq{"feed_version_id": fvid, "generated": true})
+			if _, err := q.Exec(); err != nil {
+				return err
+			}
+		}
+		for j := len(stage.entities) - 1; j >= 0; j-- {
+			tables = append(tables, stage.entities[j].(hasTableName).TableName())
+		}
+	}
+	for _, table := range tables {
+		if _, err := atx.Sqrl().Delete(table).Where(sq.Eq{"feed_version_id": fvid}).Exec(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resumeWriter is a Writer and copier hook for resumable imports.
+// Each stage is written in its own transaction, and the stage is saved to the FVI Checkpoint in the same transaction.
+// Entities in completed stages are not written; the IDs of existing entities are returned instead.
+type resumeWriter struct {
+	*tldb.Writer
+	fvi       *FeedVersionImport
+	adapter   tldb.Adapter
+	completed map[string]bool
+	skip      bool
+	ids       map[string]map[string]int
+}
+
+// FileGroup skips writing if the stage is complete, otherwise writes the stage and saves the checkpoint in a transaction.
+func (w *resumeWriter) FileGroup(cp *copier.Copier, filenames []string, copyGroup func() error) error {
+	stage := filenames[0]
+	w.skip = w.completed[stage]
+	if w.skip {
+		return copyGroup()
+	}
+	checkpoint := stage
+	if w.fvi.Checkpoint != "" {
+		checkpoint = w.fvi.Checkpoint + "," + stage
+	}
+	err := w.adapter.Tx(func(atx tldb.Adapter) error {
+		prev := w.Writer.Adapter
+		w.Writer.Adapter = atx
+		defer func() { w.Writer.Adapter = prev }()
+		if err := copyGroup(); err != nil {
+			return err
+		}
+		q := atx.Sqrl().Update(w.fvi.TableName()).Set("checkpoint", checkpoint).Set("updated_at", time.Now()).Where(sq.Eq{"id": w.fvi.ID})
+		_, err := q.Exec()
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	w.fvi.Checkpoint = checkpoint
+	w.completed[stage] = true
+	return nil
+}
+
+// AddEntity writes the entity, or returns the ID of the existing entity.
+func (w *resumeWriter) AddEntity(ent tl.Entity) (string, error) {
+	if !w.skip {
+		return w.Writer.AddEntity(ent)
+	}
+	return w.existingID(ent)
+}
+
+// AddEntities writes the entities, or returns the IDs of the existing entities.
+func (w *resumeWriter) AddEntities(ents []tl.Entity) ([]string, error) {
+	if !w.skip {
+		return w.Writer.AddEntities(ents)
+	}
+	eids := []string{}
+	for _, ent := range ents {
+		eid, err := w.existingID(ent)
+		if err != nil {
+			return eids, err
+		}
+		eids = append(eids, eid)
+	}
+	return eids, nil
+}
+
+// existingID returns the database ID of an entity written by an earlier attempt.
+// Entities that are not referenced by other files, or were generated, return an empty ID.
+func (w *resumeWriter) existingID(ent tl.Entity) (string, error) {
+	efn := ent.Filename()
+	col, ok := resumeIDColumns[efn]
+	if !ok {
+		return "", nil
+	}
+	ids, ok := w.ids[efn]
+	if !ok {
+		table := ent.(hasTableName).TableName()
+		rows := []struct {
+			ID       int
+			EntityID string
+		}{}
+		q := fmt.Sprintf("SELECT id, %s AS entity_id FROM %s WHERE feed_version_id = ?", col, table)
+		if err := w.adapter.Select(&rows, q, w.FeedVersionID); err != nil {
+			return "", err
+		}
+		ids = map[string]int{}
+		for _, row := range rows {
+			ids[row.EntityID] = row.ID
+		}
+		if w.ids == nil {
+			w.ids = map[string]map[string]int{}
+		}
+		w.ids[efn] = ids
+	}
+	id, ok := ids[ent.EntityID()]
+	if !ok {
+		return "", nil
+	}
+	if v, ok := ent.(interface{ SetID(int) }); ok {
+		v.SetID(id)
+	}
+	return strconv.Itoa(id), nil
+}
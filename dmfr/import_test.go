@@ -1,11 +1,20 @@
 package dmfr
 
 import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/interline-io/transitland-lib/copier"
 	"github.com/interline-io/transitland-lib/internal/testdb"
 	"github.com/interline-io/transitland-lib/internal/testutil"
 	"github.com/interline-io/transitland-lib/tl"
+	"github.com/interline-io/transitland-lib/tlcsv"
 	"github.com/interline-io/transitland-lib/tldb"
 )
 
@@ -62,6 +71,9 @@ func TestMainImportFeedVersion(t *testing.T) {
 			if fvi.InProgress != false {
 				t.Errorf("expected in_progress = false")
 			}
+			if fvi.Progress != 100 {
+				t.Errorf("got progress %f, expected 100", fvi.Progress)
+			}
 			count := 0
 			expstops := testutil.ExampleDir.Counts["stops.txt"]
 			testdb.ShouldGet(t, atx, &count, "SELECT count(*) FROM gtfs_stops WHERE feed_version_id = ?", fvid)
@@ -75,6 +87,37 @@ func TestMainImportFeedVersion(t *testing.T) {
 			return nil
 		})
 	})
+	t.Run("Progress", func(t *testing.T) {
+		testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
+			fvid := setup(atx, testutil.ExampleDir.URL)
+			// Estimated totals are read from the feed version file infos
+			reader, err := tlcsv.NewReader(testutil.ExampleDir.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			fvfis, err := NewFeedVersionFileInfosFromReader(reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, fvfi := range fvfis {
+				fvfi.FeedVersionID = fvid
+				testdb.ShouldInsert(t, atx, &fvfi)
+			}
+			ps := []copier.Progress{}
+			handler := copier.ProgressFunc(func(p copier.Progress) { ps = append(ps, p) })
+			atx2 := testdb.AdapterIgnoreTx{Adapter: atx}
+			if _, err := MainImportFeedVersion(&atx2, ImportOptions{FeedVersionID: fvid, ProgressHandler: handler}); err != nil {
+				t.Fatal(err)
+			}
+			if len(ps) == 0 {
+				t.Fatal("expected progress")
+			}
+			if last := ps[len(ps)-1]; last.Percent() != 100 {
+				t.Errorf("got %f percent, expected 100", last.Percent())
+			}
+			return nil
+		})
+	})
 	t.Run("Failed", func(t *testing.T) {
 		fvid := 0
 		err := testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
@@ -104,6 +147,222 @@ func TestMainImportFeedVersion(t *testing.T) {
 	})
 }
 
+func TestMainImportFeedVersion_Resumable(t *testing.T) {
+	err := testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
+		fvid := testdb.ShouldInsert(t, atx, &tl.FeedVersion{File: testutil.ExampleDir.URL})
+		atx2 := testdb.AdapterIgnoreTx{Adapter: atx}
+		opts := ImportOptions{FeedVersionID: fvid, Resumable: true, CreateMissingShapes: true}
+		if _, err := MainImportFeedVersion(&atx2, opts); err != nil {
+			t.Fatal(err)
+		}
+		fvi := FeedVersionImport{}
+		testdb.ShouldGet(t, atx, &fvi, "SELECT * FROM feed_version_gtfs_imports WHERE feed_version_id = ?", fvid)
+		if !fvi.Success || fvi.InProgress {
+			t.Errorf("expected success = true and in_progress = false")
+		}
+		if stages := strings.Split(fvi.Checkpoint, ","); len(stages) != len(importStages) {
+			t.Errorf("got checkpoint '%s', expected %d stages", fvi.Checkpoint, len(importStages))
+		}
+		stopids := []int{}
+		testdb.ShouldSelect(t, atx, &stopids, "SELECT id FROM gtfs_stops WHERE feed_version_id = ? ORDER BY id", fvid)
+		// Simulate a failure after stops; trips and stop_times from the failed attempt are still present
+		if _, err := atx.DBX().Exec(atx.DBX().Rebind("UPDATE feed_version_gtfs_imports SET success = ?, checkpoint = ? WHERE id = ?"), false, "agency.txt,routes.txt,levels.txt,stops.txt", fvi.ID); err != nil {
+			t.Fatal(err)
+		}
+		// A retry without the option is skipped
+		if result, err := MainImportFeedVersion(&atx2, ImportOptions{FeedVersionID: fvid}); err != nil || result.FeedVersionImport.Success {
+			t.Errorf("expected import to be skipped")
+		}
+		// Resume
+		result, err := MainImportFeedVersion(&atx2, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !result.FeedVersionImport.Success {
+			t.Errorf("expected success = true")
+		}
+		for _, fn := range []string{"stops.txt", "trips.txt", "stop_times.txt"} {
+			if c, exp := result.FeedVersionImport.EntityCount[fn], testutil.ExampleDir.Counts[fn]; c != exp {
+				t.Errorf("got %d %s in fvi result, expected %d", c, fn, exp)
+			}
+		}
+		// Completed stages are not written again; incomplete stages are replaced
+		stopids2 := []int{}
+		testdb.ShouldSelect(t, atx, &stopids2, "SELECT id FROM gtfs_stops WHERE feed_version_id = ? ORDER BY id", fvid)
+		if !testutil.CompareSliceInt(stopids, stopids2) {
+			t.Errorf("got stop ids %v, expected %v", stopids2, stopids)
+		}
+		count := 0
+		testdb.ShouldGet(t, atx, &count, "SELECT count(*) FROM gtfs_trips WHERE feed_version_id = ?", fvid)
+		if exp := testutil.ExampleDir.Counts["trips.txt"]; count != exp {
+			t.Errorf("got %d trips, expected %d", count, exp)
+		}
+		testdb.ShouldGet(t, atx, &count, "SELECT count(*) FROM gtfs_stop_times WHERE feed_version_id = ?", fvid)
+		if exp := testutil.ExampleDir.Counts["stop_times.txt"]; count != exp {
+			t.Errorf("got %d stop_times, expected %d", count, exp)
+		}
+		testdb.ShouldGet(t, atx, &count, "SELECT count(*) FROM gtfs_stop_times INNER JOIN gtfs_stops ON gtfs_stops.id = gtfs_stop_times.stop_id WHERE gtfs_stop_times.feed_version_id = ?", fvid)
+		if exp := testutil.ExampleDir.Counts["stop_times.txt"]; count != exp {
+			t.Errorf("got %d stop_times referencing stops, expected %d", count, exp)
+		}
+		// Resume after all stages are complete, e.g. if finalizing failed
+		if _, err := atx.DBX().Exec(atx.DBX().Rebind("UPDATE feed_version_gtfs_imports SET success = ? WHERE id = ?"), false, fvi.ID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := MainImportFeedVersion(&atx2, opts); err != nil {
+			t.Fatal(err)
+		}
+		testdb.ShouldGet(t, atx, &count, "SELECT count(*) FROM gtfs_stop_times WHERE feed_version_id = ?", fvid)
+		if exp := testutil.ExampleDir.Counts["stop_times.txt"]; count != exp {
+			t.Errorf("got %d stop_times, expected %d", count, exp)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestResumeWriter_FileGroup(t *testing.T) {
+	// Use a database file; the stage transactions must be real transactions
+	dir, err := ioutil.TempDir("", "dmfr-import")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	writer := mustGetWriter("sqlite3://"+filepath.Join(dir, "test.db"), true)
+	defer writer.Close()
+	adapter := writer.Adapter
+	fvid := testdb.ShouldInsert(t, adapter, &tl.FeedVersion{File: "test"})
+	fvi := FeedVersionImport{FeedVersionID: fvid, InProgress: true}
+	fvi.ID = testdb.ShouldInsert(t, adapter, &fvi)
+	writer.FeedVersionID = fvid
+	rw := &resumeWriter{Writer: writer, fvi: &fvi, adapter: adapter, completed: map[string]bool{}}
+	check := func(expectAgencies int, expectCheckpoint string) {
+		t.Helper()
+		count := 0
+		testdb.ShouldGet(t, adapter, &count, "SELECT count(*) FROM gtfs_agencies WHERE feed_version_id = ?", fvid)
+		if count != expectAgencies {
+			t.Errorf("got %d agencies, expected %d", count, expectAgencies)
+		}
+		checkpoint := ""
+		testdb.ShouldGet(t, adapter, &checkpoint, "SELECT checkpoint FROM feed_version_gtfs_imports WHERE id = ?", fvi.ID)
+		if checkpoint != expectCheckpoint || fvi.Checkpoint != expectCheckpoint {
+			t.Errorf("got checkpoint '%s', expected '%s'", checkpoint, expectCheckpoint)
+		}
+	}
+	copyAgency := func(copyErr error) func() error {
+		return func() error {
+			if _, err := rw.AddEntity(&tl.Agency{AgencyID: "test", AgencyName: "test"}); err != nil {
+				return err
+			}
+			return copyErr
+		}
+	}
+	// A failed stage is rolled back, including the checkpoint
+	stageErr := errors.New("stage error")
+	if err := rw.FileGroup(nil, []string{"agency.txt"}, copyAgency(stageErr)); err != stageErr {
+		t.Errorf("got error '%v', expected '%v'", err, stageErr)
+	}
+	check(0, "")
+	// A completed stage is committed with the checkpoint
+	if err := rw.FileGroup(nil, []string{"agency.txt"}, copyAgency(nil)); err != nil {
+		t.Fatal(err)
+	}
+	check(1, "agency.txt")
+	if writer.Adapter != adapter {
+		t.Errorf("expected writer adapter to be restored")
+	}
+}
+
+func TestMainImportFeedVersion_InProgress(t *testing.T) {
+	err := testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
+		fvid := testdb.ShouldInsert(t, atx, &tl.FeedVersion{File: testutil.ExampleDir.URL})
+		atx2 := testdb.AdapterIgnoreTx{Adapter: atx}
+		opts := ImportOptions{FeedVersionID: fvid, Resumable: true}
+		if _, err := MainImportFeedVersion(&atx2, opts); err != nil {
+			t.Fatal(err)
+		}
+		fvi := FeedVersionImport{}
+		testdb.ShouldGet(t, atx, &fvi, "SELECT * FROM feed_version_gtfs_imports WHERE feed_version_id = ?", fvid)
+		// Simulate a crash after stops; the import is left in progress
+		crash := func(updatedAt time.Time) {
+			q := atx.DBX().Rebind("UPDATE feed_version_gtfs_imports SET success = ?, in_progress = ?, checkpoint = ?, updated_at = ? WHERE id = ?")
+			if _, err := atx.DBX().Exec(q, false, true, "agency.txt,routes.txt,levels.txt,stops.txt", updatedAt, fvi.ID); err != nil {
+				t.Fatal(err)
+			}
+		}
+		check := func(expect bool) {
+			t.Helper()
+			check := FeedVersionImport{}
+			testdb.ShouldGet(t, atx, &check, "SELECT * FROM feed_version_gtfs_imports WHERE feed_version_id = ?", fvid)
+			if check.Success != expect {
+				t.Errorf("got success = %t, expected %t", check.Success, expect)
+			}
+		}
+		crash(time.Now().Add(-time.Minute))
+		// Not resumed while it may still be running
+		staleOpts := opts
+		staleOpts.StaleImport = time.Hour
+		for _, o := range []ImportOptions{opts, staleOpts} {
+			if _, err := MainImportFeedVersion(&atx2, o); err != nil {
+				t.Fatal(err)
+			}
+			check(false)
+		}
+		// Resumed when stale
+		crash(time.Now().Add(-2 * time.Hour))
+		if _, err := MainImportFeedVersion(&atx2, staleOpts); err != nil {
+			t.Fatal(err)
+		}
+		check(true)
+		// Resumed when requested
+		crash(time.Now())
+		forceOpts := opts
+		forceOpts.ResumeInProgress = true
+		if _, err := MainImportFeedVersion(&atx2, forceOpts); err != nil {
+			t.Fatal(err)
+		}
+		check(true)
+		count := 0
+		testdb.ShouldGet(t, atx, &count, "SELECT count(*) FROM gtfs_stop_times WHERE feed_version_id = ?", fvid)
+		if exp := testutil.ExampleDir.Counts["stop_times.txt"]; count != exp {
+			t.Errorf("got %d stop_times, expected %d", count, exp)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestMainImportFeedVersionContext(t *testing.T) {
+	err := testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
+		fvid := testdb.ShouldInsert(t, atx, &tl.FeedVersion{File: testutil.ExampleDir.URL})
+		atx2 := testdb.AdapterIgnoreTx{Adapter: atx}
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := MainImportFeedVersionContext(ctx, &atx2, ImportOptions{FeedVersionID: fvid})
+		if err != context.Canceled {
+			t.Errorf("got error '%v', expected '%v'", err, context.Canceled)
+		}
+		fvi := FeedVersionImport{}
+		testdb.ShouldGet(t, atx, &fvi, "SELECT * FROM feed_version_gtfs_imports WHERE feed_version_id = ?", fvid)
+		if fvi.Success != false || fvi.InProgress != false {
+			t.Errorf("expected success = false and in_progress = false")
+		}
+		count := 0
+		testdb.ShouldGet(t, atx, &count, "SELECT count(*) FROM gtfs_stops WHERE feed_version_id = ?", fvid)
+		if count != 0 {
+			t.Errorf("expected no stops, got %d", count)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
 func TestImportFeedVersion(t *testing.T) {
 	err := testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
 		// Create FV
@@ -0,0 +1,181 @@
+package dmfr
+
+import (
+	"database/sql"
+	"strconv"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/interline-io/transitland-lib/tl"
+	"github.com/interline-io/transitland-lib/tldb"
+)
+
+// Job types and statuses.
+const (
+	JobFetch     = "fetch"
+	JobImport    = "import"
+	JobPending   = "pending"
+	JobRunning   = "running"
+	JobSucceeded = "succeeded"
+	JobFailed    = "failed"
+)
+
+// Job is a fetch or import job in the job queue; see MainWorker.
+type Job struct {
+	ID            int
+	JobType       string
+	FeedID        tl.OptionalKey // fetch jobs
+	FeedVersionID tl.OptionalKey // import jobs
+	Status        string
+	Attempts      int
+	RunAt         time.Time // pending jobs are run after this time
+	LockedAt      tl.OptionalTime
+	LockedBy      string
+	LastError     string
+	tl.Timestamps
+}
+
+// EntityID .
+func (ent *Job) EntityID() string {
+	return strconv.Itoa(ent.ID)
+}
+
+// SetID .
+func (ent *Job) SetID(id int) {
+	ent.ID = id
+}
+
+// TableName .
+func (ent *Job) TableName() string {
+	return "dmfr_jobs"
+}
+
+// NewFetchJob returns a pending job to fetch a feed.
+func NewFetchJob(feedID int) Job {
+	return Job{JobType: JobFetch, FeedID: tl.OptionalKey{NullInt64: sql.NullInt64{Int64: int64(feedID), Valid: true}}}
+}
+
+// NewImportJob returns a pending job to import a feed version.
+func NewImportJob(fvid int) Job {
+	return Job{JobType: JobImport, FeedVersionID: tl.OptionalKey{NullInt64: sql.NullInt64{Int64: int64(fvid), Valid: true}}}
+}
+
+// EnqueueJob adds a pending job to the queue, to be run as soon as possible.
+// If a pending or running job of the same type already exists for the feed or feed version, the existing job ID is returned instead.
+// The unique index on pending and running jobs ensures that only one job is added when called concurrently.
+func EnqueueJob(atx tldb.Adapter, job Job) (int, bool, error) {
+	job.Status = JobPending
+	if job.RunAt.IsZero() {
+		job.RunAt = time.Now().UTC()
+	}
+	job.UpdateTimestamps()
+	res, err := atx.Sqrl().
+		Insert(job.TableName()).
+		Columns("job_type", "feed_id", "feed_version_id", "status", "attempts", "run_at", "locked_by", "last_error", "created_at", "updated_at").
+		Values(job.JobType, job.FeedID, job.FeedVersionID, job.Status, job.Attempts, job.RunAt, job.LockedBy, job.LastError, job.CreatedAt, job.UpdatedAt).
+		Suffix("ON CONFLICT DO NOTHING").
+		Exec()
+	if err != nil {
+		return 0, false, err
+	}
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return 0, false, err
+	}
+	// The added job, or the existing job
+	id := 0
+	qstr, args, err := atx.Sqrl().
+		Select("id").
+		From(job.TableName()).
+		Where(sq.Eq{
+			"job_type":        job.JobType,
+			"feed_id":         job.FeedID,
+			"feed_version_id": job.FeedVersionID,
+			"status":          []string{JobPending, JobRunning},
+		}).
+		ToSql()
+	if err != nil {
+		return 0, false, err
+	}
+	if err := atx.Get(&id, qstr, args...); err != nil {
+		return 0, false, err
+	}
+	return id, inserted > 0, nil
+}
+
+// ClaimJob locks and returns the next pending job that is due, for the worker.
+// Running jobs that have not been updated for lockTimeout, e.g. because the worker stopped, are claimed again.
+// Returns false if no job is due.
+// On Postgres, jobs locked by other transactions are skipped with FOR UPDATE SKIP LOCKED.
+// On SQLite, which allows only one writer, the job is only claimed if it has not changed since it was selected.
+func ClaimJob(adapter tldb.Adapter, workerID string, now time.Time, lockTimeout time.Duration) (Job, bool, error) {
+	job := Job{}
+	claimed := false
+	err := adapter.Tx(func(atx tldb.Adapter) error {
+		q := atx.Sqrl().
+			Select("*").
+			From(job.TableName()).
+			Where(sq.Or{
+				sq.And{sq.Eq{"status": JobPending}, sq.LtOrEq{"run_at": now}},
+				sq.And{sq.Eq{"status": JobRunning}, sq.LtOrEq{"locked_at": now.Add(-lockTimeout)}},
+			}).
+			OrderBy("run_at", "id").
+			Limit(1)
+		if atx.DBX().DriverName() == "postgres" {
+			q = q.Suffix("FOR UPDATE SKIP LOCKED")
+		}
+		qstr, args, err := q.ToSql()
+		if err != nil {
+			return err
+		}
+		if err := atx.Get(&job, qstr, args...); err == sql.ErrNoRows {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		res, err := atx.Sqrl().
+			Update(job.TableName()).
+			Set("status", JobRunning).
+			Set("attempts", job.Attempts+1).
+			Set("locked_at", now).
+			Set("locked_by", workerID).
+			Set("updated_at", now).
+			Where(sq.Eq{"id": job.ID, "status": job.Status, "attempts": job.Attempts}).
+			Exec()
+		if err != nil {
+			return err
+		}
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			// Claimed by another worker
+			return err
+		}
+		job.Status = JobRunning
+		job.Attempts++
+		job.LockedAt = tl.OptionalTime{Time: now, Valid: true}
+		job.LockedBy = workerID
+		claimed = true
+		return nil
+	})
+	return job, claimed, err
+}
+
+// updateJob saves the job status, if the job is still claimed by the worker.
+// Returns false if the job was claimed again, e.g. by another worker after the lock timeout.
+func updateJob(adapter tldb.Adapter, job Job, attempt int) (bool, error) {
+	job.UpdateTimestamps()
+	res, err := adapter.Sqrl().
+		Update(job.TableName()).
+		Set("status", job.Status).
+		Set("attempts", job.Attempts).
+		Set("run_at", job.RunAt).
+		Set("locked_at", job.LockedAt).
+		Set("last_error", job.LastError).
+		Set("updated_at", job.UpdatedAt).
+		Where(sq.Eq{"id": job.ID, "status": JobRunning, "locked_by": job.LockedBy, "attempts": attempt}).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
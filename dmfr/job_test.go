@@ -0,0 +1,108 @@
+package dmfr
+
+import (
+	"testing"
+	"time"
+
+	"github.com/interline-io/transitland-lib/internal/testdb"
+	"github.com/interline-io/transitland-lib/tl"
+	"github.com/interline-io/transitland-lib/tldb"
+)
+
+// setupJobs creates two feeds and a feed version of the first feed, for jobs that refer to them.
+func setupJobs(t *testing.T, atx tldb.Adapter) (int, int, int) {
+	f1 := caltrain(atx, "test1")
+	f2 := caltrain(atx, "test2")
+	fvid := testdb.ShouldInsert(t, atx, &tl.FeedVersion{FeedID: f1.ID})
+	return f1.ID, f2.ID, fvid
+}
+
+func TestEnqueueJob(t *testing.T) {
+	testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
+		feed1, feed2, fvid := setupJobs(t, atx)
+		id, ok, err := EnqueueJob(atx, NewFetchJob(feed1))
+		if err != nil || !ok {
+			t.Fatalf("expected job to be added: %v", err)
+		}
+		// Same feed is already in the queue
+		if id2, ok, err := EnqueueJob(atx, NewFetchJob(feed1)); err != nil {
+			t.Error(err)
+		} else if ok || id2 != id {
+			t.Errorf("got job %d added %t, expected existing job %d", id2, ok, id)
+		}
+		// Different feed, or different job type
+		for _, job := range []Job{NewFetchJob(feed2), NewImportJob(fvid)} {
+			if id2, ok, err := EnqueueJob(atx, job); err != nil {
+				t.Error(err)
+			} else if !ok || id2 == id {
+				t.Errorf("expected new %s job", job.JobType)
+			}
+		}
+		// Also enforced by the unique index
+		dup := NewFetchJob(feed1)
+		dup.Status = JobRunning
+		dup.RunAt = time.Now().UTC()
+		dup.UpdateTimestamps()
+		if _, err := atx.Insert(&dup); err == nil {
+			t.Error("expected unique index error")
+		}
+		// Finished jobs can be added again
+		if _, err := atx.Sqrl().Update("dmfr_jobs").Set("status", JobSucceeded).Where("id = ?", id).Exec(); err != nil {
+			t.Fatal(err)
+		}
+		if id2, ok, err := EnqueueJob(atx, NewFetchJob(feed1)); err != nil || !ok {
+			t.Errorf("expected job to be added: %v", err)
+		} else if id2 == id {
+			t.Errorf("got finished job %d, expected new job", id)
+		}
+		return nil
+	})
+}
+
+func TestClaimJob(t *testing.T) {
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
+		atx2 := &testdb.AdapterIgnoreTx{Adapter: atx}
+		feed1, feed2, fvid := setupJobs(t, atx)
+		later := NewFetchJob(feed1)
+		later.RunAt = now.Add(time.Hour)
+		laterID, _, _ := EnqueueJob(atx, later)
+		first := NewFetchJob(feed2)
+		first.RunAt = now.Add(-time.Hour)
+		firstID, _, _ := EnqueueJob(atx, first)
+		second := NewImportJob(fvid)
+		second.RunAt = now.Add(-time.Minute)
+		secondID, _, _ := EnqueueJob(atx, second)
+		// Due jobs, in order
+		for _, expect := range []int{firstID, secondID} {
+			job, ok, err := ClaimJob(atx2, "a", now, time.Hour)
+			if err != nil || !ok {
+				t.Fatalf("expected job: %v", err)
+			}
+			if job.ID != expect || job.Status != JobRunning || job.Attempts != 1 || job.LockedBy != "a" {
+				t.Errorf("got job %d status %s attempts %d locked by '%s', expected job %d", job.ID, job.Status, job.Attempts, job.LockedBy, expect)
+			}
+		}
+		if job, ok, err := ClaimJob(atx2, "b", now, time.Hour); err != nil || ok {
+			t.Errorf("got job %d, expected none: %v", job.ID, err)
+		}
+		// Running jobs are claimed again after the lock timeout
+		job, ok, err := ClaimJob(atx2, "b", now.Add(time.Hour), time.Hour)
+		if err != nil || !ok {
+			t.Fatalf("expected job: %v", err)
+		}
+		if job.ID != firstID || job.Attempts != 2 || job.LockedBy != "b" {
+			t.Errorf("got job %d attempts %d locked by '%s', expected job %d", job.ID, job.Attempts, job.LockedBy, firstID)
+		}
+		// The previous worker can no longer update the job
+		stale := job
+		stale.LockedBy = "a"
+		if ok, err := updateJob(atx2, stale, 1); err != nil || ok {
+			t.Errorf("expected update to be ignored: %v", err)
+		}
+		if job, ok, err := ClaimJob(atx2, "b", now.Add(time.Hour), 2*time.Hour); err != nil || !ok || job.ID != laterID {
+			t.Errorf("got job %d, expected job %d: %v", job.ID, laterID, err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,95 @@
+package dmfr
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/interline-io/transitland-lib/internal/log"
+	"github.com/interline-io/transitland-lib/tldb"
+)
+
+// MigrateCommand applies or reverts schema migrations.
+type MigrateCommand struct {
+	DBURL     string
+	Direction string // up, down, or status
+	Version   int    // up: last version to apply, or 0 for all
+	Steps     int    // down: number of migrations to revert
+	adapter   tldb.Adapter
+}
+
+// Parse command line options.
+func (cmd *MigrateCommand) Parse(args []string) error {
+	fl := flag.NewFlagSet("migrate", flag.ExitOnError)
+	fl.Usage = func() {
+		log.Print("Usage: migrate up [version] | down [steps] | status")
+		fl.PrintDefaults()
+	}
+	fl.StringVar(&cmd.DBURL, "dburl", "", "Database URL (default: $DMFR_DATABASE_URL)")
+	fl.Parse(args)
+	if cmd.DBURL == "" {
+		cmd.DBURL = os.Getenv("DMFR_DATABASE_URL")
+	}
+	if fl.NArg() == 0 || fl.NArg() > 2 {
+		fl.Usage()
+		return errors.New("requires up, down, or status")
+	}
+	cmd.Direction = fl.Arg(0)
+	n := 0
+	if fl.NArg() == 2 {
+		var err error
+		if n, err = strconv.Atoi(fl.Arg(1)); err != nil || n < 1 {
+			return fmt.Errorf("invalid argument: %s", fl.Arg(1))
+		}
+	}
+	switch cmd.Direction {
+	case "up":
+		cmd.Version = n
+	case "down":
+		cmd.Steps = 1
+		if n > 0 {
+			cmd.Steps = n
+		}
+	case "status":
+		if n > 0 {
+			return errors.New("status does not take an argument")
+		}
+	default:
+		return fmt.Errorf("invalid migrate command: %q", cmd.Direction)
+	}
+	return nil
+}
+
+// Run this command.
+func (cmd *MigrateCommand) Run() error {
+	if cmd.adapter == nil {
+		writer := mustGetWriter(cmd.DBURL, false)
+		cmd.adapter = writer.Adapter
+		defer writer.Close()
+	}
+	var migrations []tldb.Migration
+	var err error
+	switch cmd.Direction {
+	case "up":
+		migrations, err = tldb.MigrateUp(cmd.adapter, cmd.Version)
+		log.Print("Applied %d migrations", len(migrations))
+	case "down":
+		migrations, err = tldb.MigrateDown(cmd.adapter, cmd.Steps)
+		log.Print("Reverted %d migrations", len(migrations))
+	case "status":
+		statuses, err := tldb.MigrationStatuses(cmd.adapter)
+		if err != nil {
+			return err
+		}
+		for _, s := range statuses {
+			applied := "pending"
+			if s.Applied {
+				applied = "applied " + s.AppliedAt.Format("2006-01-02T15:04:05Z")
+			}
+			log.Print("%04d %-24s %s", s.Version, s.Name, applied)
+		}
+	}
+	return err
+}
@@ -0,0 +1,127 @@
+package dmfr
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/interline-io/transitland-lib/internal/log"
+	"github.com/interline-io/transitland-lib/tl"
+	"github.com/interline-io/transitland-lib/tldb"
+)
+
+// UnimportOptions sets options for removing a feed version.
+type UnimportOptions struct {
+	FeedVersionID int
+	Extensions    []string
+	Force         bool // allow removing the active feed version
+}
+
+// DeleteOptions sets options for deleting a feed version.
+type DeleteOptions struct {
+	UnimportOptions
+	DeleteFile bool // also delete the stored GTFS file
+	Directory  string
+	S3         string
+}
+
+// MainUnimportFeedVersion deletes the imported entities and the FVI of a feed version inside a Tx, so it can be imported again.
+func MainUnimportFeedVersion(adapter tldb.Adapter, opts UnimportOptions) error {
+	return adapter.Tx(func(atx tldb.Adapter) error {
+		fv := tl.FeedVersion{ID: opts.FeedVersionID}
+		if err := atx.Find(&fv); err != nil {
+			return err
+		}
+		return unimportFeedVersion(atx, fv.ID, opts)
+	})
+}
+
+// MainDeleteFeedVersion deletes a feed version and all its records inside a Tx.
+// If opts.DeleteFile is set, the stored GTFS file is deleted after the Tx is committed.
+func MainDeleteFeedVersion(ctx context.Context, adapter tldb.Adapter, opts DeleteOptions) error {
+	fv := tl.FeedVersion{ID: opts.FeedVersionID}
+	err := adapter.Tx(func(atx tldb.Adapter) error {
+		if err := atx.Find(&fv); err != nil {
+			return err
+		}
+		if err := unimportFeedVersion(atx, fv.ID, opts.UnimportOptions); err != nil {
+			return err
+		}
+		for _, table := range []string{FeedVersionFileInfo{}.TableName(), FeedVersionServiceLevel{}.TableName(), (&Job{}).TableName()} {
+			if _, err := atx.Sqrl().Delete(table).Where(sq.Eq{"feed_version_id": fv.ID}).Exec(); err != nil {
+				return err
+			}
+		}
+		_, err := atx.Sqrl().Delete("feed_versions").Where(sq.Eq{"id": fv.ID}).Exec()
+		return err
+	})
+	if err != nil || !opts.DeleteFile {
+		return err
+	}
+	return deleteStoredFile(ctx, fv, opts.Directory, opts.S3)
+}
+
+// activeFeedVersionError is returned when removing the active feed version without opts.Force.
+type activeFeedVersionError struct {
+	FeedVersionID int
+}
+
+func (e activeFeedVersionError) Error() string {
+	return fmt.Sprintf("feed version %d is active; use force to remove it", e.FeedVersionID)
+}
+
+// unimportFeedVersion deletes the imported entities and the FVI.
+// The active feed version is only removed if opts.Force is set, and is then no longer active.
+func unimportFeedVersion(atx tldb.Adapter, fvid int, opts UnimportOptions) error {
+	active := 0
+	if err := atx.Get(&active, "SELECT count(*) FROM feed_states WHERE feed_version_id = ?", fvid); err != nil {
+		return err
+	}
+	if active > 0 && !opts.Force {
+		return activeFeedVersionError{FeedVersionID: fvid}
+	}
+	inProgress := 0
+	if err := atx.Get(&inProgress, "SELECT count(*) FROM feed_version_gtfs_imports WHERE feed_version_id = ? AND in_progress = ?", fvid, true); err != nil {
+		return err
+	}
+	if inProgress > 0 {
+		return fmt.Errorf("feed version %d is being imported", fvid)
+	}
+	if active > 0 {
+		if _, err := atx.Sqrl().Update("feed_states").Set("feed_version_id", nil).Where(sq.Eq{"feed_version_id": fvid}).Exec(); err != nil {
+			return err
+		}
+	}
+	// Same as deleting the entities of every stage of an incomplete import
+	if err := deleteIncompleteStages(atx, fvid, map[string]bool{}, opts.Extensions); err != nil {
+		return err
+	}
+	_, err := atx.Sqrl().Delete(FeedVersionImport{}.TableName()).Where(sq.Eq{"feed_version_id": fvid}).Exec()
+	return err
+}
+
+// deleteStoredFile deletes the GTFS file saved by fetch in a directory or S3.
+func deleteStoredFile(ctx context.Context, fv tl.FeedVersion, directory string, s3 string) error {
+	fn := fv.SHA1 + ".zip"
+	if s3 != "" {
+		awscmd := exec.CommandContext(ctx, "aws", "s3", "rm", fmt.Sprintf("%s/%s", s3, fn))
+		if output, err := awscmd.Output(); err != nil {
+			return fmt.Errorf("delete error: %s: %s", err, output)
+		}
+		log.Info("Deleted %s/%s", s3, fn)
+	}
+	if directory != "" {
+		path := filepath.Join(directory, fn)
+		if err := os.Remove(path); os.IsNotExist(err) {
+			log.Info("File %s does not exist", path)
+		} else if err != nil {
+			return err
+		} else {
+			log.Info("Deleted %s", path)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package dmfr
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/interline-io/transitland-lib/internal/log"
+	"github.com/interline-io/transitland-lib/tldb"
+)
+
+// UnimportCommand deletes the imported entities of feed versions.
+type UnimportCommand struct {
+	DBURL           string
+	FVIDs           []int
+	UnimportOptions UnimportOptions
+	adapter         tldb.Adapter
+}
+
+// Parse command line options.
+func (cmd *UnimportCommand) Parse(args []string) error {
+	extflags := arrayFlags{}
+	fvids := arrayFlags{}
+	fl := flag.NewFlagSet("unimport", flag.ExitOnError)
+	fl.Usage = func() {
+		log.Print("Usage: unimport -fvid N [-fvid N...]")
+		fl.PrintDefaults()
+	}
+	fl.StringVar(&cmd.DBURL, "dburl", "", "Database URL (default: $DMFR_DATABASE_URL)")
+	fl.Var(&fvids, "fvid", "Feed version ID; may be specified multiple times")
+	fl.Var(&extflags, "ext", "Also delete entities written by this GTFS Extension")
+	fl.BoolVar(&cmd.UnimportOptions.Force, "force", false, "Allow removing the active feed version; the feed will have no active feed version")
+	fl.Parse(args)
+	if cmd.DBURL == "" {
+		cmd.DBURL = os.Getenv("DMFR_DATABASE_URL")
+	}
+	cmd.UnimportOptions.Extensions = extflags
+	var err error
+	cmd.FVIDs, err = parseFVIDs(fvids)
+	if err != nil {
+		return err
+	}
+	if len(cmd.FVIDs) == 0 {
+		fl.Usage()
+		return errors.New("requires -fvid")
+	}
+	return nil
+}
+
+// Run this command.
+func (cmd *UnimportCommand) Run() error {
+	if cmd.adapter == nil {
+		writer := mustGetWriter(cmd.DBURL, false)
+		cmd.adapter = writer.Adapter
+		defer writer.Close()
+	}
+	for _, fvid := range cmd.FVIDs {
+		opts := cmd.UnimportOptions
+		opts.FeedVersionID = fvid
+		if err := MainUnimportFeedVersion(cmd.adapter, opts); err != nil {
+			return fmt.Errorf("could not unimport feed version %d: %s", fvid, err.Error())
+		}
+		log.Print("Unimported feed version %d", fvid)
+	}
+	return nil
+}
+
+// DeleteCommand deletes feed versions.
+type DeleteCommand struct {
+	DBURL         string
+	FVIDs         []int
+	DeleteOptions DeleteOptions
+	adapter       tldb.Adapter
+}
+
+// Parse command line options.
+func (cmd *DeleteCommand) Parse(args []string) error {
+	extflags := arrayFlags{}
+	fvids := arrayFlags{}
+	fl := flag.NewFlagSet("delete", flag.ExitOnError)
+	fl.Usage = func() {
+		log.Print("Usage: delete -fvid N [-fvid N...]")
+		fl.PrintDefaults()
+	}
+	fl.StringVar(&cmd.DBURL, "dburl", "", "Database URL (default: $DMFR_DATABASE_URL)")
+	fl.Var(&fvids, "fvid", "Feed version ID; may be specified multiple times")
+	fl.Var(&extflags, "ext", "Also delete entities written by this GTFS Extension")
+	fl.BoolVar(&cmd.DeleteOptions.Force, "force", false, "Allow deleting the active feed version; the feed will have no active feed version")
+	fl.BoolVar(&cmd.DeleteOptions.DeleteFile, "delete-file", false, "Also delete the GTFS file saved by fetch in -gtfsdir or -s3")
+	fl.StringVar(&cmd.DeleteOptions.Directory, "gtfsdir", ".", "GTFS Directory")
+	fl.StringVar(&cmd.DeleteOptions.S3, "s3", "", "GTFS files in S3 bucket/prefix")
+	fl.Parse(args)
+	if cmd.DBURL == "" {
+		cmd.DBURL = os.Getenv("DMFR_DATABASE_URL")
+	}
+	cmd.DeleteOptions.Extensions = extflags
+	var err error
+	cmd.FVIDs, err = parseFVIDs(fvids)
+	if err != nil {
+		return err
+	}
+	if len(cmd.FVIDs) == 0 {
+		fl.Usage()
+		return errors.New("requires -fvid")
+	}
+	return nil
+}
+
+// Run this command.
+func (cmd *DeleteCommand) Run() error {
+	if cmd.adapter == nil {
+		writer := mustGetWriter(cmd.DBURL, false)
+		cmd.adapter = writer.Adapter
+		defer writer.Close()
+	}
+	ctx, cancel := signalContext()
+	defer cancel()
+	for _, fvid := range cmd.FVIDs {
+		opts := cmd.DeleteOptions
+		opts.FeedVersionID = fvid
+		if err := MainDeleteFeedVersion(ctx, cmd.adapter, opts); err != nil {
+			return fmt.Errorf("could not delete feed version %d: %s", fvid, err.Error())
+		}
+		log.Print("Deleted feed version %d", fvid)
+	}
+	return nil
+}
+
+func parseFVIDs(values []string) ([]int, error) {
+	fvids := []int{}
+	for _, v := range values {
+		fvid, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid feed version ID: %s", v)
+		}
+		fvids = append(fvids, fvid)
+	}
+	return fvids, nil
+}
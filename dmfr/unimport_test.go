@@ -0,0 +1,143 @@
+package dmfr
+
+import (
+	"context"
+	"database/sql"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/interline-io/transitland-lib/internal/testdb"
+	"github.com/interline-io/transitland-lib/internal/testutil"
+	"github.com/interline-io/transitland-lib/tl"
+	"github.com/interline-io/transitland-lib/tldb"
+)
+
+var unimportTables = []string{
+	"gtfs_agencies",
+	"gtfs_routes",
+	"gtfs_stops",
+	"gtfs_trips",
+	"gtfs_stop_times",
+	"gtfs_shapes",
+	"gtfs_calendars",
+	"gtfs_calendar_dates",
+	"gtfs_frequencies",
+	"gtfs_fare_attributes",
+	"gtfs_fare_rules",
+	"gtfs_feed_infos",
+	"feed_version_gtfs_imports",
+}
+
+func setupUnimport(t *testing.T, atx tldb.Adapter, activate bool) (Feed, int) {
+	f := caltrain(atx, "test")
+	fv := tl.FeedVersion{FeedID: f.ID}
+	fv.File = testutil.ExampleDir.URL
+	fv.SHA1 = "test"
+	fvid := testdb.ShouldInsert(t, atx, &fv)
+	atx2 := testdb.AdapterIgnoreTx{Adapter: atx}
+	if _, err := MainImportFeedVersion(&atx2, ImportOptions{FeedVersionID: fvid}); err != nil {
+		t.Fatal(err)
+	}
+	if activate {
+		testdb.ShouldInsert(t, atx, &FeedState{FeedID: f.ID, FeedVersionID: tl.OptionalKey{NullInt64: sql.NullInt64{Int64: int64(fvid), Valid: true}}})
+	}
+	return f, fvid
+}
+
+func countRows(t *testing.T, atx tldb.Adapter, table string, fvid int) int {
+	count := 0
+	testdb.ShouldGet(t, atx, &count, "SELECT count(*) FROM "+table+" WHERE feed_version_id = ?", fvid)
+	return count
+}
+
+func TestMainUnimportFeedVersion(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
+			_, fvid := setupUnimport(t, atx, false)
+			if countRows(t, atx, "gtfs_stops", fvid) == 0 {
+				t.Fatal("expected stops")
+			}
+			if err := MainUnimportFeedVersion(&testdb.AdapterIgnoreTx{Adapter: atx}, UnimportOptions{FeedVersionID: fvid}); err != nil {
+				t.Fatal(err)
+			}
+			for _, table := range unimportTables {
+				if count := countRows(t, atx, table, fvid); count != 0 {
+					t.Errorf("%s: got %d rows, expected 0", table, count)
+				}
+			}
+			// Feed version is kept
+			fv := tl.FeedVersion{ID: fvid}
+			if err := atx.Find(&fv); err != nil {
+				t.Error(err)
+			}
+			// Can be imported again
+			atx2 := testdb.AdapterIgnoreTx{Adapter: atx}
+			if _, err := MainImportFeedVersion(&atx2, ImportOptions{FeedVersionID: fvid}); err != nil {
+				t.Fatal(err)
+			}
+			if count, exp := countRows(t, atx, "gtfs_stops", fvid), testutil.ExampleDir.Counts["stops.txt"]; count != exp {
+				t.Errorf("got %d stops after import, expected %d", count, exp)
+			}
+			return nil
+		})
+	})
+	t.Run("Active", func(t *testing.T) {
+		testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
+			f, fvid := setupUnimport(t, atx, true)
+			if err := MainUnimportFeedVersion(&testdb.AdapterIgnoreTx{Adapter: atx}, UnimportOptions{FeedVersionID: fvid}); err == nil {
+				t.Error("expected error for active feed version")
+			}
+			if countRows(t, atx, "gtfs_stops", fvid) == 0 {
+				t.Error("expected stops to be kept")
+			}
+			if err := MainUnimportFeedVersion(&testdb.AdapterIgnoreTx{Adapter: atx}, UnimportOptions{FeedVersionID: fvid, Force: true}); err != nil {
+				t.Fatal(err)
+			}
+			if count := countRows(t, atx, "gtfs_stops", fvid); count != 0 {
+				t.Errorf("got %d stops, expected 0", count)
+			}
+			fs := FeedState{}
+			testdb.ShouldGet(t, atx, &fs, "SELECT * FROM feed_states WHERE feed_id = ?", f.ID)
+			if fs.FeedVersionID.Valid {
+				t.Errorf("expected no active feed version, got %d", fs.FeedVersionID.Int64)
+			}
+			return nil
+		})
+	})
+}
+
+func TestMainDeleteFeedVersion(t *testing.T) {
+	testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
+		_, fvid := setupUnimport(t, atx, false)
+		tmpdir, err := ioutil.TempDir("", "gtfs")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(tmpdir)
+		fn := filepath.Join(tmpdir, "test.zip")
+		if err := ioutil.WriteFile(fn, []byte("test"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		opts := DeleteOptions{DeleteFile: true, Directory: tmpdir}
+		opts.FeedVersionID = fvid
+		if err := MainDeleteFeedVersion(context.Background(), &testdb.AdapterIgnoreTx{Adapter: atx}, opts); err != nil {
+			t.Fatal(err)
+		}
+		for _, table := range append(unimportTables, "feed_version_file_infos", "feed_version_service_levels") {
+			if count := countRows(t, atx, table, fvid); count != 0 {
+				t.Errorf("%s: got %d rows, expected 0", table, count)
+			}
+		}
+		count := 0
+		testdb.ShouldGet(t, atx, &count, "SELECT count(*) FROM feed_versions WHERE id = ?", fvid)
+		if count != 0 {
+			t.Error("expected feed version to be deleted")
+		}
+		if _, err := os.Stat(fn); !os.IsNotExist(err) {
+			t.Error("expected file to be deleted")
+		}
+		return nil
+	})
+}
@@ -0,0 +1,224 @@
+package dmfr
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/interline-io/transitland-lib/internal/log"
+	"github.com/interline-io/transitland-lib/tl"
+	"github.com/interline-io/transitland-lib/tldb"
+)
+
+// WorkerOptions sets options for running jobs from the job queue.
+type WorkerOptions struct {
+	FetchOptions  FetchOptions  // options for fetch jobs; FeedID and FeedURL are ignored
+	ImportOptions ImportOptions // options for import jobs; FeedVersionID is ignored
+	WorkerID      string        // identifies the worker in locked jobs; default hostname and process ID
+	Workers       int           // number of jobs run at the same time
+	Poll          time.Duration // how often to check for jobs when the queue is empty
+	MaxAttempts   int           // failed jobs are retried until they have been attempted this many times
+	Backoff       time.Duration // delay before the first retry; doubled for each further attempt
+	MaxBackoff    time.Duration // maximum delay before a retry
+	LockTimeout   time.Duration // running jobs that are not updated for this long are claimed again
+	ImportNew     bool          // enqueue an import job for each new feed version fetched by a fetch job
+	Drain         bool          // return when no jobs are due, instead of polling
+}
+
+// JobResult is the result of running a job.
+type JobResult struct {
+	Job          Job // the job with its updated status
+	FetchResult  FetchResult
+	ImportResult ImportResult
+	Error        error // the job failed with this error
+}
+
+// retryDelay returns the delay before the next attempt of a job that has been attempted the given number of times.
+func (opts WorkerOptions) retryDelay(attempts int) time.Duration {
+	delay := opts.Backoff
+	for i := 1; i < attempts; i++ {
+		delay = delay * 2
+		if opts.MaxBackoff > 0 && delay >= opts.MaxBackoff {
+			return opts.MaxBackoff
+		}
+	}
+	return delay
+}
+
+func (opts *WorkerOptions) setDefaults() {
+	if opts.WorkerID == "" {
+		host, _ := os.Hostname()
+		opts.WorkerID = fmt.Sprintf("%s:%d", host, os.Getpid())
+	}
+	if opts.Workers < 1 {
+		opts.Workers = 1
+	}
+	if opts.Poll <= 0 {
+		opts.Poll = 10 * time.Second
+	}
+	if opts.MaxAttempts < 1 {
+		opts.MaxAttempts = 1
+	}
+	if opts.LockTimeout <= 0 {
+		opts.LockTimeout = time.Hour
+	}
+}
+
+// MainWorker claims and runs jobs from the job queue until stop is done; see ClaimJob and RunJob.
+// After stop is done, no new jobs are claimed and MainWorker returns when the running jobs are finished.
+// Cancelling ctx also cancels the running jobs, which are returned to the queue without counting the attempt.
+// Each result is passed to handler, if not nil.
+func MainWorker(ctx context.Context, stop context.Context, adapter tldb.Adapter, opts WorkerOptions, handler func(JobResult)) error {
+	opts.setDefaults()
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	for w := 0; w < opts.Workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			workerID := fmt.Sprintf("%s:%d", opts.WorkerID, w)
+			for stop.Err() == nil {
+				job, ok, err := ClaimJob(adapter, workerID, time.Now().UTC(), opts.LockTimeout)
+				if err != nil {
+					log.Error("Could not claim job: %s", err.Error())
+				}
+				if !ok {
+					if opts.Drain && err == nil {
+						return
+					}
+					select {
+					case <-stop.Done():
+					case <-time.After(opts.Poll):
+					}
+					continue
+				}
+				r := RunJob(ctx, adapter, opts, job)
+				if handler != nil {
+					mu.Lock()
+					handler(r)
+					mu.Unlock()
+				}
+			}
+		}(w)
+	}
+	wg.Wait()
+	return nil
+}
+
+// RunJob runs a claimed job and saves the result.
+// A failed job is returned to the queue with a delay, until it has been attempted opts.MaxAttempts times.
+// Failed import jobs are only retried if opts.ImportOptions.Resumable is set, since otherwise the failed import is not run again.
+// While the job is running, it is updated every quarter of opts.LockTimeout, so that it is not claimed by another worker.
+func RunJob(ctx context.Context, adapter tldb.Adapter, opts WorkerOptions, job Job) JobResult {
+	opts.setDefaults()
+	r := JobResult{Job: job}
+	attempt := job.Attempts
+	// Keep the job locked while running
+	done := make(chan struct{})
+	var hb sync.WaitGroup
+	hb.Add(1)
+	go func(job Job) {
+		defer hb.Done()
+		ticker := time.NewTicker(opts.LockTimeout / 4)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				job.LockedAt = tl.OptionalTime{Time: time.Now().UTC(), Valid: true}
+				if ok, err := updateJob(adapter, job, attempt); err != nil {
+					log.Error("Job %d: could not update lock: %s", job.ID, err.Error())
+				} else if !ok {
+					log.Error("Job %d: claimed by another worker", job.ID)
+				}
+			}
+		}
+	}(job)
+	retry := true
+	switch job.JobType {
+	case JobFetch:
+		r.FetchResult, r.Error = runFetchJob(ctx, adapter, opts, job)
+	case JobImport:
+		r.ImportResult, r.Error = runImportJob(ctx, adapter, opts, job)
+		retry = opts.ImportOptions.Resumable
+	default:
+		r.Error = fmt.Errorf("unknown job type '%s'", job.JobType)
+		retry = false
+	}
+	close(done)
+	hb.Wait()
+	// Save result
+	now := time.Now().UTC()
+	job.LockedAt = tl.OptionalTime{}
+	if r.Error == nil {
+		job.Status = JobSucceeded
+		job.LastError = ""
+	} else if ctx.Err() != nil {
+		// Cancelled; return to the queue
+		job.Status = JobPending
+		job.Attempts--
+		job.RunAt = now
+		job.LastError = r.Error.Error()
+	} else if retry && job.Attempts < opts.MaxAttempts {
+		job.Status = JobPending
+		job.RunAt = now.Add(opts.retryDelay(job.Attempts))
+		job.LastError = r.Error.Error()
+	} else {
+		job.Status = JobFailed
+		job.LastError = r.Error.Error()
+	}
+	if ok, err := updateJob(adapter, job, attempt); err != nil {
+		log.Error("Job %d: could not save status: %s", job.ID, err.Error())
+	} else if !ok {
+		log.Error("Job %d: claimed by another worker; status not saved", job.ID)
+	}
+	r.Job = job
+	return r
+}
+
+// runFetchJob fetches the feed; fetch errors, such as a 404, are job errors.
+func runFetchJob(ctx context.Context, adapter tldb.Adapter, opts WorkerOptions, job Job) (FetchResult, error) {
+	fr := FetchResult{}
+	if !job.FeedID.Valid {
+		return fr, errors.New("no feed")
+	}
+	feed := Feed{ID: int(job.FeedID.Int64)}
+	if err := adapter.Find(&feed); err != nil {
+		return fr, err
+	}
+	fetchOpts := opts.FetchOptions
+	fetchOpts.FeedID = feed.FeedID
+	fetchOpts.FeedURL = ""
+	fetchOpts.FetchedAt = time.Time{}
+	err := adapter.Tx(func(atx tldb.Adapter) error {
+		var err error
+		fr, err = DatabaseFetchContext(ctx, atx, fetchOpts)
+		if err != nil || fr.FetchError != nil || fr.FoundSHA1 || fr.FoundDirSHA1 || !opts.ImportNew {
+			return err
+		}
+		_, _, err = EnqueueJob(atx, NewImportJob(fr.FeedVersion.ID))
+		return err
+	})
+	if err != nil {
+		return fr, err
+	}
+	return fr, fr.FetchError
+}
+
+// runImportJob imports the feed version; an unsuccessful import is a job error.
+func runImportJob(ctx context.Context, adapter tldb.Adapter, opts WorkerOptions, job Job) (ImportResult, error) {
+	if !job.FeedVersionID.Valid {
+		return ImportResult{}, errors.New("no feed version")
+	}
+	importOpts := opts.ImportOptions
+	importOpts.FeedVersionID = int(job.FeedVersionID.Int64)
+	result, err := MainImportFeedVersionContext(ctx, adapter, importOpts)
+	if err == nil && !result.FeedVersionImport.Success {
+		err = errors.New(result.FeedVersionImport.ExceptionLog)
+	}
+	return result, err
+}
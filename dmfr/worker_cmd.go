@@ -0,0 +1,122 @@
+package dmfr
+
+import (
+	"flag"
+	"os"
+	"time"
+
+	"github.com/interline-io/transitland-lib/copier"
+	"github.com/interline-io/transitland-lib/internal/log"
+	"github.com/interline-io/transitland-lib/tldb"
+)
+
+// WorkerCommand runs fetch and import jobs from the job queue.
+type WorkerCommand struct {
+	DBURL         string
+	WorkerOptions WorkerOptions
+	adapter       tldb.Adapter
+}
+
+// Parse command line options.
+func (cmd *WorkerCommand) Parse(args []string) error {
+	extflags := arrayFlags{}
+	secretsFile := ""
+	rulesfile := ""
+	fl := flag.NewFlagSet("worker", flag.ExitOnError)
+	fl.Usage = func() {
+		log.Print("Usage: worker")
+		fl.PrintDefaults()
+	}
+	fl.StringVar(&cmd.DBURL, "dburl", "", "Database URL (default: $DMFR_DATABASE_URL)")
+	fl.StringVar(&cmd.WorkerOptions.FetchOptions.Directory, "gtfsdir", ".", "GTFS Directory")
+	fl.StringVar(&cmd.WorkerOptions.FetchOptions.S3, "s3", "", "Upload and get GTFS files from S3 bucket/prefix")
+	fl.StringVar(&secretsFile, "secrets", "", "Path to DMFR Secrets file")
+	fl.BoolVar(&cmd.WorkerOptions.FetchOptions.IgnoreDuplicateContents, "ignore-duplicate-contents", false, "Allow duplicate internal SHA1 contents")
+	fl.StringVar(&cmd.WorkerOptions.WorkerID, "worker-id", "", "Worker name saved in running jobs (default: hostname and process ID)")
+	fl.IntVar(&cmd.WorkerOptions.Workers, "workers", 1, "Number of jobs run at the same time")
+	fl.DurationVar(&cmd.WorkerOptions.Poll, "poll", 10*time.Second, "How often to check for jobs when the queue is empty")
+	fl.IntVar(&cmd.WorkerOptions.MaxAttempts, "max-attempts", 3, "Maximum attempts for each job")
+	fl.DurationVar(&cmd.WorkerOptions.Backoff, "backoff", time.Minute, "Delay before retrying a failed job; doubled after each attempt")
+	fl.DurationVar(&cmd.WorkerOptions.MaxBackoff, "max-backoff", time.Hour, "Maximum delay before retrying a failed job")
+	fl.DurationVar(&cmd.WorkerOptions.LockTimeout, "lock-timeout", time.Hour, "Run jobs again if their worker has not updated them for this long")
+	fl.BoolVar(&cmd.WorkerOptions.ImportNew, "import", false, "Enqueue an import job for each new feed version fetched")
+	fl.BoolVar(&cmd.WorkerOptions.Drain, "drain", false, "Exit when no jobs are due")
+	fl.Var(&extflags, "ext", "Include GTFS Extension")
+	fl.BoolVar(&cmd.WorkerOptions.ImportOptions.Activate, "activate", false, "Set as active feed version after import")
+	fl.BoolVar(&cmd.WorkerOptions.ImportOptions.InterpolateStopTimes, "interpolate-stop-times", false, "Interpolate missing StopTime arrival/departure values")
+	fl.BoolVar(&cmd.WorkerOptions.ImportOptions.CreateMissingShapes, "create-missing-shapes", false, "Create missing Shapes from Trip stop-to-stop geometries")
+	fl.StringVar(&rulesfile, "rules", "", "JSON file with rules to change the severity of errors and warnings")
+	fl.BoolVar(&cmd.WorkerOptions.ImportOptions.Resumable, "resumable", false, "Commit each file separately, and resume failed imports from the last completed file")
+	fl.DurationVar(&cmd.WorkerOptions.ImportOptions.StaleImport, "stale-import", 0, "With -resumable, also resume imports that are marked in progress but have not been updated for this long")
+	fl.IntVar(&cmd.WorkerOptions.ImportOptions.TripWorkers, "trip-workers", 1, "Number of workers used to validate and interpolate trips and stop_times in each feed version")
+	fl.Parse(args)
+	if cmd.DBURL == "" {
+		cmd.DBURL = os.Getenv("DMFR_DATABASE_URL")
+	}
+	cmd.WorkerOptions.ImportOptions.Directory = cmd.WorkerOptions.FetchOptions.Directory
+	cmd.WorkerOptions.ImportOptions.S3 = cmd.WorkerOptions.FetchOptions.S3
+	cmd.WorkerOptions.ImportOptions.Extensions = extflags
+	if secretsFile != "" {
+		if err := cmd.WorkerOptions.FetchOptions.Secrets.Load(secretsFile); err != nil {
+			return err
+		}
+	}
+	if rulesfile != "" {
+		rules, err := copier.LoadRuleSet(rulesfile)
+		if err != nil {
+			return err
+		}
+		cmd.WorkerOptions.ImportOptions.Rules = rules
+	}
+	return nil
+}
+
+// Run this command.
+func (cmd *WorkerCommand) Run() error {
+	if cmd.adapter == nil {
+		writer := mustGetWriter(cmd.DBURL, true)
+		cmd.adapter = writer.Adapter
+		defer writer.Close()
+	}
+	ctx, stop, cancel := signalStopContext()
+	defer cancel()
+	log.Info("Starting worker: workers %d, poll %s", cmd.WorkerOptions.Workers, cmd.WorkerOptions.Poll)
+	return MainWorker(ctx, stop, cmd.adapter, cmd.WorkerOptions, logJobResult)
+}
+
+func logJobResult(r JobResult) {
+	job := r.Job
+	target := job.FeedID.Int64
+	if job.JobType == JobImport {
+		target = job.FeedVersionID.Int64
+	}
+	if r.Error == nil {
+		log.Info("Job %d: %s %d: attempt %d: succeeded", job.ID, job.JobType, target, job.Attempts)
+	} else if job.Status == JobPending {
+		log.Error("Job %d: %s %d: attempt %d: error: %s; retry at %s", job.ID, job.JobType, target, job.Attempts, r.Error.Error(), job.RunAt.Format(time.RFC3339))
+	} else {
+		log.Error("Job %d: %s %d: attempt %d: failed: %s", job.ID, job.JobType, target, job.Attempts, r.Error.Error())
+	}
+}
+
+// enqueueJobs adds the jobs to the job queue inside a Tx.
+func enqueueJobs(adapter tldb.Adapter, jobs []Job) error {
+	added := 0
+	err := adapter.Tx(func(atx tldb.Adapter) error {
+		for _, job := range jobs {
+			_, ok, err := EnqueueJob(atx, job)
+			if err != nil {
+				return err
+			}
+			if ok {
+				added++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	log.Info("Enqueued %d jobs; %d already in the queue", added, len(jobs)-added)
+	return nil
+}
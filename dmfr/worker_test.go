@@ -0,0 +1,157 @@
+package dmfr
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/interline-io/transitland-lib/internal/testdb"
+	"github.com/interline-io/transitland-lib/tldb"
+)
+
+func TestWorkerOptions_retryDelay(t *testing.T) {
+	opts := WorkerOptions{Backoff: time.Minute, MaxBackoff: 5 * time.Minute}
+	expect := []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 5 * time.Minute, 5 * time.Minute}
+	for i, exp := range expect {
+		if got := opts.retryDelay(i + 1); got != exp {
+			t.Errorf("attempt %d: got %s, expected %s", i+1, got, exp)
+		}
+	}
+}
+
+func TestRunJob(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/404" {
+			http.NotFound(w, r)
+			return
+		}
+		buf, err := ioutil.ReadFile(ExampleZip.URL)
+		if err != nil {
+			t.Error(err)
+		}
+		w.Write(buf)
+	}))
+	defer ts.Close()
+	tmpdir, err := ioutil.TempDir("", "gtfs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpdir)
+	opts := WorkerOptions{
+		FetchOptions:  FetchOptions{Directory: tmpdir},
+		ImportOptions: ImportOptions{Directory: tmpdir},
+		MaxAttempts:   2,
+		Backoff:       time.Minute,
+		ImportNew:     true,
+	}
+	claim := func(t *testing.T, atx tldb.Adapter, now time.Time) Job {
+		job, ok, err := ClaimJob(atx, "test", now, time.Hour)
+		if err != nil || !ok {
+			t.Fatalf("expected job: %v", err)
+		}
+		return job
+	}
+	t.Run("fetch and import", func(t *testing.T) {
+		testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
+			atx2 := &testdb.AdapterIgnoreTx{Adapter: atx}
+			feed := caltrain(atx, ts.URL)
+			EnqueueJob(atx, NewFetchJob(feed.ID))
+			r := RunJob(context.Background(), atx2, opts, claim(t, atx2, time.Now().UTC()))
+			if r.Error != nil {
+				t.Fatal(r.Error)
+			}
+			if r.Job.Status != JobSucceeded {
+				t.Errorf("got status %s, expected %s", r.Job.Status, JobSucceeded)
+			}
+			// Import job for the new feed version
+			fvid := r.FetchResult.FeedVersion.ID
+			job := claim(t, atx2, time.Now().UTC())
+			if job.JobType != JobImport || int(job.FeedVersionID.Int64) != fvid {
+				t.Fatalf("got %s job for %d, expected import job for feed version %d", job.JobType, job.FeedVersionID.Int64, fvid)
+			}
+			r = RunJob(context.Background(), atx2, opts, job)
+			if r.Error != nil {
+				t.Fatal(r.Error)
+			}
+			if !r.ImportResult.FeedVersionImport.Success {
+				t.Errorf("expected successful import")
+			}
+			saved := Job{}
+			testdb.ShouldGet(t, atx, &saved, `SELECT * FROM dmfr_jobs WHERE id = ?`, job.ID)
+			if saved.Status != JobSucceeded || saved.Attempts != 1 || !saved.LockedAt.IsZero() {
+				t.Errorf("got status %s attempts %d locked at %s", saved.Status, saved.Attempts, saved.LockedAt.Time)
+			}
+			return nil
+		})
+	})
+	t.Run("retry", func(t *testing.T) {
+		testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
+			atx2 := &testdb.AdapterIgnoreTx{Adapter: atx}
+			feed := caltrain(atx, ts.URL+"/404")
+			EnqueueJob(atx, NewFetchJob(feed.ID))
+			// First attempt is retried after the backoff
+			now := time.Now().UTC()
+			r := RunJob(context.Background(), atx2, opts, claim(t, atx2, now))
+			if r.Error == nil {
+				t.Fatal("expected error")
+			}
+			if r.Job.Status != JobPending || r.Job.LastError == "" || r.Job.RunAt.Before(now.Add(opts.Backoff)) {
+				t.Errorf("got status %s run at %s, expected pending after backoff", r.Job.Status, r.Job.RunAt)
+			}
+			if _, ok, _ := ClaimJob(atx2, "test", now, time.Hour); ok {
+				t.Errorf("expected no job before backoff")
+			}
+			// Second attempt fails
+			r = RunJob(context.Background(), atx2, opts, claim(t, atx2, r.Job.RunAt))
+			if r.Job.Status != JobFailed || r.Job.Attempts != 2 {
+				t.Errorf("got status %s attempts %d, expected %s after 2 attempts", r.Job.Status, r.Job.Attempts, JobFailed)
+			}
+			return nil
+		})
+	})
+}
+
+func TestMainWorker(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		buf, err := ioutil.ReadFile(ExampleZip.URL)
+		if err != nil {
+			t.Error(err)
+		}
+		w.Write(buf)
+	}))
+	defer ts.Close()
+	tmpdir, err := ioutil.TempDir("", "gtfs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpdir)
+	testdb.WithAdapterRollback(func(atx tldb.Adapter) error {
+		atx2 := &testdb.AdapterIgnoreTx{Adapter: atx}
+		feed := caltrain(atx, ts.URL)
+		EnqueueJob(atx, NewFetchJob(feed.ID))
+		opts := WorkerOptions{
+			FetchOptions:  FetchOptions{Directory: tmpdir},
+			ImportOptions: ImportOptions{Directory: tmpdir},
+			ImportNew:     true,
+			Drain:         true,
+		}
+		results := []JobResult{}
+		handler := func(r JobResult) { results = append(results, r) }
+		if err := MainWorker(context.Background(), context.Background(), atx2, opts, handler); err != nil {
+			t.Fatal(err)
+		}
+		if len(results) != 2 {
+			t.Fatalf("got %d results, expected 2", len(results))
+		}
+		for i, expect := range []string{JobFetch, JobImport} {
+			if r := results[i]; r.Job.JobType != expect || r.Error != nil {
+				t.Errorf("got %s job error %v, expected successful %s job", r.Job.JobType, r.Error, expect)
+			}
+		}
+		return nil
+	})
+}
@@ -8,7 +8,7 @@ import (
 
 
 func init() {
-	data := "PK\x03\x04\x14\x00\x08\x00\x08\x00\xf5+\x83Q\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0e\x00	\x00postgres.pgsqlUT\x05\x00\x01?x\xc8_\xd4\\[o\xe3\xb6\xb3\x7f\xdfO\xa1\xb7\xdd\x05\x82\xc2\xf7\xcb\xf6)\xcd\xbamp\xb2N\x9b\xcbi\x8b\xa2\x10h\x89\xb2y\"K*%'\x9b\x1e\xfc\xbf\xfb\x1f\xa2$J\xa2x\x19*q\xbdy	\x10k8\xbf!\xf9\xe3\xccpH\xe9\xe2fu~\xb7rV\xbf\xdf\xad\xd6\xb7\x97\xd7k'\x89\xd3lK\xd2\xef\xdfu\x9e\xec\xd2,\xa6\x98?\xb8;\xff\xe1j\xe5$\x87MH\xbc\xef\x02\x8c}\xf7\x11\xd3\x94\xc4Q\xea|x\xe78\x8eC|gC\xb6$\xca\x9c\xf5\xf5\x9d\xb3\xbe\xbf\xba:c\xbf3Y\xed\xc3\xec9\xc1\x8e\xb7C\x14y\x19\xa6\xce#\xa2\xcf$\xda:\x9fW?\x9e\xdf_\xdd9\xef\xb7Y\x90\xbe\xff\xf4\xa9+!\xe8\"\xa1V\x0d@\x05F4$8\xcd\\\x0f\x858\xf2\x11u}\x94a\x87\xfdiK\x86(\x03\xc9\xa5;4\x94\x18\xd5\x16\xda\xfb\xd3\xaeL\xf1(C\xdb\xb4\x1a\xf5bF\xaa\x81\xcb\xbc\x1d\xf6]\x949\x19\xd9\xe34C\xfb\xc4y\"\xd9.>\x14\xbf8\xff\xc4\x91h\x0c\xd9'1\xcd\x8c\xad\na\x8fbd\x96\xe5\xc3\x1b\xc5O\x1f>\nx\x87\xc4\x7f\xa9\x8a\xc2d7\xc4\x8f8tH\x94\xe1-\xa6\xbc\xc1@\xc4\xa3\xe1\x0b	\x90s\xc8\xa5\xe8I5\x1d\xf9t\xea\x9e\xef\xfd\xa9\xee1S\x9fs\xfe\x11\x85\xc4GYLU\x92>\x0e\xb1y\xe8\x1aF\xf9D\xa2\xeb\xddG\xf9\xfa\xcdW\x14\xe7\xaei\x01\xa7\x98>\x12\x0f\xe7k\xd84|\xfb8\xf2\xd13\x9f\xa7\xf6\xc3\xec\x80S\xf5\xd3'\xecG\xba\xe7\xd9\xee@5\x8f\x03J\xd4\x0fS\x94\x1d\xa8\xe6\xf1Acu\x9a!\x9a)\x977\x8e|\xe5\xb3oc\x015]\xb5\xd2\x0doq\x84i\x8e\xe4l\xe28\xc4(\xe2\xcfU\x0c\xf2\x0e\x94\xe2(cd6\x11(\x8ep\x9a\xc5	\x84@\xd2\x15\\ND\x82\xbdW\x88\x12*\x87\x1a\xa24\xef\x0d\xc4\xab6\x1aXy\xd4r\x16*\xa2}K,\xa9\xac\x88\xa9[i#\x91\xeb\xedP\xb4\xc5)\xce\xf2\xa9kY\xbd\xc5\xf1\x1eg\xf4\x99;\x14\x1co)Jv\xcf\x1f~*\x9f\x9cM\xc6\xa3\xd9\xc7B\x1ay\x19y,\x1c_\x93\x8a-\x8d\xd8'E\x1f2J6\x87\x0c\xa7\xdd\xb9\xfe\xf3\xafz\xb6\xff\xff?\xb2\xb9\xfe\xf3\xafBY\x84\xf6\x92T\xa0$\x804\xdb(\x0d=d;\xe7\xff\xd28\xda\x08H\xc5o\x1d\xae\xa6`a\x1b\x7f\xce\x98\x95\x1e<\x0f\xa7ip\x08\x0bV\xc2[\x16\xe2\x98RYt\xb1\x8a\x86!\xf1p\x94bp'\xe3l\x87\xa9K|\xf8\xb0\xa04\x8d=\xc2\xf8Vx\x12h\xc3\x10E\xdb\x03\xdabx\x0b\xc6\xbe\x9c\x18i\x82T\xd1\xccfp^\x94n\xaa\xdc*32\xcd\xf2\xe4\xf2\xc5iu\xc7\xe9\xbf\xc4\xd1}\x0btd\xbd\xa2\x18\x859\x8c\x8b#\xb4	\x1b\x11\xab\xd2\x14\xa00\x15C1k\x99P\x12S\x92=\xb7\xfd\x0e\x8b\x079_\xac\xdcq/\x07\xac\x9b\xa3\"\xcd\xa58\xc3Q\x96\xff\x90`Jb\xbf\x93\xf1.\x07F\n\xb1\xdc\x0emq\xe4\x11#\x89\x98\xd83$0\x97\x92r\xc7*\x97\x95g\xe3R\xd1|\x8c\xd8\x10\x01\xe5\xf3\xd5\x0f\x95Mv\x16\x8a\x03D\xb1\x8d\xe1x\x8f\x88Y\x18H\xaa#\xc7xC&\xa8\xe5\x13\x8d\x0ff\x97\xc4\x84 d*\x04\xd3]Ny\x10\xa3\x8a\x06a\x1cmm\xe4}\x9c\xca2F\x99(K\n\xe4\x1b\x80B\x00\xc2\x89B\xd2\x8bC\xa9\xa7\x93\xc2\xe2\xaf\x99U\x834\x1f\xb2\x98\xfa\x98*\xac}\x13T\x13\x9d\x8f\x0d\x13\xf3\x9d\x84\x89\x88\xd0\xdd\x06\x93\xf3b\xdfL(&	\xa2\x1e\x93\x041/\x1fE\xb0\x95\x10\xfe\x85\xb1\x87X\xf0\xd0\xb0\x99)\x03\xbb\xdb\xa7\x1d\xc6\xa1\xb7C\x84\xba\x9b\x18Q?\x97\x90\xebUo\x08~\x89\xf3\xd4\x83\xed\x06\xde.W\x13\xc4v\xbbyf\x96\xef\xe0Z\xf9\x14~\xc4a\xdd\xb0A\xde\xdb\xd5\xaf\xf7\xab\xf5\x85|\xcf\xec\x12\xdfM\xf1\xdfL\xc5\xed\xdd\xf9\xcd\x9d\xf3\xdb\xe5\xdd\xcf\xce\x90\xfdp\xb9\xbe\xb8Y}Y\xad\xef\x9c\x1f\xfe(\x7fZ_;_.\xd7\xff{~u\xbf\xe2\xff\x9f\xff^\xff\x7fq~\xf1\xf3\xca\x19~\xff\xee\xfc\xeanu\x03\xc2v\xae\x7f[\xaf>\xe7\x102\xa1\xef\x88\xaf\xecI#M\xfd\x97\xfb\xd1E\xee\xf4\xa2!\xd2\xec\x83\xb2\x80\xed\xb2\xaa\x1c\x89\x82\xd8\xe4W@Ta^\"w\xec\xe2\xca#\xff`U\xdc|J\xe5O\xbc8<\xec\xa3T\xb5\x96wh(C\xdaa\x94\x07\x08\xc9\x13/}tC\xf2\x80;e\x9eocAj\xd6\x8eb\xc2N\xc1>\xa5\x15r&v\xc5a\xacd\xd1\xae\xd8\x17\x98xYn\xce\x14\xb3Z\x95\xd0\xe3\xad\x8c\x12\xf8\xab\x87\x13\x165\x14\x02\xd2\n|/\xde\xf4bJ\x8f\x15H\"7\xa1\xf1\x966\x87D\xb7;L\x1fH\xe2\xe6\xfb\xae\xec\xb9\xd8\xa7\xba^|\x88\xb2\xa2\xb2P\x06BD#\x12m\xbb\x0f\xcaf\x9d\xdfyE\xb5\xfb\xa8	Gq\x80)\x8e<\xac\x17\x0bH\x98a\x89YM\x99=\xa2\x0f2\xb8|\xcah\x12\x87\xcc\x18\x1e\xfeK\xb9r>\xa1\x0b\xaf\xc9\xc9S.=\x89\x1d\xfa\xc5\xd7l\x00[~\xd5\xa9\x07\xe3\xbdi\x01\x82h\xc9\xb7G\xf9\"+\xe7\xaf\xf7\xe1Bs?.Y\xb4\x9d\x1d\x96R\xa6\xdeT)E4\xe9dy\xe0#\xedou\xde#}X\x1f\xf7\xc8\xdbV\xa7=\xf2\xe1.\x0e{\xe4\xbe\xb0:\xebQxJ\x99\xbdP\xfa\xb79q\xca\x05 \xb5D\xbf\x04\xdaM\x8c\xd9]u\xba\x7f\xc2^\x82\xfa\xa5\xedI\xab\x16\xf6/\xf7D\x86\xdd\xe9IKH\xe9\x98Z\xc7\xb5\xcc%\x98\x1c\x92\xd2\xa5\xf0h\xafY\xd4\xc0p~\xb44\xd0\xc2\xa76\xce\xa6\xe1kZ2\x9c\xa7\xe0\x86\xd4\x029C\xda\xa2F\xc6\xf3\x93\xfdSv\x0b\xd6#\x03\xe9Y=\xb6q&h\x08\xc3\xb94\xa0\xa2\x92P\xe2a\xc7\x8f\x0f\x9b\x10;	\xc5\x1ea\xe7\xb2\xc2*`\x9ba\xefYu=IP\x89\x9e\xf7\xf9\xd6y\x8f\xb3]\xa3n/D5\x8a\xa24\xc0\xd4\xf5\x0f\xb4($\xbc\xf9\x05(\x16\xf1\xda\x1dM\x01	\xa6l\x9eO\xc1[\xb9	r\xf6\n\xb2\x00\x0e\xd3Ch\xa4oL\xf3_!\x04\xf6q\x9a\x91\xa8(\xf6\x01\xc4\xbd8\xca\x10a\xf1\xd4,\xfbv\xb8\xc7\x13\xea&\xf5*\x1f`\xac\xc6	ss2\xca5\xd15lcb\x06\xa2\xb1#ah\x1d\x8b\xb5Lw\x98\xc2\xea\xdaB\x1bH=\x9a5\x01\x1d\xd5U%\xbb\xe6f\xa8\xf1\xa4\xb5\x11\x92\x10\x04\xd4\x817Al#_\xf9\x14\x9f\x84\xaf\"\xba\x82\xaf\\\xcc\xc0W\x8a\xff>\x80\xce\xa9\x0bj\xb0\x11\x96G\xcc\x9c!\x9a\xc7;\x8c\xfc'\xf4\xec\xa6\xd8S\x15S\xf1W\xe4\x15\x10*\x897A\xa0*\xfe\x92\xa4\x0f\xbb\xea	9	\xbd:\xf0\n~\xd5rz\x82\xbd^\xed\x86\x1f\xf2\x98\xbcL)\x18\xf9\xf8\xab)\xc1,D\xdf\x84\xef2\x11\xe7$\x05\x91.\xb2\x9c.\xdd\x82G\x97)	\xcavO\xe8\xf9U\xb8R\xea\x82\xb0%\xa0\xf1\xde\xadN\xab\xe5+9\xd6?\xaf\xc0\xf6\xb1\xafr~$u7\xc4'\x14{y\xbe\x88TE\xfc\x10G\xdblg\"mFQ\xde\x7f\x14\xea\xdcm\x9a!B\xdb\x15fAb\x8f\xbe\xbai\x18'\xc6]\xd8\x9eD\xee\x13\xf1\xcd\x86\xa5d\x1b%q\xca(.\xb9;*HS\x9c\xf7\x02\xbbv\xad\xbe\xf5eX\xb1\xf8\x14\x0bQ\xc0\x96/\xc5J\xc8X\xc1(\xee\x1b\x9d\xa2\x1f-dy/\n\x11\xbdCIw(1\xa75\xb9\x10\xc4Ot\xef\xe7\xeb\xce\xb3\xd4w1\xaeH\x84o3J\xa2\xed\x97\xb7{#\xc3\xb4\n\x8a\xa1?\x05wZ\xc8r\xee\x14\"\x06\xeeT\x87t&\xfe J\xc9\xa3\xde\x15\xfb8A4;P\xac\xf7\xd7q\x92\x9b\x9c'UZ\x99<\x8f\xce]\xa6\x91\xb0	\xf1\x1e\x0e\x89\xae\xc8\xec\xd38q\xe3 \xd0^Vb\xeb\xc3'i\xe6\xb2\xb8\x13b\xdf\x14\x06\xf2^&1Q\x06\x9e\xe6ih\xe7v\xad\xf8>\xd9\x9bX\x0eU\\\x96f\xfc\x8d\xb9\xeb\xb1\x908\x0fO\xb2\x98Dt\xc5\x82\xe2b\xc6\xa0\xc2\xae\x0e\x9e\xaa+\xe6^\x18\xbcB]S\xd5;\x05^d\xd6\x1d\xdb\x92\xc8\xad\xe5\x1a\x8e\xe1\xcd\xf1\xfe%\x19\xb4\x89\xff|\xc4O\xc1\x19\x11\\\xce\x1b.e\xe2\x0e1^\x9a\xad<\x88\xc9\xb539p `\xd2\x167\xbd\xf9>\xc5%\xaaS\x94M\x18{\x0f\x10K\x1b\xb7W\x11\xbb E\xf2\xc8\xa1PJ\x1ep\xea\xa20\x8c\x9f\xb0\n\xf8\x0d-\x0b\xa14.\x8d\xabB\xe1\x9c-\x92\x04e\x19\xa6\x9a\xc1\xef\x7f\xe0\xca8x\x9a\x95D\x0c\xde\x97I\xb0\x15T\xa8)\x16\xd0\xf5\xfaJ~3\xd6)\xa4.\xae\xaf\xee\xbf\xac\xf3\xf5t\xbb\xba\xab\xa7\x0c\x7f\xcd\x1eQ\xf8\xe1\xbd\xacei\xc5\xfbO\x9f(\xdez!J\xd3\x8fj\xcc\xaa(\x9ea;\xc4F;{<\xc9\x9dXkl\xe5\x05\xc9\x1ev\xb0\xf9\xa9nA\xf6\xb6\xa4\xa9\xa5\xbf-\xc2\x95\xb0\xde\xd6H/\xef\xd8\xdb\xd3\xdf\x02;L6x\xfc\x852\x1b\xccVK{L\xe1\xc6\x8b5\xb2\xf4\x82E\x0f\xfc\x17@\xf7@\x15\xaf<Xc\xcb\x0f\xb2m-(\x0e\xac\xfb\x817\x8f4mp\xeb\xf3K{\\\xf1h\xca\x06\xb7q\x0ee\x0f\xdc9\xb4\xb0@\xee\xe1I\x1a\xed\xec\xf1x\x99\xdb\x1aQ(\xefY`\x96\xaf\x0eZ#\xb6\nq\x16xe\xe5\xcd\x1a\xafU\xbc\xb1\xc1\xab\xab5\xf6\x98\xe2\x1e\xd7\x12\xb7'd\x0f\xb4z\xf7i\x8d(\xeed\xacPI\x9f>63>\x18Z+Kc\x99\xe5\xf9\xe7\xcf\xce\xc5\xf5\xfa\xf6\xee\xe6\xfcr}\xe7\xb4\xd3\xb8\xe4\x01?;\xbf\xdc\\~9\xbf\xf9\xc3\xf9\x9f\xd5\x1f\xce\x07\xe2\x9b\xa2v\x91\x91\xc9t7\x13\xb6>\x9a\xbb\xf9\x96\x12E\x92\x9a\xbd\x04\xb1\x99W\x191[I\xd8KP\xdb\xf9\x93\x11WH\xb7^\x80l\xc4\xb2\xd6\xde\xca\x90d\xda\xdb)T\x1f\xed\xed,H\x89!$K/A2\x83\xf4\xd3/$6J\x141\x01\xea\x8d\xc5\xf2\x18=L\x91\xea\xf4B\xe0\x19\x8b\x1a\xa1Njz!\xd4\xa9\x89\x1a\xa2\x91\xbe\xf4\xc1P\xaf\xc1f\xa2\xd2Gs\x95q(u\xf3\x94\xa4\x8f\xf6\"\xbbP\xea.\x93\x8f>\x9a\x8b<B\xa9\xb9L3zi\xe6\xd9\x82Z{\x9dP\xf4E\xd0+\xef\xa7\x97\xa7\x00J\xddu\x92\xd0O?\xd1\xd8]\xa4\x03\n\xbde\xc9\xear\xfdy\xf5\xbb2F\n57\x97\xf8_\x9d\xeb\xb5!\x08;\xf7\xb7\x97\xeb\x9f\x9cMF1v>\x08*\xa0\xc8\x11\xda\xe3>py;(\x06\xfb~`\x0f\x8c\xbc\x9d\x16C\x88\xbc\xd55N\x1d\x96Pci\xe1U\xedm0-fN\x07\xad\x9c\xbd\xfb\xf5\xe5\xaf\xf7}\x0c\xa9*\xb4\xe5\xb5\xd7W2\xec\x8cW~\xcf\x1a/\x17\x9e9}\x86\x8e[\xd8\xd3\xb4\xaa\xbd\x0dfms_\xd4Z\x83\x88+Ks\xear\xb6\xdb~5Jf\x83D\x81\x80\xcd\xb5\x9d	oZ\x9d9J\x8b\x1aA^OVA\x1aH\xd0\x02\x89]Ht\xdb\x9b\x988r\x15\x1f\xe6\xab1\xdbu\xef\x16\xa2\xbc-\x14\xf8\x90\xed\x800\x87l\x07T\n\xf8\x88!\x08\xd2\xac\x07h\x10\xbf\x88\xa3\x87\xdd\x924s>T\xc2\n\xdf\xa2\x80h|[\x13\xd4\xb7Z\x1e\xd8\x07\xf6\x95C\x90\xea\\Rk<\xa3J\xb9\xcf\xcd\xc3\n.\xbe`',\xf2\xf2\xc4\xa3\xcbn\xa5\xdbUk\xe7\x9f|\x83bT\x0d\xac\x91\xa4\xabG\x8fe\x0c'\x0d\xb4J\x96\xf9\x80j\x0fm\xc6\x965\x03Z\x026\xa1\xfa\xf8\x845t\xd9\xd0\x0c\xc9`\x14\x9f\xe6\x96\xa3\x8a\x89\x83\xb4-\x10\x98\xfd\xc0B\x02\x8a|\x15i\xe5\xb8\xbc\xe9\x99\xd3a\xb0\x16S\xfauq\x10\xa4\xac\xa5\x1c\x94M\x0c\xaf,\xe4>\x99\xbf\xca'\xc4\x1c~\xf6\xd3v\xcc\x95\xb8\x9dzv\x0b\xc0\x06\xa0\x9d\xc9J\x16H\x1b\xe9\x10\x91\xbf\x0f@\x04\x81\xfcg\x0e\xa4OB\x06\x11G\xe2\xec\x18\xd3\x04\xc3\x9ct\x01\x84\xb7\xb6-\xa0\xda-\xad@\xd5\xae\xc5\x88\nr*\n\xd8\xc6\x05\x03\x0b\xc4\xba\x15\x00\xac\x18\xd3\xea\x9d2\x05\x8aq\xef\xa1bb\x0bE\x18	\xe6B\xcc=4\xd1\xd4\xbe\xb7\xe5\x173\x80xL\x18\xaa\xba\xfc\x04\x08Lu!\x0cU\xcd\xbf\xe5\x01S^\x89\x83\xd5\xdbN\x84\xfd\xb87\xdei\x04B(\xb7/J\x88\x83\xc5\xf8\x17\xc2P\xd5\xfc+,0\xe5\x958X}\xf9\x81\x18\xa0\xf6B\x1a\xaa\xbc\xfe\xc0\x0cL=\x97\xd7\x00\x88\x85e]\xd0\x14\x8f\xf1\xadc\xa7\x04\xacz\xb7\xd9\x06\xaalc\xf6[\"\x9e<\x90\xea\xb1DG\xd5\x01\x97\xa3\x16EtC\x07\x8b\x0b	\xfa\xbe\x99\xd4\xebC\x9a\x12\x06\x1c\xce\xdap\xfc\x12\x1e\x10GU0i\x024\xca\xf5\x80\x0e5.6\xf4\xedQ\x1b\xb0\xba$\n\x05*\xe5\xcd\xec+\xeb?r\xd2\x81\n_\xd5+\x9a\x9a\xce\xf0\x13\x03\x16\x11\x1b\x17\x87\x05<~MB\x08\x8bu\x0b \x08\x7fm\x14\xd0!K\xfb\x1bo\x19\x82\xac\xaf\xe5\x81\x00\x8d{\xd3 \x80Z\xde<\xdb\x1cG>\xdf\x8a\xf1\x17g\x1c\xd4\xa5\xf2$G\xe7\xaa\xcb\x1b*\xd6\x1e\xbaV\xddI\xf3l\xa0:\xfd\x82\xeeDj\xfc\xc6\xf7\xae\x01\x80\xb5\xb4\x85rX_\x00.LT\\\x7f\x80\x0e\xac\x9f7\xb1\x80i\xdcA\x07\xe3\xd4m,\x80d[45D{[\xa6Z/%\x86|\xb5\x808\x05\x98\x98\xf2d\x92\xd5-\xab\xd7\x0d\x05\xa8\xf2nU\x0b\x8a\x0b\x03UwJ\xa2]\xcd\xaa\x82\xa8F/\xbf\xd2\x0e\xb0\xb8\x925\x8f{\xa9_>\xee2\xd5\xdd\xadZ\x07\xab\x0bR\x9f\xd9J\\I\x19AU\xbe\xb8q\xfbLoH\xa9\xe7\xcc1G\xb0\xb6A\x96\xc0\xb6\xea\x15\x19\x85J=8\xa1h\xa0(\xa6\xaf\xdf\xc8U\xafI\x1a:\xa8';\xbb\xbag\xcb\xf5Jk\xfb3\xef\x1a\xd5Ub\xd1\x94\x87\x00\x08\x1f;7#\xb4\x1b@ \xd8P\xb2/\xef\x9b\xb5sY\xb0bY T)6\x84\xc1\xb6b9O{\xad\x80Z\xa9,&\xa9\xd4\xc2\x8a\xa1\x85~5\xedM\x8c7\xf7\xa0\xbe\xaea\xde\x85\xd4WG{\xeeA\xda`\x9a\xbc]\x85\x04K\xdc[0\xea\xecW\x01\"K\x7f%\x10$\xd1\xe6T\xc5\x85W\xdb\x94\x8a\xabUW\xb4$\x8aA\xd5\xacZ\xb5\"\xc0\xca\x14\x9bc\x1eW\xdb~\x9b\xd0\xac\xbb%\x0f\x06\x00\x99\xdd	-\x06\xa5\xea\x8cR\xa5\\\x96O\xaa\xd6p\x81%_\xc3\x12\xfd\xaa\xa8\x05\xbc\xd9\xda\xf3\xee\xb0\x98\xac\x04\x0f\xf8\xd9\xf9\xf1\xfafu\xf9\xd3\xba\xb8c%\xaer\xe7f\xf5\xe3\xeaf\xb5\xbeX\xdd\xca\xecH\xe1\x97q_x\x0d\xf8\xdf6\xbd\x9e8\xa9\xa5\x0f.E$L\xdd\xc1\x14#\x7f\xb0\x98O\xc7mc\xf8\x9a\x92XQ\xf8u\x96\x8d\x82l\xd0\xdc\xc5\xab\xed\xf0\xbcY\x10\x8c\x16\x0b$\x0cJ\xd3\x97*m\xc9c\x0c\xc0\x944\xd3]h\xe4\xb6\x8cFh6\x1f\x0e\xe6\xf3\xcd1'\xa8\xa5\x8d\x99\xa7\xbb\xd8\xcem\xf3\x87\x93\xe1|1\xf2\x96\xc7\xb4\xcdf\xb4\xc6\x03\x0f\xfb\x83\x0dB\x0b\x81A\xaf4i\xfa\x9b\xd1\xb5\x19c\xbc\\\xcc\x96\xder\xde6\x83\x873\x95\x1d\xc5v\x16`H\xaa\xba\xacZ\xdb\x80\x16\xe3\xe5t\xb4\x9cN\xda6\x08\xf9\xeaKG\x84\x9f(h\x8d\x99\x8c\xa6\xe3\x85\xbfYnF\xc7d\n\x13\xd5\xbcjRY3\x1d.\x96\x93\xc9|89>o\x0d\xd7\xd0\xb9I3<\x1b-\xa7\x83\xf1<h\x9bTE2\xd541\xa7\n\xb0\xa3}~\xac5e\x8e\xc6\xb3i0\x9d\x0f\x8e\xeaq\x1a~[k\xcdb\x82\xe6\x13\xbc\x18\xfb\x8b\xe3[c\\S\x8b\xd9 \x08\xd0\x14M\x06mcx\xf9Z5ME\xdc\x05\xd8\x00x\xcd\xa3\xb6\x06\x8d=\xb4\x98\xcc}|\xf4\xa1\xd1\xbd\x8fP\xdb\xe3\xcf7\xc1d6\x9a\xce\x8ei\x0ft\x91/\x97\x18a\xdf\x0b\x962\xe2\xc8\xadh\xdd\xef\x03\x8c\n,\x9b@\x83\xf1\xc0\x9b\xa0\x11:\xbe\xb7)\xc2\x87\xde\x9ai\x10L\xd1\xc8\xc3\xc7_\xdd \xd2\xa0\xd9l\x81\x87x\x80<\xc1\xf1\xc5\xaf\x96i\xe9^1\xab\xed\x98\xe3\x817\x99-\xa6\xc7\x1f\x17s\"\x9c\x87n\x1f\x0d\xc6c1\x01}}cl\x1c\xcef\xb0\x9c\x05\xf3	\x1e\x08\xf9y}^\xa3\x9a\xaaj\n\x00K\xbb\xb2]o\xc9t<\x9bzc/\x18\x1fmq\x033\xce\xcd\x14M\xe6\xc3e\x97\xbf\xaf\x14\xb8\x81\x19\xe7\xc6\x9f\xfb\xc3\xf9b2\x12\x07\xa4\xbc%\xa02C\x98\xfe\xd73\xc8\x1b\x8fg\x18-\x83\xe1\xf1)\xac\xd9\x03sk\xa6\xc1\x06Mfx2?\xba5\x16\x99\x96\x87\xa6\x83\xc9\xc6\x1b\x0c\x85\xa4\xafQ\x9cR\xcd\x1bO\xbb\x01\x16\x99\xf3\x1a/\x98l\xbc\xf9r\xb8\x18\x1c}x\xa0	\xb1?\xdcLf\x8b\xc1h\xf2-\xf8c\x7f\xe4\xcd\x82\xe5\xd2\x9f\xe2\xa3N\x14(`\xfa\xc1b2C\xb3\x8d\xb8\xaf{\xd5\xe2\x04,\xb3\xc1Co:\x0b\xe6>\x9a\x1c-v\x032\x1a<\xc5\x9bA0\x9c\xce\x8f\x14\x95\n\xdab\xdd\x0b\xcb\xb5-\x9b\xc5l\x8c6\x1b1\x1a\x9c\x84\xb5{\xe2/\xc7\xd3\xe9 \x98\x0e^P\x86\xf8o\x00\x00\x00\xff\xffPK\x07\x08\xebr\x11\xf8\xb3\x10\x00\x006\x8d\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00!:\x82Q\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x08\x00	\x00regen.shUT\x05\x00\x01o?\xc7_t\x90\xd1j\xdb0\x18\x85\xef\xfd\x14\x07\x13p2\x90\xfd\x00a\x03/\x13c0\xb2Q\xfb\xa2\x17%\xc2\xc8\xbf\x1dQ[R\xf5+n/\xfc\xf0%\x0d-)\xa9\xef\x0e\xdf9\x1f\x12?S\x84\xa0\x97\x84\xf4\xd1!\xdd\xdd\xc9\xb2\x96\x90\xf7\xb5\xdcW\x7f\xfe\xed\xe1\x1d\xc7\xde\xf06\xc5\x8fK\x0e\xc4\xb9\xef\xf9iX2\x8e\x1c]\xa0\xb3pc\xf8^\xb5\xa7\xd1\xe3!\x01\x00\x11\x91\xe9S\x08d\xa3\xea\x88Z\xce\xae\x8a3P\x13\x056\xce.\x16\xaa\x8f\x1d+3z\x17\xe2\xf2\xa83\x03)c;\xb7<a\n\x93\xd1\xa4\x06\x9ah\xb8\x9dql\"}\xc2o\x0f\x7f\xfb \xc2:\xe1\x9e-\x85w\xc0\xd7\x8dv\xe3H62V\xff\x7f\xff*\xeb\xf2gYI\xcc\xa0>\x90\x87\x98\x90\x1e\xd6\x95\xac\xe7J\xfe\x95\xbb\x1a\xbeW\xba\x89\xcd\xe0\xfaY\x88M\x8a\x19L-\x04!+\x0e\xab\xa2\xcd\xf0\xc5a\x93\xb5n\x91\xe7\x85\xb1\x91\x82m\x86-\xce_6\x8f\x10\x1c\xf4\xf7</X\x1fil <.i\x93\xbc\x06\x00\x00\xff\xffPK\x07\x08~\xcf\xe5c\x0e\x01\x00\x00\xf8\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xe9+\x83Q\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\n\x00	\x00sqlite.sqlUT\x05\x00\x01&x\xc8_\xe4[Mo\xe46\x12\xbd\xfbW\x08>u\x80\x9d\x1dd\x81\\vO3\x89\x03\x0c\xe0x\x163\x1e 7\x82-Uw\x13\xa6H\x85\xa4lw~\xfd\x82\x1f\x92H\x89_\xee,\x90\xc4\xf6\xadUOO\x14\xebU\x91U\xa2\xdf\xbdkNJ\x0d\xf2\xdf\xef\xdf\xb7\xbc\x83=\xe0Q\x91\xc3\xf9\x9f\\\x1c\xdf\xcb\xdf\xe8\x81\x8b\x1e+\x05\xe2\xea\xc7/7\x1f\xeeo\x9a\xfb\x0f\x1foo\x9aO?7w\x9f\xef\x9b\x9b_?}\xbd\xff\xda\\\xb7\xa3\x10\xc0\x14:\x00t\xf2\xba\xd9]5\xcd5\xe9\xae\x1b\xc2\x14\x1cA4\x83 =\x16\xe7\xe6\x01\xce\x0d\x1e\x15'\xac\x15\xd0\x03S\xffh4\xb4\x15\x80\x15t\x08\xab\xeb\xa6\xc3\n\x14\xe9\xa1\xf9\xe9\xe6\xe7\x0f\xdfn\xef\x9b\x1f\xbf}\xf9rsw\x8f\xee?\xfdr\xf3\xf5\xfe\xc3/\xff5O\xbe\xfbv{ko\x1e\x87\xee\xf2\x9b9\x03\xa9\xf8\x80\xf4`\x1f\xb1hOX\xec\xfe\xf5\xc3\x0f\xdf-0\x8d\x92\x03\xb49{\x07\x14\xd6C0\x06JZ`\x12\xae\x9b\x8f\xb7\x9f?\x9a+xT'\xef\xe7(\xa8\xf4~R\xcc\x8e#>\x82\x7f\x0dK\xc9[b^\xd1M\xefl\xd2\xbf\x11\xc3=\xc8\x01\xb7Px\x89\x03\xa1\x90\xb0_}\xf7\x9f\xac{\xcds\x1eAH\xc2\x19:\xaa\x83D\xa4\x1f\xb8P\x17\xb8:\xa0\xf2\xef\x0b\xfd\xf2\xe7)B\x8em\x0bR^7{\xce\xa9\x998\xfb\xae\x88\xf2\xe3u\xb3\xa7|o/24\x08~\x14!\x14\x9e[\x18\x94~\xb5\x15\xdaQ\xc0#\xd0\xe5\x95\x19W\x0d\x1b\xa9{\nS \x06N\xcd\xc8\x8d&\xf5\xb0Q\xcbG\xa6\x12\xb7\xc8\x072 `\x8a\xa83\x02!\xb8\x98\xd0\xf3s}\x84\x80\x03\x08`-dQ=\x16\x0f\xd0e!\x07B\x15l\x9fu\x04\x06\xc2\x8c~my\xc2\x82\x11v\xdc\\w|\xde\xe5\x92\x10\x8d\xf6\xf4\xe4\xbcJ\xe5\xe5\x13\xd1\x82\xd1\x11_F\xe9l^Fu \x93\xa9\xcd\xa0~\xe7,\x97Y\x16\xa6Q\xd0,\x88\xf2\x16\x9b\xd0P\xe7\x01R\xb3?`\xb3\x8eHe\xa03\xca{\x8c\x9eR=\xa6\xec\xb3\x9eN\x00\xb4=a\"\xd0\x9ec\xd1\x11vL=\xd1\xc4\xa4\xaf\x07'f\xde\x83\x12g\x9bl\xab\x13\xa5\xd1\xe7\x80\xd5\xe9	\x9f_\xa3D\xdd\xab\x95V\x1a\xc1{4\xaby\xf3\x0e\x1a\xa2x\x010=\xa97*\x8eB\x88D{\xd2\x11\x01\xad\xd6\n\xa6	\x18\x05v\xd4k\xae\x00LW\x83\x10XO6\xa6FT\x89\xdb\xa5\xd2*Z\xa5\xe1\x00\xd1\xe3g$)\xd7\xa2\xde>\xa3'\x0c=\x91.>\x00I\x8el\xe0\xd2\xb8C\xe6fT\x80\x1e(\xa0\x9a\x1b\xaa4jT\xff\x1a\x15\xba\x84sz6\x1d\x86u\xf0\x1c\xf3\x8a5g\x92l\xd5\x0c\xcb\x13\x1e\xe05\xce\xb0y\xb1\xd2\x8a0o\x06\xec\xe6(\x9c\xe0\x0b\x93\xab?3\xd3\xc4\x9ak~\xf2^\xe6\xd0.3\xb9U\xeb\x84\xbf/\x02PGD\x16\x94\xd9V\xbb\xc9.\xac\x8b\x80\x05% \x15j1\x05\xd6a\x81\xb4K<\xc7\xac\x04\x8eU=\xf8\x00\xaa=\xad\x1d\x1dB^ \xcd?CsZ\x14eU\xe8\xfd\xc2\x14l/z\xa7\x88\x84\x02\xadf\x02\xd27\xeb$b\xb6\xeb\xc0\xf0\x9e\xce\xaa\xf7w\xeb\x13t\x10\x84\x0b\xa2\xcek\x1e\x8a\xa5\xae\x9f\xb7\xfe\xf2\xed\xae89\x8c\xd4B\x93@[pd\x98\xec\xed\xa6j\xc8\xa4J\xbdH\xe2\xa3Wr\xfeA\x15\\\xae\x03\xf7\xf00u\xf8\xe2\xf8t\xf7\xd3\xcd\xaf\x0d\xe9\x9eQ\x90't\x1e\xfe\xbe\xf9|\xb7N\x1f;\x1b\xfeK\xcaI\xdd\x1f\x0f\xcf\x18c\x1cY~B,\xa2c\xfc1\\\x99\xdd\xc9;F\xe8L\x95\x1c:\x9f&Y\xb41\xc6\xb3\x14l\xd3~\xcfPxu\xdc\xce]/\xdc\x1d\x16\x06\x1b\x92\xd0\\\xe0ZE\xf4\x86leO\xb3\x99%\x1eM\x0b\xa2\xc7c\xd7\xfe\xddd)1\xa4\x07\xe4\x88\x92#J\xef?\xacs\xd9\x81\x07K\xe50\xee)\x91'\x10\xe5\"r\x85/-c\x06N\xb1.\xb4J(\xa9\xb0P\xab\x85\xcb3\x03\xeb\xd2\xc6i\x12\x8a\xc3\xaf_\x03\xfe\xca[\xae\xab\xa4t\x16\xff\xa6\xe5\xe3i\xe0\"		\xf8m\x04\xd6\x92y\x1f\xab\x04\x99k\xb6\xf5f\xc98u.\xa4\xd6\x9b\x1c\xd6%m'\xc0\x9d.\xf5$\xb425\xf7\xf0\x8c[\xcb\x9e\x84\xbcz\x87/\xde@\xce\x0f\x9e\xa7=W\xed\x9c\xb5\x8a)\xad\x1d\x9f\xf1\x02\xf1\xe8AL\xb2\x11|T\x90\xd2\x0d\x88G\xd2\xa6\xac\xb3\xe02\x91n0ZD\xba:.#\xe5\x89\x0bU\xce\x1fsc!#\x90=\xe5\xedCixK\xbdD&\xfdy\xed)l6tdO\x93-\xb1=y\x00\x890\xa5\xfc	\x92#1\x8b\xe8`>\xd4\xe4\x06\xfc\xdac\xc4\x88\x0eMr[\xc4l\xc5\xb8\x9b\x0c\x85\xfb\x17In\x18\x16S\x81c\x13\xa1\x8e\xa0\x14\x9bn\x04\x9b-\xc5\xf4\xfc\xd2\x8e\xc2\xdd\x1f\xcaaK\x13\xda\x0bl\xc9\x1c\xe1\xd8.\xc8\x0e\xf8\x18\xac+\xe6g\xae\xad\xd8x\xa8b\xe8:\\i\xc7\xe2`U\x0de\x87-\xeeo\x1cn8U\x12\x1e\xb0\x80\xda\x81B\x8fI\x1e\xf8\x07\xc3\xfb/\x1d\xda\x93b\xd0\xac\x95E\x87\xb3\x9av\xb3\xb1\xcc\x93T\xf5\xc2v\x81\xb0\x95\xc0L\x1e@\xcc\xbb\xeeu/|\xbd$\xf1\xac\xd5\xb1e\xbf\x99\xf4\x84\xa1\x05\xe8w\xb2\xff/\xaa\xf8\x1b$}7\xe5(\x98.?MM>\xd9\x05\x88\n\xc6L\xea\x9b9k\xcbE\x8f\xd5\x13E\x9c\xd2\x03T\xf0-\"\x8a\xbf4\xdfr\xa5\x15<\xf5\x17&\x05\xfb\x1b\xb4L\xee\xe99\xeb\xf09\xa5\x185\x82\xcc\x98\x9f\xa0cY\x80:\x8d\"g?\x08\x92\xb1J\xacF\x91\xb3\x8f\xb9\xb1\xc7\xca\xd5\x15d[\xb2\xae\x00\xc9~\xf8\x9b\x88\xd0YRhv\xf4\"\xd4Eo;O\x07\x15\\\x8b_\xa2d\x8b\xb9\x82+\x19\xe7\x1eam\x9c/\xac\x93,\xa2t\x93\xb1\x82'\xb6!\xf5\xdf\xb4\xbc)]\xb8l\x9c\xc6\xa7\xdf\xc5p\x05\x8b\x8b\xe78\xcd\x14\xec5<.\xae\x13DS\xd4W0\xd9\x0c\x10\xe7q\xd9\xa1\x82e\xca\x14q\x9e9\x8f\xd40\x8d\xe9yv\xf9\xe6%\xd9\xd8H%\x96\x92\xb7\x9b\x86|\xa6\x9aO(\xe5\xb6\x14o%!\xd9YM\xc4\xa8\x9b\xf2\x9d\x9d\xd0Z\xaep\x82\xd3\xac!\xae\x96\xbd\x98\xa7&\xfa\x97&+\xc7\x9f\xcb4\x13u,\xdd\xa4\x05lj\xeem#\xa8\\\xee\xc4\xc5m\x19*{8\x16L9;\xd6b\x8b\xc7\xa1,,\x17;\x16Q\xaa\xe9,\xaa\xe54\xfd\xfd\xcd\x7f\x1e<\xabj\xb0\xd4s\xc3E\x07\xe2\xad\x86\xb7\xd5\\\xa4\x0f\xe4\xc4Xn\x049\x86H\xad9Q\x94+\xcd`\x14a2\x08\xc7\x91M\x00\x8e%\x19\xf8\x13\xd5\x05\xb5\xea|\x9e\xae\xa2\xbd\x8f\x85 \x8f\xfeI\xa9\xf5\xa2\x03\x03\x16j\x14\x90Dd\xca\\c\x92\xb6\xdf\x9c\x8c+\x03\xaaj\xf3\x0e\xa4}\x18\x87l\x90v\x82\x0f\x88\x1f\x0eY\x90m\xb6uD*d\x8e\x8a\x99\x0f\xfb\xe1y!\x83\xd3o<p\x12= f\xe3\xcd;\xdb\xfb\x06cr\xd1\xd9\xb6-\xeai\xb0\xd8\x1b\xf5x\xa2\x1f\x93'\x9eR\xe1\xec\xf1$\xc3\xca\xe7\xbb \xb4L_O\x8ct\x0e-s!\xb7\xa4\x05\x1f\x08\xb8 G\xc2J\x0be\x07R\x11\x86\xe7\x8f\x14\x19h\xcb\x99\xc2\x84\xc9\x12\xee\x15\xabp\xe5\x17\xe4<\xb2\xb8\xdc\xf3\xd9\xce\x19\xabx\x92_\xd0|\xbe\x0b%\x84\x95\x12d\xef\xed\xa0f\x1d\xe5\xf2\x9f \xed\xe60\xaau\x86\xf9?\x9d\xf6\\>\x1c7\xe0\xb3v5\xeaA\x9dx\xd2\xb9^\xb7\xd3\xe7rKF\xb0\x8f\x0bo@\xdd(\xc2#\xdeoQ\x88\x8bw\x13j\xf4\xdc_'I\x9f1\xab\xcb\x80y#\xce\xab\xabw\xdb\xbf\xab\x8a\x83x\x13\xcb\x81P\x08N\x9f\xbc\x0e\x7f\xda\x9b#\xb5D\xf8\x7f9\xe4woO\x11\x98\x04\x7f\x92	S\xcb\xe9\xd83k]\xf1m\x0f\xaa\x06v\xbd)\x82ue\x10r\xcbGD\xc9\x03\xac\xce%\x96\xcf\xcfy\x8e\x8c\n*\xe5\xf4\x98\xa6\"\x92z\xf7\x02IMe\xa7\x7ft\xbdZX\xf9\xa3\x9c\x8b\x7f-.^\xa6:c\xbe\x1dl1\xb9~\xb0ET|\xcb\xf4\xc7R.x}t\xb1\xe2\xf5\xc1\x89\x1d\xb0\x85$\xbb\xfa\xeeph\xb2\xado\xed\xb9\xbe\xbecH7\xf6\x9d\xcfR\x9d}\xe7\x8dtk\xdf\x01\x12\xbd\xfd\n\xed\x87\x8a+\xeb\x7f\xa5\xd0m\x0c\xfc/\x00\x00\xff\xffPK\x07\x08\x1b/C\xb5*\x08\x00\x00l;\x00\x00PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xf5+\x83Q\xebr\x11\xf8\xb3\x10\x00\x006\x8d\x00\x00\x0e\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x00\x00\x00\x00postgres.pgsqlUT\x05\x00\x01?x\xc8_PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00!:\x82Q~\xcf\xe5c\x0e\x01\x00\x00\xf8\x01\x00\x00\x08\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xed\x81\xf8\x10\x00\x00regen.shUT\x05\x00\x01o?\xc7_PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xe9+\x83Q\x1b/C\xb5*\x08\x00\x00l;\x00\x00\n\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81E\x12\x00\x00sqlite.sqlUT\x05\x00\x01&x\xc8_PK\x05\x06\x00\x00\x00\x00\x03\x00\x03\x00\xc5\x00\x00\x00\xb0\x1a\x00\x00\x00\x00"
+	data := "PK\x03\x04\x14\x00\x08\x00\x08\x00\xbd\x8cS]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x001\x00	\x00migrations/postgres/0001_import_progress.down.sqlUT\x05\x00\x01wU\xd6jr\xf4	q\x0dR\x08qt\xf2qU((M\xca\xc9L\xd6KKMM\x89/K-*\xce\xcc\xcf\x8bO/I+\x8e\xcf\xcc-\xc8/*)Vp	\xf2\x0fPp\xf6\xf7	\xf5\xf5S\xf0tSp\x8d\xf0\x0c\x0e	VH\xceHM\xce.\xc8\xcf\xcc+\xb1\xe6\xa2\x82q\x05E\xf9\xe9E\xa9\xc5\xc5\xd6\\\x80\x01\x00PK\x07\x08\xb3\xfcnU_\x00\x00\x00\x9c\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xbd\x8cS]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00/\x00	\x00migrations/postgres/0001_import_progress.up.sqlUT\x05\x00\x01wU\xd6j\xac\xcd\xc1\x8a\x830\x14F\xe1\xbdO\xf1\xef\xdc\x0d\xb3\xd6Uf\x8c \xdc\x890&\xd0\x9d`\xbc\xdaPkB\x12K\x1f\xbf\xe0\xa2O\xd0\xed\xe1\xc0'H\xcb\x7fh\xf1C\x12\xe1\x986g\xbf\x16\xe6y|pL\xce\xef\xe3\x9a\x974\xba{\xf01'\x88\xa6\xc1oO\xe6O\xa1k\xa1z\x0dy\xe9\x06= D\xbfFN	\xb3?\xa6\x8d\x11\"[\x97\x9c\xdf\xd1\xc8V\x18\xd2\xf8>we\x88\xea\xe23\xa6\xbd\xb2\xbd\x05\xef\xf6\x8c\xcc\xcf\xfc\x96\xca\xb2\xaa\xce\xa0z\x0de\x88\xea\xe25\x00PK\x07\x08\xa3\x82\xb4\xfe\x90\x00\x00\x00\xe5\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xcc\x8cS]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x000\x00	\x00migrations/postgres/0002_unique_indexes.down.sqlUT\x05\x00\x01\x91U\xd6j\x00m\x00\x92\xff-- The unique indexes were part of the Postgres schema before migrations were added, and are kept.\nSELECT 1;\n\x03\x00PK\x07\x08\xfe\xc04\xf7t\x00\x00\x00m\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xcc\x8cS]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00.\x00	\x00migrations/postgres/0002_unique_indexes.up.sqlUT\x05\x00\x01\x91U\xd6jT\xcd\xb1\x8a\xc2@\x14\x85\xe1>Oq\xca]\xd8\x04\xb6Vl4\x8a\x90BI\xfa08'\xc9Es'\xce\x9d\x88\xbe\xbd \x16\xda\x7f\xfc\x7f\x9e\xa3\x19\x88Y\xe5:\x13\xa2\x9ew\x1a\x82\xe2\xa7#}{c4	\xda\x8a\xff\xc3r\xd7lk\xec7\xab_\xb8H\xb8K\xa4\xf3\x0fL.&\x84\x0ei \x0e\xc1R\x1fi\xb0\xd3\xc0\xd1\x15\xd9+/\x86Q\xfa\xe8\x92\x04\xc5\x99\x9c\xec\x1b;\xf5\xa8\x8f\x95$~\xb8\xf7\xd9 \nK\x9c\x8a\xac.\xabr\xdd\xe0\x7f\x91=\x07\x00PK\x07\x08\x9f\x9f\xa6\x9d\x8c\x00\x00\x00\xb5\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xbd\x8cS]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00.\x00	\x00migrations/postgres/0003_extra_fields.down.sqlUT\x05\x00\x01wU\xd6j\xa4\xd2]\n\xc20\x0c\xc0\xf1wO\xd1\x13x\x01\x9f\xa6N\x18L'\xdb\x04\xdfJ\xb6\xa5[\xa1v5M\xfd\xb8\xbdx\x00\x11\xb2\x03\xfcH\xf2'Y\xd9\xe6\xb5j\xb3m\x99\xab\x90:g\xfb\xf5\xc8&\xea\xc8s\x88j_Wg\xb5\xab\xca\xcb\xf1\xa4\x8a\x83\xca\xafE\xd36\n_L\xb0Y\xfd\xa2\x01xz\xc2[\xa8\x1d>\xd0	m\x9c \xa0\xd0\x1a\xc4A[of\xa9'\xbc'\xf4\xbd\x95.\xc0d\xa5\xc5a\\6\x18|4HB\xde\x83C?\xc0R\xae\x07`i:\x9a\x93\xd8~\x1f]\xb3\xbdI\xbd\x01BM\xc9-\xf2\xc0L\xb6\xfb\x7f\xc4g\x00PK\x07\x08\xc3\xdc\xcc\xe8\xa4\x00\x00\x00\xae\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xbd\x8cS]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00,\x00	\x00migrations/postgres/0003_extra_fields.up.sqlUT\x05\x00\x01wU\xd6j\xac\xd3\xcdM\xc50\x0c\xc0\xf1;Sx\x02\x16\xe0Th\x91*\x95V\xa2A\xe2\x16\xb9\xad\xd3\x06\x85$8\x0e\x1f\xdb\xa37\xc0;<\xb9\x0b\xfc\xf4\xb7-7\x83\xe9^\xc14\x8fC\x07\xb9.\xc1\xaf\xf7\xbb\xb8b\x8b\xa4\\\xa0i[x\x9a\x86\xb7\x97\x11\xfag\x18'\x03\xdd{?\x9b\x19\xe8W\x18\xe1\xa3\xa4\xb8<\xdc]32\xca\xf1\x83\x7fZ&\xd07\x05-R\x0e\xcc\xa4E\x1c\xd1f}tI\x0d1}U\x8a\xabW'	{\xf5\x9dp?)\x05cq\xc4Zg\xc5@q\xc3\xd3\x1c\xbb\xa1\xa8\xf7\xcc\xa9\xea\x91\xcbSY\xf1\x9fj\xc8!\x93\xe5\x1a\xce\x81P\x84\xfdr\xc3|\xff\x03\x00PK\x07\x08\xa3 \xb0Z\xaf\x00\x00\x005\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x93\x8dS]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x000\x00	\x00migrations/postgres/0004_fetch_interval.down.sqlUT\x05\x00\x01\x06W\xd6j\x00E\x00\xba\xffALTER TABLE public.feed_states DROP COLUMN IF EXISTS fetch_interval;\n\x03\x00PK\x07\x08\xf9t\x9dVL\x00\x00\x00E\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x93\x8dS]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00.\x00	\x00migrations/postgres/0004_fetch_interval.up.sqlUT\x05\x00\x01\x06W\xd6j\x00P\x00\xaf\xffALTER TABLE public.feed_states ADD COLUMN IF NOT EXISTS fetch_interval integer;\n\x03\x00PK\x07\x08H\xb9A\xf5W\x00\x00\x00P\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xb0\x8dS]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00&\x00	\x00migrations/postgres/0005_jobs.down.sqlUT\x05\x00\x01<W\xd6j\x00'\x00\xd8\xffDROP TABLE IF EXISTS public.dmfr_jobs;\n\x03\x00PK\x07\x08\xfd@h\x0e.\x00\x00\x00'\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xd4\x8dS]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00$\x00	\x00migrations/postgres/0005_jobs.up.sqlUT\x05\x00\x01\x81W\xd6j\x94\x93O\x8f\xda0\x10\xc5\xef|\x8aw\x83Hl\xb5g8\xa5`\xda\xa84\xb4\x01\xd4\xdd\x93\xe5$\x03\x98\x82\x9d\xda\x93\xed\xd2O_\xe5\x0f,\x1bU\xcb\xf6\x14\xc9~o2o\xfc\x9bI\"\xc2\x95\xc0*\xfc8\x17\x88f\x88\x17+\x88\x87h\xb9Z\xa2(\xd3\x83\xce>\xe4\xc7\x8d\x93{\x9bz\x0cz\x00\xa0s\xa4z\xeb\xc9iu\xc0\xb7$\xfa\x1a&\x8f\xf8\"\x1e\x87\xf5\xed\xde\xa6\x92O\x05!\xdb)\xa72&\x87'\xe5N\xdal\xeb\xd2\xf1z>o\x84\x1b\xa2\\6\xb5\xb4a$b&\x12\x11O\xc4\xe5\xbfY\xe9\x1c\x19\x96\x95\xd0\x0ft\x1e\\\xf9\x9e\xc8ym\xcd\x9b\xfek\xe1\x95\xdf\xb3\xe2\xd2\xff\xa3\xbd\xa9\x98\x85\xeb\xf9\n\xfd\x82L\xae\xcd\xb6?\x1a\xdd\xca\xa0\x98\xe9X\xb0\x876L[r\x97\x1a\xf7\x1d\xa1+\x8dT\x0c\xd6G\xf2\xac\x8e\x05~k\xde\xd9\xb29\xc1\x1fk\xa8c8\xd8\xec'\xe57<\xaf\xa4\xe9\xe9\xadL\xef\x08sP\x9e%9g\x1d\x98\x9e\xf9e\x1e\xfd\xd1\xa8>x\xdda\xe6H\xf1\xcd\x16;\xa6\xb2\xc8\xff\xc7\xd4\x0b\xc6\xbd\x16\xd0(\x9e\x8a\x87\x0e\xa0\xda\xe4\xf4,/|Jkd\xf3\xbaR\x99\\\xb63_\xc4g\xa0^@^/\xa3\xf8\x13RvD\x184\x96a\xfbF\xc1\xb8ww\x87\x90q\xb4\x9eQ%hq\x80u\x95\xc4Tc\xdd\xdb\x14v\x03R\xd9\x0e5\xec\x1b\xeb\xa0j\xa4+Y\xfdm\xc9\x1b\x83w\x04\xcb;r\x88\xa6\xd0\xbe	\xd6\xa6Z\xc7\xd1\xf7\xf5\xfb\xc2\x95F\xff*\xe9v\x9c\xf3\x02\x0e1Y\x84s\xb1\x9c\x88A\xbbjC\xdc\x07\xdd\xd3\xb6\xcb\xf66\xc0\x8f\xcf\"\x11\xe7\x1d\x89b\x0c.\xdb0D\xdf\x95\xc6h\xb3\xed\x07\xe3\xde\xdf\x01\x00PK\x07\x08\xbe\x96`@\xb8\x01\x00\x007\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xcb\x8cS]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00/\x00	\x00migrations/sqlite/0001_import_progress.down.sqlUT\x05\x00\x01\x8eU\xd6j\xec\x94\xc1\x8e\xa30\x0c\x86\xef<\x85\x95\xd3\x8e\x947\xe8\x89\xedd\xa4J\x94\x8ehz\x8e(\xb8(*$\x91cf\xb6o\xbf*\xed\xee\x00\xd52\xa3\xdd\xeb^\xcd\xef\xd8\xfe?\xe3u\xa1R\xad@\xa7\xdf3\x05\xe2\x84X\x9b7\xa4h\xbd3\x0d\x9f\xa2\xb1]\xf0\xc4\xd1\xd4\xfe\xdd	\xf8\x96\x00\x08[\x0b\xb0\x8e\xb1A\x82@\xb6+\xe9\x02g\xbc@\xd9\xb3\xb7\xae\"\xec\xd0\xb1L`\xf6\xdc8-\xdfi\xc8\x0fY6\xa8*\xc2\x92\xb16%\x0b\xa8KF\xb6\x1d\xc2\xb3zI\x0f\x99\x86\xf5\xa1(T\xae\x8d\xdel\xd5^\xa7\xdb\xd7in\x1f\xea\xbf\xce\x8d}Ua\x8c\x02\x8e\xde\xb7\xc3k\xb7aM\xeb\x1b\x01\xc7\xd6\x1foAg\x02\xf9\x86\xa6R\xfcQa\xe0\xabM3\xf5\xfd	|\xc3\xf6c^\xe7\x19\\\xdf\xde\xab8F\n\xbe\x1d\x1a\x8f\xec\x83\xb9Nl*\xdf;\xfeCJ<\xdb`\xd0\xb1\xe5\x8bA\"O\xbf\xd4\xbf\xeb\x8e\x15\x84'$t\x15.\xaa\xba\x92\xceX/JN\xb6e|\xac\xd5\xa0C\x1a\xba\x9f\x7fy/\xc9Y\xd7<\xc4\xef\x8d\x8d\xc2\xc9\xd3*\xd9\xe4{Uh\xd8\xe4z\xf7\x85\xd5\xbb\xee\x9d\x9c\xe9n\xa1\xd1\x02\xc9\xc9J\xc8\x0f\xc8rBWN\xb1\xca9\xcf\x91z\x00)?\xa1&\xa7\x00\xc6\x88\xe42\x1b\xb9\x00E.\xd0\x90\x8f\x18\xe4\xdc\x7f93\xfe	\xf6*Sk\x0d\xff\xad\xfcW+\xe1\xa5\xd8m\x17\xee\xa5X%\xcf\xc5\xee\xf5\xd3\xbb*VI\x9aiU|\xf5\x00\x17*O\xb7\n\x16\x7f\x18\xb1J~\x0e\x00PK\x07\x08,M3v\x8a\x01\x00\x00\xd6\x05\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xbd\x8cS]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00-\x00	\x00migrations/sqlite/0001_import_progress.up.sqlUT\x05\x00\x01wU\xd6j\x9c\xcc1\x0e\xc2 \x14\x06\xe0\xbd\xa7\xf8\xc3\xd2\xd5\xbd\x13\nNOH\x0c\xcc\xc4\xd4\xd7J\xd4B\xe0\xc5x|7\x0f\xd0\x0b|\x9a\x82\xbd\"\xe8#Y\xa8\x85\xf9\x9e>\xdcz.[Ze\xe9)\xbfki\xd2\x15\xb418y\x8a\x17\x07U[Y\x1b\xf7\xae\xd0\xf8\xf6\x82\xb1g\x1d)\xe0\x00\xe7\x03\\$\x9a\x86=\xec\xfc\xe0\xf9YK\xdeDA\xf8+\x7fx\x1c\xe1|\x80\x8bD\xd3\xf0\x1b\x00PK\x07\x08,\xaf.\x8at\x00\x00\x00\xb1\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xbd\x8cS]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00.\x00	\x00migrations/sqlite/0002_unique_indexes.down.sqlUT\x05\x00\x01wU\xd6j\x8c\xd0=\x0e\xc20\x0c\x86\xe1\x9dS\xf8\x1e\xac-R\x16@\x94\xa1\x9beZ\xb7\xb5\x14\xd2\xe08\xfc\xdc\x1e1\xb2T\xde\x1f\xbf\x96\xbe\xe6r:C86m\x0f\xe1\x00m\x1f\xbak\x072\xbeq\xb6\xa9`&[^\xf4)X\x93<*\xefw\xdb<\xf2\x93\xa3\x17\x17[\xb3\xdb.\x94\xd9\x8bM\xc5\x1d\xa6\x99\xd3 \xee\xf4@\x91\xd3H\xea\xf5\xbaVs\xc7\x7f\x83\xa0\xc9\xdd}0\x912\x92\x99\xca\xed\xef\xcdw\x00PK\x07\x08\xa1\x80\xa0sz\x00\x00\x00\xd4\x01\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xbd\x8cS]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00,\x00	\x00migrations/sqlite/0002_unique_indexes.up.sqlUT\x05\x00\x01wU\xd6j\x94\xd3\xb1N\xc30\x10\x06\xe0\xbdOq\xeaD%\xde\xa0\x13\x02#eI\x05M\xa5n\xd6\x11_\xda\x93\x82\x93\xdaN\x80\xb7Gq\x03\x08r\x0c\xb7\xfe\xff\xf0\xe5\x8f\xed\xfbgsW\x198\x94\xc5\xd3\xc1@Q>\x98#\x14\x8fP\xee*0\xc7b_\xed\x81\xdd\xbb=\xa5&\xda\x1e\xd3\xf9\x0d?\xa2\x1d<_\x06\x82]	\xeb_\xc5\xfa\xa6!rv\xa4\x10\xb9\xf3\x96\xdd-\xcc\x95e\xb7\xd9\xae\x14VK#\xb5K\xe9\x1a\x0bN.\xd4JL]\xbfDr*\x18S\xae'\xce\xd8\x93`\xe4XB\xa6B\xad\xa4\xc0\xc2\x90\x9c\n\xc6\x94\xab	<\x91\xafY\x98\xf2U\x08P\xae\xf4\x87_cK\xdeaXZ\xdf\x8d\x80E\n#\xd7\xfa\x7f\x17\xba!	\xb3\xae\xb1\xe0\xe4B\xad\xe4\xcb\x93\xf8U\x90~\xaa\xff\xcfj\xbe}\x91.\x03\xf9\x9at\x8f\xa9\xc1@\x16S\n\xfc\"N\xfd\xd3\x0b_\xd1` \xcbn\xb3]}\x0e\x00PK\x07\x08\x95\xb6\x1fJ\xf1\x00\x00\x000\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xcb\x8cS]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00,\x00	\x00migrations/sqlite/0003_extra_fields.down.sqlUT\x05\x00\x01\x8eU\xd6j\xe4\\Io\xe3:\x12\xbe\xe7W\x08>9\x00O\x03\xbcSN\xe9n? @:y\x93v\x80\xb9	\x8cD\xdbD\xcb\x92\x1eEe\x99_? \xc5\xad\xb8\x88J\xdc@\xbfxn\xb1\xaa\xf8\xa9T\xcbW\\\xa4|}\xd8\\o7\xc5\xf6\xfa\xcb\xed\xa6X\xed\xf9n(\x07\xde\xf5CYw/\xed\xaaX_\x14\xc5\x8a\xd6\xab\x82\xb6\x9c\xec	+zF\x8f\x98\xbd\x15?\xc9[\x81G\xde\xd1\xb6b\xe4HZ\x8e\x84\xe6\x8e\x90\xba|&l\xa0][\xba\xc3\xee\xee\xb7\xc5\xdd\xe3\xed\xad\xd4\xaa\x18\xc1\x9c\xd4%\xe6\xab\xa2\xc6\x9cpz$\xc5\xb7\xcd\x9f\xd7\x8f\xb7\xdb\xe2\xeb\xe3\xc3\xc3\xe6n[no\xbeo~l\xaf\xbf\xff\x05\xc7\x8e}\xfd\xe1\xb1\xe2\xc1\xa4U\xcf\x98U\x07\xcc\xd6\xff\xfa\xe3\x8fK\x08/UZ|$Y\xa5\xaa\xab\xf3J5\x19\xaa9\xa5\xffv-Yb\xd1\xc8\x9a9\x98\xa6\xab0\x17.\xe7o=I8\xbd\xc7\x8c\xb4\xbc\x1c\xb8\xd44J\xf6\x1e\"\n\xc2\x9e\xb9\x1b\xbd\x1c\x08i\xaa\x03\xa6\xac|\xea0\xabi\xbbO\xdc\xae!\xcf\xa4qS@^\xdd\x93\xeeH8{[\x15_n\xef\xbf\x18\xec\x8b\xcb\xab\x8b\x9b\xbb\x1f\x9b\x87mqs\xb7\xbd\x8f\xe5\xa1HB\x14&\x18\x02\xd9\x84@~ \xe5\xbcI\xcdFV_\x97\x11\xd4?d\xa4\x90\x8d\x88\xbe.<\x8f|\x0f\xa3\xc0\x9d\xc8w\"\x8a;\x0b9\x9eA\x8e?.\x8b\x1f\x9b\xdb\xcd\xd7mq\xe6\xcfY\xfc\xf9p\xff\xdd\x8d\xef\xea\xea\xe2\xdb\xc3\xfd_!\x01\xad\xae.\xaeo\xb7\x9b\x87$5=l\xee\xae\xbfo\n\x98.\xab\xab\x0b\xc5h7w\xdf6\xff)h\xfdZ:#U>\x14\xf7w`\xd0Z]\xbf\x9c\x1f\x0dc\x1e\x80@q\x06\xcbK\xe4\x00\xcc\x93[\xb4\xc7\xbb\x9b\x7f?&@\xc7\x96\xfe=\x92\x1c\x16*\xec\xe3jT\xd7\xc9=\xe6\x87\x17\xfcv\x96-@=[\x86sw\xac;\xea\\I\xf0\x1b\xef2\n\xfaNGQ}q\x0c:\x94O\xb4\xa6\x8cT\x82Cp\x93PkH\xbb\xe7\x87U\xc1\x08n \x00gXx\x1a7\x92w\x12\xc3\x07.*\xb3\xea\xc6\x96'4\x8e\xf8\xb5\x1c\x9a\xae'\xb1{\x1ci[\xbe\xd0:n\xc0@\xf7m\xdf\x0d\x92\x96\x86\xb9\xc6\xc1\x880\x94\x94K\x06\xc4\xbb\x81\x9f\x95\x1f#J\x1d\x155\xd8\x8d3\x02AE^\x04Q$\\\x92\xccelP\x10\x0b\x04\x1d\x8f\\/#\xd7\xab\xc8\xf7\"Jy\xeb\xc4\x1e\xf1\x99\x1f\xddm\x1b\xca\xb6h\xe7pda\xf3\xf02\xc8\xef\x1f\xce\xd8Y\xaa50>\xdb\x1a\x00\x9f\xbcQ\xa1Di\xce\x95\xd3\xa5\xb3d\\;\x11L\xb3\x83\xd2ik\xf2\x1ac\x99I<3+\x8f3\x06\xf4\xe9\xc7\xf8\xc2Xo'n\xd2J\xf3SZube.\xbb\x89[\x03\xf2r\xb4\x02\x8c$\xcc\x7f\xe0\x0f?\xfb\xcd\xb8\xd9\xdcW\x10~\xe6\xab\xc1a\xde\xeb'Kd\xfdp\xc0=9\xcf\xa5\xa6x\xb2L\xd6\xefIK\x98\xb8\xc3\xaax\xea:/\xe7\xdf\xb7R\x02~\xfcX\xa6\x1b\x8b\x91k\xd9\xaf\\\xa3do\xe0f\xb8\xd4\x8ef\xb8\x91\x84\x19\x0e\xfc\xe0g\xb8\x19\xa72\xdcKm5V[\xe9L\xa2\xc5\x95a\xb5\xd6\x92\xf4\xc4^(\xcc\xcd\xec\x15\x90\xa7\x90\x9d\xda\xcbaA\xcd%\xd0P\xe1\xd8\x19\xab9yw\xda\xee:Pw\xf2j?>5t8\x106\xc7\xb5(\xa2\x9e\xd9\x9e\x90\xe0\x0dn\xf7Y\xa5\x81c\xc6K\x915vg\xc8JI['e\xda\x9f9\xcb\xcf`/+\xce\x01a\\\xa3AEA\xac\xd5\x0e\x87\x0d\x12\n\x83\x81\xfc\x08 \xcf?zc\xe5\xfd\xd4c{\xe7\xa70\xd7\xe5(\xeb\xf2\x18O\x01i\xc8UA\x1d\xfa|\x05\xc6\xabB\xf6\xa8\xc1\xc1\x00\xd1pw8\x1c\x98\x08\xf3D\x19\x82\x91\xbfG\xd2V\x14\xb6f\xce\xa8Y\xee\xc2\xd2\x9d<o\x96\xa0P(\x8a6!:\x10\\\x8bU\xc6@\xaa!\xb14%\xaf\xb8\xe2r|J\xe3|\x0b:\x0c\x83\x89\x01\x02NG\x8e\x97\x91\xe7V\x04}xb\x89\xfe\x9e\xfb\x83\x9a\xb3^\x89\x16\x1d\x10G\xaa.\xc8\xed\xa0\xec\x00\x82*\x0f\xbf\xee\x1c\x14\xe5\x12\xa7\xe0\x1c\x80\xb5\x92&\xe7\x0c.\x92\xe7\x92\x04\xa2\xa7\x95\xe8\xf2\xe2\xbe\xa0zY7r\x92(_\xc2\x9ei\x95\x10\xaa\x07\x98\x9b\x0cH\x15Q\xcbb\xcd\x9eU\x1c\x0e\x1d\xe3\xd96m\xf6\x1a\xd2\x85\xfa\xd4t\xd5\xcf\xcc\x14[\xcf\x85$\x84\x7f\x82\x81\xab\x8a\x0c\x03}jR;gO\xf4'\x19J\xdc4\xdd\x0bIY!7Mz\xcc9a3\xb6\x9e\xc1	Z|\xd6\x01\xf2\xcc&\x19\x02Y\x85T\xe8\x9d?M\xba\xa00-\x90\x17~\xe4\xc4\x1a91E\xa9h\"?t(\x8c\xd3\x89Dx\xeeO\xeaR\xae\xb0:J\xb6Z\x10\xd2\xac\x9b\x16>\xc1\xeaQqj\x95\xd2R\xbb\xd7r\xa0\xbc\xbeZkA\x92P\xa5^i\x93/@\xb0\xa2\x0c\x86\nd\x00\xa0\xae\xe7,P\xcc\x13\xde_	r\xe3!\xaf\x840P\x9eAK\xb6\x16\xe5\xd6\xb0\xa9\xcc\x9e6\xc9Q\xc1\x8a4\x8e\x85\n\xeb\xb0X\xa7\xc2\xfbp\xaa)\xaf\xe5\x0ei\x94R\xae\x93(\xb5\xcc\x12Ui\x99\xe3\xdb<`n=\xab\x10\xfb\xc32\xb8\x1dfd\xa1\x91\xe4\x88\xe9\xec\xc3\x9c\xd6m\xfe\xa1\x9d\xc6\xcf\x13\xed\xe0\x89\xdb\xd4\x0f\xcd\xaa\xea\xa7Z+\xfa\xc1\xb5\nz\x01\xa94\xa6`\xa10(\xf6\xd2\xe4\xfc\x13\xbb\xc7\xa73\xddm\x07:\x10\xb1\x8e\xe0\xc8\xc2\xa6\xe0E\xd0\xef\x0b\xceXE\x13\x1e\xeb\x98\xf1\xc6}\x96\x15\xcd\xe0\xb5\x11&)\xd1\xe0$Y\xd1\xa2\xbd\x93\x18\x0d\xb4\xcf\x8dIDT\xb8\x06kt\xd7\xa7\x9c\xe1v\xd8\x11\x06\x18\x12\x1c\xdfEf\xed\xf0t\x1a\x96\xb4\x06\x9c{[H\x9c\xfaZ=\xbd|7\xef\xf1\x9c\xf1|\xd6sv\xe6\x8c\x18\xfaR\x1d\xecB\xc7\x9d\xc8\x15\xbf\xe7\xfep\xfe\xa7|\x12\x9f\x03Zal\x1e\x08s7\x9c\x0b\xda\xd1*\xf5\xbd\xa27\xf8\xe6/\x99\xb6\xee|HC\xac\x81\xc6\xcc\x8cH#z\x0e\x89bz:KP\x9d\x80\xc5!\x1d\x85\x05x\xbc\x9bE\xb3\xe2\xc4V@\x85\x1b\xd2\xd6\xd8\xa6\xb4\xa8o;\x0b\x9e\x9bH\x1c\xbb\xb6\xc6o	\x8a\xe0#\x19\xd2\xd2\x17R\xb7sr~\x18\xd9\x8cx\xc7hZ8`>\xb2\x19\xf18cu\xec\x84\x01Z\x16\x9e2@\xf9\xec\xb1\xdd\xd9\x12c\x90E\xde\xb2W\xa5\n\xb2y\x81\xdc$@N\xc4\x05\x15N\xe1EN,\xc5\xdf\xa3\xc6p\xa2\xa467\xf56>89|?\xafY^\xfd\x94\xe6\xbb\xb4l\"\x12\xa3eW\x18\xd2\xb2O	>-\xbb\xa3\xe3\xb4l\x11\x8c\x93,;\xd9\xe1k'\x05\x92Tg\xb1\xac\xdf\xa2`V\xbc\x00\xcbsk\x14\xd0\xd3\xc9\xbddi\x0d\xf5\xa7wF\x12\x99\xdf\xd9D[`\xb5&\x9f\xa8\xb9Z\xb8\x00\xc7\xde4\x8a\xf4.\x9b\xa6\xda\x88\xe2\xe8\xb2_`\x91\xa2\x858\x8c\xe6\x8c%8\xaaq$\x80t[Y\x804Uq\x1cG\x11\xd4\x02\x14M`q\x1cCoK\x90\xc6\xb4\x9f\x155fZ\xbc\xcc\x8ed\x9f\x0f\x16\x02s]\x8e\xbcV\xa4\xcf}Sp\xf6\xdd\x0e:\xd4\xeb\x19\x86\xdf\xa1\xab>D\xec\xa9\xbe\xf4+\xef\x11k\x1e\xd3\x03\xceu\x10\xa3\x91n#\xc0K\xa9^bp\xe6\x1b\x8a\xc6\x8ar\xa0\xc2XOn\xc9\x96\x94\xc2\x82\xbeK\xa3B\xbd\xa5\xe8^\x04\xd2\xf0\x9e\xe2R|\x9bsih\xab\x93 \x08\xb9mn\xf2X\xd4\xb7\xdeH\x9f\x9b\xfe\x9b\x9d\x89\x08wL\xe3\x97\x1d\xe6M\xbaM\xd7\xee\x17\xaa\xe6>\x9b\x9a\xb4f\x88iR\xc8l\xa7NJU\xd7t,o\x12'\xaf|\xa9\xee \x8e\xb2:V\x13\x960\xefly\x13\xe6\x99\xf20\xd8\xa1\x9d~\x04\xd9\x83\xc2,1\x97\xf4\xa7YN\xd4\xcd/\xb5\xc3\xe9\x86\x12EB\x86\"\xa19\x91\xa7\xcf\xef\xd9\xdc\xfe \xef\x12\xed\x0bF\x12\xf6\x03\x10}\xbf\x0f\x98qq\xfeWc\xb5W-\xd7\xa9q\xf9\x93?\x85`H+\x840\xa2\x1c\x86\xcd\xb4\x94\x1d\xb3\x1dBY\x92\xec\x0c\xfa\x91\xde\xf9\x8d\x97\x82\xf5\xd7\x1e	4T8\x1e\x8b\xf5\x04\xf3\xbd\xa2\xa9W\xf0\xbeG\xc0\xf8\x981\xfa\xec~j\x04\xa9\xaf&=f|d$\xa5\xa0\xf70\xc3\xa1R2L\xef\xb8\xa4\xa6\x9aRg\xc9K&=\xad~\x8e\xfd\\s\xa8Y\xd7\x97\xddn7\xa73\x9d\xd2\xd6t\xe0b'\xee\x994\xa4\x8e}\x99 \x1e\xb5\xefh\xf2\xcb*\xf1(\xac\xef\x1aA\x1f)\x95\xcf\xffqu\xfc\xd5\x900\xbf\xdc\xb7@@6\xa1 }\xf4{\x0c\xeaE\x08\x90 \xc8\xcf\x06\x04\xa3\x8e\xfc\x10\xa3x<\x91\x1b?\xe4\x05\xeb\xc4\xeep\xeeO\xea\xf6\n\x1b\xe8X\xbf\x00\xd2\xb0g\x04,\xe4\xf7\x0d0^\xd1\x98%Fg\xb8r\xb9elgd\xf6\xa5\x0d\x07'\xd8lwq\x94p	\x8eW\xaeq\xbb<\xa5%\x9f\xf9*x\xbf\x0d\xcc\xa0\x9aW0P\x01\xa86\xd1\x18\xe4\xd11\x1b\x1b[\xb8\x82\x1b\xe4\xd5(}\xeb>c_\xb2\xeb\x18\xdd\xd36\xb3\xba\xa8\xc9\xc0i\x8b\xcdK~i\xcd\xaak9\xa6\xed\x90\x01<[&\x0d\x03b\xa2a&\x7f\xd3\xdf\xd6\xf1(p0\x82\x8e<\x91\xe0~\x93\x01.\xefX\xb7\xc4x\x07HC\xde	\x92\xdc\xe7\x1d0>N\x1a\x0e\x86r\x87-rg\xf8Z	\x93\xa4\xe1\xe2@>H\xe0\x05\xa4\xa1\x81\x03\x07`\xce\x19}\x1ay\xa2\x96\xd3\xc5\xd43ZE\xbf\x07\xafF\xc6\xe4\x1b'\xb2\xe1\xcc!\xe07Qp\xe5\x91\xf0C\x97\xaa1}d\xeb}<\x1e\xd9~Po\x10\xab\x13\xdezd\xf0\xff\x98\xfc?QA\x18T\xb7\x1c\xa7\xc8!?R(\x88\x88\xf3\xfa\xc2\x10\xacbCO\xff:\xc2\xf8GY\x180\x8a\xf5n\x92V\x80J\x82[\x82\xca\x8b\x12\x0c@RE\xec\xf5|\xef\x8e	\xaaq\x80\x96\xf1\x8d\x8b\x08\xdd5\x87\x0c5s\xff\x95\xc47\xdd\x9f\xb3x\xf2\x80\xd9P\xb1\xc3\x8c\x94\xb4\xbe\xbc\xba\xf8\xdf\x00PK\x07\x08f\x81X}\xc2	\x00\x00\xa9J\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xbd\x8cS]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00*\x00	\x00migrations/sqlite/0003_extra_fields.up.sqlUT\x05\x00\x01wU\xd6j\x9c\xd2?\xae\xc20\x0c\xc7\xf1\xfd\x9d\xc2\xca5\xdeTh\xb7\x02\x12*s\xe4\xb6N\x1b)$\xc5q\xf8s{\xc4\xcc\x80\x9c\x03|\xf4\xb3\xber\xd3\x0f\xdd\x19\x86f\xd7w`\x16q\xd9fI[6\xd0\xb4-\xecO\xfd\xe5p\x04COa40\x864\xfe\xff}\x8b\x0de}\xe0K\x87\x02\xdd)\xe8H^q#\x1dqD\xb3\xf5\xd1%%c\xba\x15\x8a\x93W\xce	{e;\\\xaaf0fG\xacS\x13\x06\x8a3V*;\xa3(kp*Z\xf2y>+\xfe\xaad\x0e\x99,\x97P\xc3P\x84\xfd\xf8\xe3\xd2\xf7\x00PK\x07\x08\xe7a\xe1\\\x9a\x00\x00\x00'\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x97\x8dS]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00.\x00	\x00migrations/sqlite/0004_fetch_interval.down.sqlUT\x05\x00\x01\x0eW\xd6j\xdcT=\x8b\xe30\x10\xed\xfd+\x06U\x1bPu\xb0\x95+'\xab\x85\x80?\x16E\xa9\x8d\xd6\x9ed\xc5\xd9\x92\x19\xc9{\xf8\xdf\x1f9\x9bC1N\x9a\xeb\xae3\xbc7\xcf3\xef\xcd\xe8 E\xa6\x04\xa8l\x9f\x0b`\x17\xc4\xb6\xf6A\x07\xf4u\xeb~Y\x06/	\x00\x003-\x03c\x03^\x91` \xd3k\x9a\xe0'N\xa0\xc7\xe0\x8cm\x08{\xb4\x81\xcf\xdc?\"qAY)(\xcfy\x1e\xe3\xdfH\xde8\x1b\xf3b\x98Pw\xc1\xf4X\xa3\xd5\x9f\x1d\xb6\x0c>\x9d\xeb\xc0\xba\x00v\xec\xba\x98:\x90qd\xc2\xf4\xf7\x7f\x0b\xd8i\x1f\xea\x0b\x86\xe6\x0b\xdbZ\x07\x06\xad\x0ex\xd3\x8cq?6\x0dz\x7f\x19\xbb\x99\xfa\x90h\xfa\xc1Qx\xa24\x97#\x91#\x06\xdf\x9a\x9a/M/?^_w\xeb\xf1\x83\xbez\x06\xfb\xbc\xda/\x8d4\x84z\xad\x0co\xe2=;\xe7\n\x0eg)E\xa9ju,\xc4Ie\xc5\xc7Zn\x1c\xda\x7f\xa8\xbe\xa2\xeb1\xd047\x94\xec\xd2\xe4X\x9e\x84Tp,U\xb5\xb9\x0f\xb7U\xe0\x0b\x12}Fy\xf2G\x19\xf2ub<\xf6nv\x80?	\x86od\xc17\xec\xe7\x8b\xc9\xfc\xce[~\xe7\x15\x8ff\xdf\xc1I\xe4\xe2\xa0\xe0?\x9c\x0d\xdeeU,M\xcf\x87\xcd\xd2\xe4MV\x1f\x1b'\xcf\xd2$\xcb\x95\x90\x8f_\x03)\xca\xac\x10\xb0Z\x0d\x96&\xbf\x07\x00PK\x07\x08\xc5\xf49\xd6_\x01\x00\x00G\x04\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\x93\x8dS]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00,\x00	\x00migrations/sqlite/0004_fetch_interval.up.sqlUT\x05\x00\x01\x06W\xd6j\x00?\x00\xc0\xffALTER TABLE \"feed_states\" ADD COLUMN \"fetch_interval\" integer;\n\x03\x00PK\x07\x08\xda{wDF\x00\x00\x00?\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xb0\x8dS]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00$\x00	\x00migrations/sqlite/0005_jobs.down.sqlUT\x05\x00\x01<W\xd6j\x00\"\x00\xdd\xffDROP TABLE IF EXISTS \"dmfr_jobs\";\n\x03\x00PK\x07\x08\x05]\xfd\xb1)\x00\x00\x00\"\x00\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xd4\x8dS]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\"\x00	\x00migrations/sqlite/0005_jobs.up.sqlUT\x05\x00\x01\x81W\xd6j\x9c\x92A\x8b\xe20\x18\x86\xef\xfe\x8a\x97^l\xc1\x81aaN\x9e\xdc1\xb2\x05\xa7\xb3[+;\xb7\x10\xdbO\x8dc\x93n\xf2u\x18\xff\xfdR[\x1d[\x10\x96=\x15\xfa=o\xf2&y\x9eS1\xcb\x04\xb2\xd9\xf7\xa5@\xbc@\xf2\x9aA\xbc\xc5\xabl\x85\xa0(\xb7N\x1e\xec\xc6\x07\x08G@\xa0\x8b\x00\xda0\xed\xc8\xa1r\xbaT\xee\x84w:A\xd5l\xb5\xc9\x1d\x95dx\xd2\x90\x07\xbb\x91|\xaa(\xc0\x87r\xf9^\xb9\xf0\xdb\xd3St^<Y/\x97gfKT\xc8\xdb%S\xb1\x10\xa9H\x9e\xc5\nA^;G\x86e\x03\xf9 l\xb6\x8e\xbeR\x1f\xe4\xbc\xb6\xe6n\xfa\x16\xea\xa5=+\xae\xfd\xa0\xd5\\,f\xebe\x86qE\xa6\xd0f7\xee\xf7T\xccTV\xec\xbf\x8a^\x02\x8f}\xd0\xd5F*\x0eP(&\xd6%\xf5\xa7G\x9b\xbfS\xd1\x03n\xffoN\xf7Z\x0d\xea\x1c\x95gI\xceY\x17\x80\xe9\x93\xef\x82\xb9#\xc5\x83\x1d\xaf\xf0\xf3:ME\x92\xc9,~\x11\xabl\xf6\xf2\xb3\x9f\xad\xab\xe2\x7f\xb2\xa3h:\xea\x84\x8a\x93\xb9x\x1b\x08\xa5\x8bOyuJ\xb6o!\xdb[\xc3kr\xeb[\xd8\x0e'h\xa7\xd1t\xf4\xf0\x80\x19\xa3\xb4\x9ea\x0d\xa1{*X\xd7 F\x9b\x1d\x0ev\x03\xbb\x05\xa9|\x8f\xc6=l\xad\x83B\xe3B\x83\x9d\xbf\x9d\x13S\xf0\x9e`yO\x0e\xf1\x1c\xda\x9fo\xedR}\x9d\xc4\xbf\xd6\xffr\x82\xda\xe8?5\x0d\xab_\xdc\x9f \xb7\xeaH>\xa7\xb0S}\x82\xc7h\xf8\xb7k\xd4M#\xfc\xfe!R\x81\xf6\xf8\x88\x13\x84W+'\x18\xbb\xda\x18mv\xe3h:\xfa;\x00PK\x07\x08.\xc1?\xc7\xa1\x01\x00\x00\xb8\x03\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xd4\x8dS]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x0e\x00	\x00postgres.pgsqlUT\x05\x00\x01\x81W\xd6j\xd4]_o\xe3\xb6\xb2\x7f\xef\xa7\xd0\xdb&@p\xe0\xc4\xff\xf7\xe0>\xe4d\xdd6\xb8Y\xe7\x9c${\xdbE\xb1\x10h\x89\xb6\xd9\x95%\x95\xa2\x93M/\xeew\xbf %Q\"\xc5\xbfJ\xbc\xde\xbc\x14]k8\xbf\x99\xe1\xccp8\xa4\x94\xab\xbb\xc5\xe5\xc3\"X\xfc\xfe\xb0X\xde_\xdf.\x83<+\xc8\x06\x15\xff\xfc\xa9\xf3d[\x90\x0cC\xfe\xe0\xe1\xf2_7\x8b \xdf\xaf\x12\x14\xfdc\x0da\x1c>B\\\xa0,-\x82\x93\x9f\x82 \x08P\x1c\xac\xd0\x06\xa5$X\xde>\x04\xcbO77g\xecwFk|H\x9es\x18D[\x80AD \x0e\x1e\x01~F\xe9&\xf8\xb0\xf8\xf9\xf2\xd3\xcdC\xf0nC\xd6\xc5\xbb\xf7\xef\xbb\x14\x12\x10J\x8cl\x1cX@\x80\x13\x04\x0b\x12F \x81i\x0cp\x18\x03\x02\x03\xf6\x1f\x11,\x01\xc4\x89\xae\xd8\x82s\x85P\"\xd1.\x1ewiJ\x03\x11\xb0)j\xab\x973R[\x95D[\x18\x87\x80\x04\x04\xed`A\xc0.\x0f\x9e\x10\xd9f\xfb\xf2\x97\xe0\xef,\x95\x85A\xbb<\xc3\xc4:\xaaD\x880\x04vZ>Ki\xf6tr*\xe1\xed\xf3\xf8\xa5,J\x91\xc3\x04>\xc2$@)\x81\x1b\x88\xf9\x80\x81\x8c\x87\x13\x85\xad}\x1c\x80\xfaP\x88\xc1\x93n:\xe8t\x9a\x9e\xef\xe2\xb1\xe91cO}\xfe\x11$(\x06$\xc3:\xa0\x18&\xd0n\xba\xc6\xc7\xc2\x18)x\xfdt\xaa\x8e_\x1aQ\xdc\xc7m\x01\\@\xfc\x88\"Hc\xb8\x03 \x91\xee\xb24\x06\xcf|\x9e\xc4\x87d\x0f\x0b\xfd\xd3'\x18\xa7\xa6\xe7d\xbb\xc7\x86\xc7k\x8c\xf4\x0f\x0b@\xf6\xd8\xf0xo\x90\xba \x00\x13m\x1a\x80i\xac}\xf6c\x04P;Uk\xd3\xf0\x06\xa6\x10Sa\x83U\x96%\x10\xa4\x12\x13\xf8\x8d`\x10\xfcYd\xe9J\xe7R\xd1\x1ec\x98\x12\xe6\xdd6\x8f\xcaRX\x90,w\xf1(eHW\xd3\x96\xc3\xc8\x14\xee\x8e\xcb\x86.\xc3&\xa0 \xa1[\x9a\xad\x97\x84\x82\x84^)\xb6\x9a\x96\xda\xe3\x7f\xa4\xbc[;o\x86\xc3:\x8b\xa34\x8c\xb6 \xdd\xc0\x02\x12:u\x82\xd4\x1b\x98\xed \xc1\xcf<\xc3\xc0l\x83A\xbe}>\xf9\xa5zr6\x1a^LNK\x1dAD\xd0#\x0c\xdbeD\x87#\x8cQ\x99\x00	F\xab=\x81Ew\xae\xff\xf8\xc2\xcd\xf0\xee\x7f\xffO\xb5\xbe\xff\xf1\xa5\x04L\xc1NQ\x1bTyIY~T\x82\xee\xc9\xb6\xf4{	\xa9\xfcM2;N\ngb\x9f\x04\xcf<\xab\xd8G\x11,\x8a\xf5>)\xbd\xd2\xc9\xc3\x1a'\x0e!\xc6\xaa\xe5\xa6\xd1\xcb\xa1>JP\x04\xd3\x02:+\x99\x91-\xc4!\x8a\xdd\xcd\x02\x8a\"\x8b\x10\xf3\xb72\x93\xb8\x0eL@\xba\xd9\x83\x0dt\x87\xa2\xfcC\xea\x18E\x0et\xcb\x9b\x8fq^T\x7f\xea\xd2j\xbc[\xe3\xf0\xcfleK\xa9\x7ff+]\x1dm*\xc7[\xbf\xb5\xe2\xb0\xfd\xac \x80\xec\x0b\x93ir\x98\xc6(\xdd8\xb8\x0f \x04\xeerR\xd4\xa9C[\xc8\xe1}jK^\xe2\x80$\x8b\xbe\xba\x06SI\xbaz6\xe9\xe4\xa0\x0c\x0b\xad2\xa8\x08\xfcF\x04Wa?\xa8\x13\xaa\x97Z\x8e\x05\xb4\xcd\x89\xd8\xfc\xd2\x89\x8467\xb2o\xd64n\xd2o\xb5\xfc\x11r\x1aS\x19C\x90\xd0I\x08a\nVI\xab\x0e\xaagu\x0d\x92B\x9e\x1d62\xc7(\xc3\x88\xf0\xda\xb2ZS\xc0\xa6LD\xdfo\xf2Us\xc4\x8a\x91\x10C\x02SB\x7f\xc8!F\x19_g\xb9rsy#U.04J\xf1#\xe0\xfb.\x9d{\xd1B+\x04\x1b\x98F\xc8\xea`\x8c\xec\xd9\xa5\xf2\xab(\xd5+\xb7\x9aV\xbd\xffS\x92\xd2\xc9f\xe6sdM\x97\x17W\xda|\xeb\xc1x\x0d0\xf4\x11\x1c\xee\x00\xb2k\xe9\x98m\x0e\\D:\xed=\x1c\xf6\x16\xcc\xc1p\xb6\xb7\xe7/F\xe4\xe2]%a\xb1\xa5\xcd\x05'\x17+\x07$Y\xba\xf1\xa1\x8fa\x119\xb2f\xabw\x1d\x99*l\x17')YEY\xa2,\xf5T\\\xe9R\xe55\xa0\xa0&\xcbp\x0c\xb1F\xda7\xe4{Up\xbf\xd45\xe9f\xd6\xe6\x99\xae\x1b^F\x17e\xb1=\x810J'_d\x94N\xaeHS\xa2K\xf80\x8e.\x0e\x99d\x11`K\x8f\xc1\xbd\x193\xe7\x84\xfc\xb4\x850\x89\xb6\x00\xe1p\x95\x01LKO\x8d#\xea\xf7\xa4\xff\xceh}\xcb6\xa4o\xd7ys\xc0\x1a.\xb4\xaeCYZ\xd1T%\x12m\x976\x03M\x89\xf6~\xf1\x9fO\x8b\xe5\x95\xba\x8f\x13\xa28,\xe0_l\xf8\xfd\xc3\xe5\xddC\xf0\xdb\xf5\xc3\xaf\xc19\xfb\xe1zyu\xb7\xf8\xb8X>\x04\xff\xfa\\\xfd\xb4\xbc\x0d>^/\xff\xe7\xf2\xe6\xd3\x82\xff\xfb\xf2\xf7\xe6\xdfW\x97W\xbf.\x82\xf3\x7f\xfety\xf3\xb0\xb8s\xc2\x0en\x7f[.>P\x08\x95\x80\xff@1\xafIdn|\xeb\xf4\x9d\xb5\x90q;\x1ap\x02\x93\xf4\xad\x9a\xfd;\xcb\xdfE\xeeh\xd0\"i\xeb T\x85\x82\x0f\xd3\xbdq\x88\xd2ufK\x93N\x9e\xcf\x92\x9eb\x8fU\xa0\xbf\xa1:Vp\xf6T\xa8\x9fDY\xb2\xdf\xa5\xcd\x96T|J[\xeb*\xa4-\x04t\x01T<\x89\x8a\xc70A_\xa1\xa6\x93z\xec\xc5\xd1\x10\xf9\x9a	;\x86\xf7i\xa5P{b\x97\xdc\xcd+Y]Yn\x92\xac\xcbw\xb9S\xd5\xccj}J\x95mT.\x01\xbfE0\xa79ZG\xa0<\xe4\xea\xe57\xbd<E\x1c$XTg\x0e\x94\x869\xce6\xb8m\x12\xd3V\xb9\xf8\x8a\xf2\x90nB\xc9s\xb9i\x0f\xa3l\x9f\x12\xb6E^U\xeb:\xc0)J7\xdd\x07\xd5\xb0\xce\xef\xfc\xd0\xa2\xfb\xa8\x0d\x87\xe1\x1ab\x98F\xd0L\xb6F	\x81\xd8\xccj\x070\xed2uhh\xf9\x81\xf3\x8c\x9e	\xc7!\xaff*\xba*\xb1\x94\xdc\xb8\xd1\xe2l\xbfJ`\x90c\x18!\xea\x8b\xdcz\xf2V<\xda\xc2\xe8kN\xeb\x15K\x9b\xc95\xb2\xdbN\x7f\xcc\xd8V\xc8a\x8e\xee\xf6\x00\xb7\xf8\xaeO.Y9\xf4*+\x0f\xdf_R\xd3\xbf\xf4\x80\xb0\xda}\xe8V\xb3\xce\x16UK\xd3\xecJ\xb5$\x86\xf2\xbb:\xb4U\x1a\xa5>\xb3U>l\x8el\xd5c\xeb\x13[\xe5\xd3\xea\xc0V\xf9\x8c\x9f\xd7\xaa\x9f\xeeU\xf2\xba\xba\xbf\xe8\x13\xc7\x0c\x00\xa5$\xe6\x10\x10\x87\xb4\x83\xc0\x84tL-\x9d\xf42j\"t\x17\xbf\xb3&*\xec\x8e&\x02\x91\xb6\x1c\x16\xae\\\xb0\x94`KH\xba{\x05M9a\x08\xeac\xd7\x99\x1e9\xb5vj\xbf\x06\xa1r\xaaD\xfb\x1e\xc3Y\x94\x12\xa8]F$\xb5\x86@M~T\xb5\xdc4\xb2D\x01ky\xb7\xce\xf5-\xeb2\xc0N-\xa9\x1c\xa3\x08v\xeb*\x91\xa8\xecnD\xcfng\xa59x\xde\xd1~\xc4\x0e\x92m\xeb\xd8DdI0H\x8b5\xc4a\xbc\xc7\xac\xd5\xf5\xf6#Rn\x8b\x8a\x8a\xf2\xbd\xb2O[I5\xf1\xc7pd\xb5\x08jw\x96h\x1d\x9c\x1a\xef\x13\xab?g\x98\x9a\xdc\xc5\xa3cX\x10\x942\x9fr!\x8f\xb2\x94\x00\xc4V\\\xabg\xbf!g\xe4%w\xbb\xc1Y'\x85\xf6o\xea\x83$\xbd\x17\xb0\xc9:\x9a\x0f\xb6\xd1\x0d\xee\xc7\xc8,\x9eG\xad\xe8\xdc[c#\x0bz-\xc7\xe9\xe8@\x1a\xe3\xd2\xf2gC\x9c\xceK\xeb6b\xfb\x82e\xcb3\x84\xcb\x95\n\x8fqR\xe0\x0dy\xba\x8f\x03\xf39?\x8a\x03\xcb\xe8\x1a\x07\xe6d\x16\x07\xc6\xf0\xaf\xbd\xd3\xed\x81r\xaf\xcdL\xae^da\x1a\x9b\x1e\xd3\xb6\xed\x13x\x0e\x0b\x18\xe9:\xbe\xf0\x1b\x88\x08\xe3\xa1\xa3xC\x1eE0\xca_\xc5\xdd\x9a\x19:\x8a\xbfu\xe05\x0e\xd7\xd0\x99=\xee\xf5\x1aB\xfc\xa4\xcd\x96\x87*\xc24\x86\xdflEjI\xfa\x86\xb2\x9bG\xe2:J\xdb\xa5\x8b\xac\xf6\x9fn[\xa5{\xcf)\x07d\xfb\x04\x9e_\xc5y*^.\xee\xb3\xc6\xd9.\xac\xef\x10(\x11If~^\x83\xed\xe8\xdd\x02ufCE\xb8B1\xc20\xa25gs\xf1K\xd2-\x81\xe9\x86lm^L0\xa0\xc1\x03\x12SB.\x08=\xc7\x17\x1a\xe5\x12\x9b\x1d\xf8\x16\x16I\x96[\xb7v;\x94\x86O(\xb6\x0bV\xa0MJ_{\xa3a\xa3\xba\xd8*RcH\xb5\x80\xa1\xdf\xa8\x1fc\x8d\xf0\x88\xcb\xca;\x8e\x12\x99\x12\xb6:6k\xa2vbW\xc69\xdb/\x1cE\x0f\x01Y\xadEIb^\x9c\x8a-\xc8\xad\xbbHF\xe4\x928\xba\xaf\xf6\x98\xce\xe9\xf4WfnP\n\xef	F\xe9\xe6\xe3[\xbf8\xe3\x11\x16\xcc\xccGq&\x01Y\xedL%\x89\xc5\x99\xea\xd3H\x9bC\x01\x8c\xd1\xa39Y\xc70\x07\x98\xec14g\xf4,\xa7\"\xd3:L\xb7\xce0\xa1h-N\x93\xaa5\x03\xe7(\xfa\xba\xcfM\xcd\xee\x18gy\x98\xad\xd7&\x1af\xac0F\x05	\xd9\xcaD/y\xdbV0\xb4\x83\xe5\xc9\xabZ\x8d\xf6\xb1/'\xd1\x9e\xe3\xfe\x10+\x82S|\x18w\x0d\xc6\x1a\xc3'\xb2\xb8c\x1e%\xbadtM\x84q\xb2v\x94i9\x1eM\x15\xbb\x16\x964Q7\xb1mY\x827\xbb\x0d\x91F\x0b\xb1\x86\xae\x95)\xde\\\x1f\xfc\xe5E\xb7G@\xd4&;\x8a\x13\xc9\xe0jG\xe2T6gB\xd6\xeb\xd0u\x8e\xb1%\x7fF\xe7\xbcT0j\x8fK\xfd|\xaf\xd3z\xfbTb\xb9\xa2\xef\x8b\xb9\x14Z\xad{\xc9\x80\xdd\x15C\xabD\x17\"+\xf4\x15\x16!H\x92\xec	\xea\x80\xdf\xd0\x82!\xb5\xe8u\xa5j\xbbYOs\x12\xad\xe9	\xc4\x06\xe3\xbf\xe2\xd10u\x8d#\x85\x16\xcama\x85\xaa\xfc\\\xb2)#\xeavy\xf3Yy1:(\xa9\xaeno>}\\\xd2\xee\xd5\xfd\xe2\xa1q\x03\xf8\x8d<\x82\xe4\xe4\x9djd%\xc5\xbb\xf7\xef1\xdcD	(\x8aS=&\xbf\xa5\xec\x85\xc7Gya\xd5\x07\x01\x04\xfa\xa1\xb5\xc6\xf9\xe3uo\x8d\xfacwy\xf4\x97\xa3}\xbf\xad\xbf$m.\xfde\xa9\x03\xafj\x94\xf6\x96F\xe4\xd3[\x9e\xfe\x12\xf8a\nWy\xbc0\x85\x91\xfe\x98\xe2]\x10\x7fdq|\x7f\xfc\x17@\xf7@\x95\x0e\xd8\xfd\xb1%\x06=%`g\xac=\xc1\xdb\xc7\xb8>\x9a\xf3\xa3\xb1\x1e\xb8|l\x0f\xdc\xe6\x84\xa4\x07p3\xd8\x1f\xb9G&\xe9\xb6\xed=,\\\xf7\x0b\xfd\xd5\xacG\xfacV\xaf\xa8z#\n\x8dC\x0f\x1d\xabN\xa17\x9e\xd0[\xf2\xc1\xe3\xdb\xe1\x1e\x98|\xac\xbf]\xe9\xd8\x9e\x90=\xd0\xf8.\xc7\x1f\x91\x0f\xed\x83\x8a\xfa\xe8\xd8.k\xddt\x14jIV\xd5^~\xf8\x10\\\xdd.\xef\x1f\xee.\xaf\x97\x0f\x81@\x10\xe6_\xe1s\xf0\xef\xbb\xeb\x8f\x97w\x9f\x83\xff^|\x0eNPl\xe0\xce\xeb?\x15\xe7\xa68\xf4\xe4\xda\xaa\xf3T|\xdbe`\x1f\xce\xdd*N\x8b\xd2%}\x11b\xbbZ\xb3b\n\xa5\xddKP\xc5\xaa\xcc\x8a+\x15q/@\xb6by\xeb%\xd4]*\xeeba\xd6\x87\xbbX[i1D\xb2\x17!\xd9A\xfa\xf1\x97\xca%-\x8a\\V\xf5\xc6b\xd5\x91\x19\x86\x91\xf4C\xe0u\x90\x1e\xa1)\x95z!4\x05\x8f\x1e\xa2U\x14\xf5\xc1\xd0\xc7`\xbb\xfc\xe9\xc3\xb9\xaec\xb4\xa2\xf3B\xa7\x0f\xf7\xb2f\xd1\xf2\xaeJ\x9a>\x9c\xcb\xeaD\xcb\xb9*^zq\xe65\x88\x9e{S\xa6\xf4E03\xef\xc7\x97\x17\x16Z\xdeM\xe9\xd1\x8f?2\xc8]\x16\x19\x1a\xbeU\xd3\xedz\xf9a\xf1{\xc0\xee7\x85\xcd:OW\x0f\xf6\x01\xb1\x10\xa4qX}\xd6\xebv\xd9\xed1}\xba\xbf^\xfe\x12\xac\x08\x8608)\x87\x9cU\x9f\x01k0>-\xaf\xff\xf3I\x07\xb5O\xd1_{hg^\x7f)\xed,\xb8\xba\xbd\xbcY\xdc_-N\xaa\xaf^\x9d\x05'\x83\xd3\xf7\xef\xcb6\xe5\xa9\xfc\xbc^\xb2e\xba\xd3\xe0\xb7_\x17w\x8b\xe0\xa4\x12\xfb\xb4\xfa\xfc\xd7\x7f\x05\x97\xcb\xcf\xc1\xc9\xc9\xe5\xdd\xdd\xe5\xe7?L_I;\x0b\xde\xe1}\x9aj\x9e~\xa9\x18\xfe\xf1\xe5T\xb6\xb6\xae\"\x91\xe4\x0dQ\xfc\xade\x17\xcd(\xd1J\x12\x0bWd\xdau\xef\x03G\xc7\xb9b\xd0w\xf2\xfb`\xd0qF\x0c\xa9\xce\xa9\xaf\x1f\x9b\xb0\xc4!\"^=\xde\x07S2{_h\xed\xec	\x11\xe4#H\xdd\xe2\xa7\x9f\x1b\xc1$|%\xc1\xce\xf8\xd1\xc1Y\xeb=\xda\xb3\xa0\x8f\xe9jN}E\xab\xc7\xfb`62\xf7Em8\xc8\xb8\xaa\xa2\xb2\x86Gq(\xbe\x05\xa8\x92A\xc1@\n\x08\xce\xedLz\xa9\xf0,\xd0J\xd4*\xa9\xa4\xc9T\xc17\xd4\"\xb44T\xd6\xbd\\F\xc4\x8dh\x96\x86\x9a\xcf\xc66\x98\xc2\x08\x11Q=\xd6\x15\x98~\x06\xd6\x0dfO\xb6\x8eL\x1d>\xb1\xeb\x04i\xe7\xe3(\x10\xbf\xfce\x86\xdd\xa0\x82\x04'5\xb1&\xb7hf\xb0\xf5\xe5g'\xdd\x1azG\x1d\xd87x\x9dXSJ\xa3\xf0\xed\xaeB\x96V\xae\xdc\x96\xbbE \xb2\xaeH\xbd\xb9\xf3oI\xbab\xd4\x03\xbc\x91\x9a\xd0s\xc6\xb2.'-4e\xc7\xc2\x8e\xad\x1a\xa6\xb0l\xc3\xa0\xd1\xbb\xa3\xb0\x8a\x17\x15\xa1\xfe\x90\x8b\xa4\xb6\x1d\xba\x1ah\x87d0\x9a\xbf$\xa1F\x95\x94T\x8fu\x04f\xca\xd3\xdb9\xac\xe2\xd68\xad\x1a\x97\x0f=\xab\xbf\x03\xeb\x88\xa9\xfcc\x18N\x90\xaa\x91jP\xb1\x8fCs2\x7fI\xb5A\x12\x88D$N\xee\xc7\x9e\x16\xa6^\x00b%\xab\x08\x10\x81Ew\xd7b@\x90\x9c\xff,p\xd1I\xaa \xb2TvDk\x99\xa0*\x05\x8c\x00b-\xe1\x03%\x8e\xf4\x02\x95\x8c\xe3\x83*\x0d\xf5\x82mj(\x1f\xc4f\x94\x03Xi\xd3\xaa\"\xd6\xa1H\x00\xdd\x02Z\xe7\x89\xf5\xf4\xa9\x8c\xc8R\x88]C	[br\xd6\xba\xc5\xe3\xaam\xf5q\x18G<F\xec\xca\xba\xfa\xda\x8d\x1b\xeb\x92\xd8\x955\xffl\x8d\x1b\xf3\x9a\xdc\x99\xbd\xefD\xf8\xdb\xbd\xd9\x8d\xb8\xea\xc0\x078k\xb1\xf7\xb0\x7fI\xec\xca\x9a\x7fp\xc8\x8dyM\xee\xcc~\x0f}\xb8\xef\xa1\x0f\xf3\xe6[Jn\xec9\xbdAz\xb9\x8doZ4%ZQ\x17\x97uF\x01V\xbf\xa4/id\x84\xaa\xc6\xd8\xf3\x96\x8c\xa7^H\xcdXr\xa2\xea\x80\xabQ\xcb#\x0b\x8b\x82\x8cH4\xa37{Q>g\x18q\x98\xcdA\xb86u\x03\xc4\x15G\xd70i\xeb\xd3:\x1c1\x96\xff\xf2QJo\x8dD\xc0\xfa\x96\xb1\xac\x91\x0e\xa8\xa2\xb7\xaf\x9aU\x8bP\xedt\xaaf\xa0\xa4\xc2Y\xfd>\xb0iz\xf8\xf9\x0c5]\xfb*\xba\xa4NM'\x19\xad5\xc2\x11\xa4\x96\xc9E!O\xf9\xab\xffw\x96\xbe\xa1w\x04h\xbd\xfe*I\xaf\x06h\xe8\xed5R\xcdA3\xdfj\x80\xce\x8cWdf\x9b\xe1\xcc\x9a\xaa\xab[F\xc2l\xb8d\xe8\x86\xb5$\x9a\x1fTG/\xd7\x9dH\x83\xdf\xfa6\xbe\x03`C\xed\xc1\xdcM\x17\x87\x14&K\xdd|k\xd1Yt>\xc4\x03\xa6\xf5\x12\x833N3\xc6\x03H\xb5ESM{\xf5\xd7\x02\x9es\x87=Ee3u\xb48\xf9\x94\xc3\xc4T\xe7\xc0\xacoY\xbf\xe2z\xbbT\xdd\x8f\x13\"\x85\xbf\x0f\xeb\xc8\xba\xd3\x12\xedr\xd65D\x0d\"\xf3\xd7w\x1d$\xaei\xedv\xaf\xf8\xab\xed.\x88\xac\xb3{\x17\xab\x0b\xd2\x9c\x90+v\x8c\xd5\n\xaa\xcb\xc5\xcd`\x8b\x03T|\xce\xea\xf7\x0d\x9d\x05\xf2\x04\xf6e_\xc9\xe5\xaaWEn\x0f\x99F>\xdd\xf4i\x10,\x96\xab\xdf\xc4\xb5\xd8\xcf\xec\xec\xf4\xda\x82\xb7\xaf\xd7\\\xc5\xbf\x00a`]\x17\x16mz\x17\xb1\xa5\xbf\x83`G\x10\x07\xb8@4\x7f\x94\xc3\xce\x9d\xd3:3\xa6K\x9b+cJ\xeb\xcc\x18\xc5\xael\xad\x11P]\xe5\xa8\xff\xe2\x88#[q\xd1\xd3-\x17l\xb4\xc1\xedm\x1eo\xd7\xa0\xb9\x1cc\xdf\x85pZ#\xaa\xc1\\\"\x98\xa1n\xd7!\xb9\x15\xee\x02\x8c\xbe\xfa\xd5\x80\xa8\xca_\x85\xd9\xe8\xabk\x86\xad!Mo\x12c\x87\x95\x9b\xb3\xd5w\xb4\x14\x8c\x9d\xbaY\x0d\xebj!s\x90\xd8a\xcd\xe3l\xc5\xd7Q\xed\xbc\x05zg\x00'\xb1;K\x8b\xde\x18\xf2k\xb1\x8er\xab\xeaI]\x0c3\x1e\x9a\x18VL\xa6n\xd52\xdcS\xe3\xd7\xb9T\xd7\xd4\xf8\xc3\xfa\xc4+\\\xd3\xdbj?\xdf\xde-\xae\x7fY\x96\xb7\xd5\xaa'\xa7\xc1\xdd\xe2\xe7\xc5\x1d\xfd$\xc0\xbd\xf2\xde\x80\xf9\xba\x9c\x8f\x18\x8d\x82:q\x1a\n\x95Xm+Y\xc4\xd2\\\x96R\xd9JCz\\\x91\xeb\xe8\xeew%\xfd{\x8b\xde\xa4\x12\xa5\x81\xbf\x86\x18\xa0\xa4\x08\x07c\x08\xe2\xc1l:\x1e\x8a\xc2\xf0\x8c\xa3\x90\xa2U\xab\x9b\xcd'fw\xb3\x1cQ4Y\xaf/f3 \x19\xa5\xbd\xd2he\xa1+\xb0\x83(M\x81j\x94\xe5\xe2\x02L\xa6\xe7\x83\xe9tu\xc8	\xd2\x9e\xe9\x9be\x8b\xcfG\xe7\xd3\xd9E4?\xa4l\xbc\x10s\xb0\xd6p\x10\xc1x\xb0\x02`&J\xf4Z\x93\xd6\xb4\xaa\x8d\x86\x19\x0e\xe1|6\x99G\xf3\xa9(\x06_\xecur0\x02W\xef\xb1\xc8\x00f\xc3\xf9\xf8b>\x1e\x892H\xd5\xfcK-\xc2\x8fs\x8c\xc2\x8c.\xc6\xc3Y\xbc\x9a\xaf.\x0e\xe9)\xad\x0b9Fi\xc6\xe7\xb3\xf9h4=\x1f\x1d\xdeo[\xads\xa3H\x138\xb9\x98\x8f\x07\xc3\xe9Z\x14\xa9^\xe7u\xd3D\x9f\xbb\xc8QO\x13;\xe56\x8b2\x05\xc3\xc9x=\x9e\x0e\x0e\x9aqZy\xdb(\xcdl\x04\xa6#8\x1b\xc6RD\xbf\xae\xe74\x9b=\xb30\x93\xc1z\x0d\xc6`4\x10\x85\xe1\xcd}\xdd41\x02\x17\x19\xa4\x038\xb34`\x18\x81\xd9h\x1a\xc3\x83\x9b\xa6n\xd9\x9b\xe5\x89\xa7\xab\xf5hr1\x9e\x1cR\x1e\xd7 \x9f\xcf!\x80q\xb4\x9e\xab\x1c\xe7\xc5e\xadG5\x01\x06\xc3A4\x02\x17\xe0\xf0\xd9F\xff\x92\x11\x9f$0^\xaf\xc7\xe0\"\x82\x87\x8fn'\xa7\x01\x93\xc9\x0c\x9e\xc3\x01\x88DyZ\x1b\xee\x97.\xda\xf5\xed0\xb3\x1cS8\x88F\x93\xd9\xf8\xf0v\xb1\x17\xc2t\xe9\x8e\xc1`8\x94\x0b\xd0V\x98\xbc<\x92\xbc\x13\xcej0\x9f\xac\xa7#8\x90\xeas~\x1e\xa6\x9d\xaaz\n\xcc\xb3%\xc8n\x96d<\x9c\x8c\xa3a\xb4\x1e\x1eb\xcf\xeaSq\xae\xc6`4=\x9fw\xfd\xf7\x95\x16n\xc7\x8as\x15O\xe3\xf3\xe9lt!\x1b\xa4\xbaC\xa1\x9b\x18i\xfa\x1d\xa2\xc9Q\xa0h8\x9c@0_\x9f\x1f\xde\x85\x0d{\xe0\xdas\xa3\xf1z\x05F\x138\x92\n\xf2\x03\x04\x94G\xa5\x15\x81\xf1`\xb4\x8a\x06\xe7R\xd1\xd7j\xdd\xe9\xe6\xadFq\x91\xc8^\xd7D\xeb\xd1*\x9a\xce\xcfgR]s\x00\xf3\xb8\x16\xc4\xf1\xf9j4\x99\x0d.F?B>\x8e/\xa2\xc9z>\x8f\xc7\xf0\xa0\x13\xe5\xb4`\xc6\xeb\xd9h\x02&\xab\xf1\xe8\x80\xcd	\xb7\xca\x06\x9eG\xe3\xc9z\x1a\x83\xd1\xc1\xd6n\x87\x8a\x06\x8e\xe1j\xb0>\x1fO\x0f\xb4*I\xafq\x19'\x07\xaef\x93!X\xad\xe4\xd5\xa0\xed\x92\xaf\xb4p\xdb\xbdv\x87\xe2\xf9p<\x1e\xac\xc7\x83\x17\xb4!\xfe\x7f\x00PK\x07\x08I\xad~yA\x12\x00\x00\x03\x95\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xb0\x8dS]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x08\x00	\x00regen.shUT\x05\x00\x01<W\xd6jt\x90\xddJ\xf3@\x10\x86\xcfs\x15C(\xa4\xfd`\x93\x0b(\x9f\x10\xeb\"\x82T19\xf0@\xba\xc4\xcdd\xb3\x9a\xfdqg\x1b=\xc8\xc5K\x15\xa5\xa5\xe6lx\xde\xf7\x81\x99!\x8c\xc0\xf0#A\xd9;H7\x0f\xbc\xac9\xf0\xc7\x9ao\xab\x9b\xbb-xGQiZ\xa7p\xf1=\x07\xa4\xdc+z\x1b\xe6\x8c\x9e\xa2\x0bx\x10\xce\x0c\xafD\xbb7\x1e\x9e\x12\x00\x00\x16!\x93\xfb\x10\xd0F\xd1!\xb6\x94\x1d\x05\x07 F\x0c\xa4\x9d\x9d\x0d\x84\x8a\x1d	m\xbc\x0bq\xbe\xd4\xe9\x01\x85\xb6\x9d\x9b\xaf\x10\x86QK\x14\x03\x8e8\x9c\xd7(6\x11Opk\xba ^\xdc\xf3	\xfc\xda\xe6\xdf/a\xd61\xf7n1\xfc\x00:N\xa43\x06m$X\xdc__\x95uyYV\x1c&@\x15\xd0\x03\x1b!\xdd-+^O\x15\xbf\xe5\x9b\x1a\xbc\x12\xb2\x89\xcd\xe0\xd4\xc4\xd8*\x85	\x08[`\x08Y\xb1[\x14m\x06\x7f|;Y\xca\x16\xf2\xbc\xd06b\xb0\xcd\xb0\x86\xc3\x1d\xfa\x15\x18\x05\xf9?\xcf\x0b\x92=\x9a\x06\x98\x07\x92=\x9af\x95|\x0e\x00PK\x07\x08.\xf7\xc0u\x14\x01\x00\x00\x0d\x02\x00\x00PK\x03\x04\x14\x00\x08\x00\x08\x00\xd4\x8dS]\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\n\x00	\x00sqlite.sqlUT\x05\x00\x01\x81W\xd6j\xe4\\Mo\xdc8\xd2\xbe\xfbW\x10}I\x1b\xe8\xbc3\xf3\x02s\xd9\x9c\x92L\x07k \xe3\xec:\x0evn\x02[\xaa\xee\xe6XM*$e\xbb\xe7\xd7/H\x91\"E\xf1CN\x06\xc8\xc2\xce%hU\xf1\xe1G=U,\x96(\xbf~\x8d\x8eRv\xe2\x1f?\xfdT\xb3\x06v\x80{I\xf6\xe7\xffc\xfc\xf0\x93\xf8\xda\xee\x19?a)\x81_\xbc\xbf\xd9\xbe\xbd\xdd\xa2\xdb\xb7\xef>n\xd1\xd5\x07t\xfd\xe9\x16m\xff\xb8\xfa|\xfb\x19\xad\xea\x9es\xa0\xb2\xda\x034b\x85\xd6\x17\x08\xadH\xb3B\x84J8\x00G\x1d''\xcc\xcf\xe8\x0e\xce\x08\xf7\x92\x11Zs8\x01\x95\x1bt\x81\xd0\xaa\xe6\x80%4\x15\x96+\xd4`	\x92\x9c\x00\xfd\xb6\xfd\xf0\xf6\xcb\xc7[\xf4\xfe\xcb\xcd\xcd\xf6\xfa\xb6\xba\xbd\xfa}\xfb\xf9\xf6\xed\xef\xff\xd2=_\x7f\xf9\xf8qh\xdcw\xcd\xb77f\x14\x84d]E\x9a\x15\xba\xc7\xbc>b\xbe\xfe\xff_\x7f\xbdtjJKtP\xe7\xe4\x0d\xb4\x10\x0eA7lI\x0dT\xc0\n\xbd\xfb\xf8\xe9\x9d~\x82{y\xf4~\xf6\xbc\x15\xde\xcf\x16\xd3C\x8f\x0f\xe0?\xc3B\xb0\x9a\xe8)\x9a\xe5\x1d\xd5\xd5\xef\x8a\xe2\x13\x88\x0e\xd7P\x98\xc4\x9e\xb4\x90\x98\xc4\xc5\xe5\x9b\xacyu?\xf7\xc0\x05a\xb4:\xc8\xbd\xa8\xc8\xa9c\\~\x83\xa9'P~\xbbq\xc1\x7f0#D_\xd7 \xc4\n\xed\x18k\xb5Q\x86\xb9V-;\xac\xd0\xaee\xbb\xe1!\xad:\xce\x0e|\xaa\n\x8f5tRM-\xd06\x10p\x0f\xad\x9b2e\x12\xd1\xbe5\xbdP	\xbcc\xad\x1e\xb9\xe6\xa4\x9asU\xb3\x9e\xcaD\x13qG\xba\n\xa8$\xf2\\\x01\xe7\x8c[\xed\xb1__\x83\xc3\x1e8\xd0\x1a\xb2Z'\xcc\xef\xa0\xc9\xaa\xecI+a\xde\xd7\x01(p=\xfaP\xf2\x809%\xf40{n\x06\x16>v\xeb\xca\x01\xb7\xa3\xe1~v\x14QZ\xf5\x11\xea\xbb\x8e\x11\xd5T\xc2\xa3\x1c\xf5^\xbdZ\xcckMe\xb5\xd6\xcf\x92\xc8\xf9\xb8\xe6tT\x00)k\xa9\xcd\xa1\xac\xd5\x80HFJ\xad\xf5\x17\xa3\xb0hT=o\xb3@-\xab\xb1\xf64y\xee\xc09\xc8t\x01;\xac\xb7%!\xb5\xea\xa8\xe5u\xa3\x9cL\x8d)\xdb\xd7\xc3\x11\xa0\xad\x8f\x98\xf0j\xc70o\x08=\xa4z\xd4.\xee\x076M\xd6\x03\xb0\x13H~\x1e\xc2z\x80\x0e\x8f\x92\xe3\xc1-J\x81X\x13\xb6\xc3\xf2\xf8\x80\xcf\xcf\x91\xb3fj\xa5\x9d\x8c\xb3S5\xd2{6\x07\xa5\"YA\xc1\xf6t\xd2\xb4\x8eb\x10Q\xedHC8\xd4\x8a<\xb8M\xa8\xb5@\x0fjO\xd7\xc1j:\x08\x8e\xd5\xa6\x89[\xcd\xb2Ds!\x15\xadL\x0c\x8cj\x9c\xf0c%Z\xd6A\xac\x8f\x13\xa1\xd5\x03i\xe2\x03\x10\xe4@;&TL\xc6\"\xc5p\xa5\xc8A\x0d\x14\xaaE\x0d2\xa4\xfdr}\xf5\xef/[tu\xfd\xdb\xf6\x0fD\x9a\xc7jB\xd8\xaa\xa7\xe4k\x0f\xe8\xd3u\xc8\xe4u\x90\x16l\x90\xb5\x0fi\x96\xb8\x84\xf6\xba\xe7\xe8\x10.\x9c\xa4\x8dgth\x03\x8f1\x12\x0c\xe2b\x90\x7f\x8aA5\xe4\xdc\x9c\xc6\nsc\xdaY,1\xa58\xe2\x0e\x9e\xa3)\xf5\xc42\xa6D\x93$jH*\x9d\xf4\xef\xdcE|\xfb\xd8\x95\xd6\xcf\xbc\xe5\x1e@\xf5\xd3a\x83\xcd\x0cZ\x1c\xf1/)\x82\x8e\nUCxV)s>1\x07\xbdBF\x00\x98\xb7\x04\x84\xacj\xdc\x02m0\xaf\x94\xb5<KM\xd5U\xa6\xbdXy\x0f\xb2>\x86\xb4\x99\xe2=\x81\xab?\xe2t\xa3R\x8b2+T\xa6d\xbd\xefIs\x8aP\xc8\xad\x8f'\x8fx\xa8/V\x9b\xa8\xb2U\x05\x14\xef\xda\xd1\x0d\xfc\x93\x92\xed\xa9\xe3\x84q\"\xcf\xe3\x08\x8d\xb0\xc5BV1{\xf9rs\xca\xdb\xf7\xed\xa0\x9aT\x1cNn\x19\xa4\xa1\xb9>~\xa5\xd89\xf4+\xf1\xc1;\xd6\x7f\xe7\x19\xf7\xdby`F3\xcdH\xcd\xc3a.\xca,\xfc\xde\xcbw|\xea\xb8\x9d`\x12ET\xd8\xfeEo\xec\xd3\xe0\xb2\x1e\x82\xc3\xe5\x9bR\xfb\xb8\xf3\xc6\x10\xe3\x9a\xe5\x1eb\xfe\x1e\xc3\x8f\xe9\x95\xd1M\xfc\x8c\x01\x1a\xd1B\x0c\x15m\x93(J\x18\xc3q\x07Y\x9b\xf6\xba]Y=\x10\xab\xb5y^h==0\xcd@\xa6\xe2\x02\x96?\x81\xd8\x88\x02y)\x8bT\x13\x98\xe7\x1cq\xac\x0d*NW\xed\xc6\xe6\xbf\xe9\xe0\xd4\x13\xb5^f\xbbNOR)\xe4fi\x80\x9e:M\xddl>\xcf8\xda\x06\xcd\xc7\x19\x8d\xee\x1aD\x0f\x85\xd0=3\x11\xde\x04\xd2~\xd7\x12q\x04^N\x12\x03\xfd\xd2\x8e\xac\xd5UM\xb3\x0c*$\xe62\xd8\x83=1\xd0&-\xb4\x8b[\x1c\xfesJ'\xe3\xf9z@&g\xf04O=R\xa4\xb9\x9a\xe1\x14\x87\xaf=\xd0\x9a\x8cI\xbb\xe4D\xb9\x9e\xde\x97\x83\x19\x0fV\x1eO\xc3\x81T\x199%;\x02n\x1e\xf0\xb9\x12P\x0bg\xc4\xa0\xfd#\xae\xa5FH\xaa<\xa7\x03\xc52\x068\xf3T\xc60.D\xf9\xb6[\x1bi2\xde\xf9H\x01O\x12\x88\xdf\xc0&5\x08\xcb#\xcez	)\"\x01\xbf'uJj\xa6\x92\x8d:ZG\xb1J\xd5<\xca\x9a\xe2\xa8\xde\x01\x14#\xccX.\xb2#\x8b\xd0t\xd7\xb2\xfa\xaet\x10\xb4a]\x83\xcc\xaa\x90X\xbf\xa3 \xbb6VU\xd2\xca;r\x07\xa2\xc2m\xcb\x1e 9\x12\xbdIv\xfa\xf5^n\xc0\xcf\xe9\x14\xbe\xc8i\x147De\xf9\xe7\xd8\xad\x9f\xaf\xd6V\x90t\x15\xadW9\x8e\xce\x10\x9c\xa8\x80a\x98<\x030\xcfK#0$\x9a\xf7o\x04\xa5\xf6S~\xcca\xa6\xf2\x02Z2h\x98eM\x86\x8bxUQ\xb7\x9a\xe5Iq\xac\x0d\x9a-XzG\xc3\x87\xc9v\xa6\x7f\xe6J\xd2\xc8\xd3*\x06\x08\x83V\xca\x9c\x8c\xda\xa2\xb7\x13F\xb7\x98g\x19\xbd\xee\xb8\x10p\x8f9,\x1d(\x9c0\xc9\xcf\xe8;\x83H0\xc4\x1f\xf7~xQ\x00\xb1\x14\xaa\xccR\xfa.8\xd2k=\n\x93\x8e3\xe2$}\xc7\xa1=\xd1}F\xe8\xd0\x83\x92\x88\x1b\x14\x19p\xda\x8d$\xc7T\xec\x81[?\x9a\xbd\xb5	\x16V\xb2\xac\xd4\xa0e_\xf7\xa9\x97!N\xd1f\x92\xee\x8d\xdfwr0\xb2\x97\xff\x8f\xf3\xd0.\x86\xf0\x96\xc5\xd6\x17\xa6\n\xab\xb5[\xb8\\\x91al\x90\x0b\xe7F%\xc3\xc9\xe48}\x96\xf8\xfb\x8d\x83\xf4\x14\x96\xcc\x9be\xd1$\x9bc\xa5)m\x0b\xcb\x96\xd2n\x1b\xcf\x86\xbe\x13\xa3\x0dvu\xca@*{\x10\x19\xf1\x0344\xab \x8f=\xcf\xc9\xf7\x9cd\xa4\x02\xcb\x9e\xe7\xe4}n\xec\xb1S{0\xbc\xf9\xc9=P\x18\xef\x8d\x84\xaf<^\xa6\xcb\x8e\x1c\xabF\xcb;\xe6:\x02\xae=b,\xc0r\x86\x8a\x829\xf1\x02\xac\xa4\xe3{\xa3{b.7\xb6\x9c\xedFi\xcc\x0dr\xee\xb7`\xd4\x96\x87\xd1\xf9[\xe1\x02\x1c\xd7i|%G\xf1\x02\xac!0Dql\xd0X\x80b\x02H\x1c\xc6F\x97%8&\x90$\x80l\x98Y\x804\x84\x9c8\x8e	G\x0bPlh\x8a\xe3\x8c\x81k	R\x9f^g\x13\xe0\x1cJ9\xfck\xaa\xc4\xf6\x80yR3\xadY\xce\xe2\x81\xbd-\x98Kj^l\x04\x1c\x969\x11\xb4\x8c\x0d\xd6\xc3\n\x179`\xb0\xdc\xfd\xcci\x1a\x14Xv=\xd5[\x8a\x1e\x04\xa8\xf4\xa0\x03\xc5\xa5\xf8\x8ejih\xa7\xb3\x84\xd1\xba\x92a\x99l\xcb\x1a\xd9\\f<\x02\xc4\x92\xf4\x01aa\xa9lPn\x19=,\xd5-^.\x1c s\xce4h\x94\x0e\xb5\x83V\xcdZ\x96\xbf\xb2`\xfa\x83G\xb9XY\xa82\"\xe3\x0d\xf0T>\xf7\xe2\xfc]\xafb\xac\xdcf\xd8Y\xae\xb7\x19\x84\x91\x9bs\x88QT\xc2p\x14\x9a\x838Y	%\x19	\xec\x94\x9eX\xeb2\x13\x0c\x93\xa3\x04\xda\x06\xcdW,\xbd\xab\x8dw\xcdm\x1c0\xa5\xb2\x98\x83c\xce\xc9\xbd\x7f\x9f1\x907\xd0a.{\x0eI\x0ds\xdaJ\x8a\xc4\xf0\xfe Y\xd7\xd6\xed\x17\x95\xed;R\xdf\xf5]6\x1a4\x9cu\x15\xdb\xef\xb3JC\xad\xb4!B\xaac\xe5=\xe8[)\xd3kvZO\xcd\xd8\xdcGO8\xb6w\xc3?\xa9\xf3\x82\x9c\xdf\x11o^\xe6\xf6HY\xacu{8\x86]q\x1c#\\\x82\x138T\x1c/P*\xd5\xab\xbda\x86~\x9cA\x1d+\xd7\x1b4\xf1\x0f\xd7[\xda\xb3u\x01\x97\xf7\xed\xe8\xd9\xfaA\xdc\xf9l\xc4\xf0\xde71N\x0e\x84\x96\x12\x82\x06\x84$\x14\x8f\xef\xbc2\xaa5\xa3\x12\x13*J\x90/\xc9	\x02CU\xc6D\x8eq\x9e\x11\xd7F\xe8l\x1fP\xcc\xc7\x99.L\x02o\xaa\xb4\x98SXJNv^\xeah\x06\x96\x8f\xc7\x9c\xd4\xb3+\xecZ2|=X\x9fM\x18\xcea\xe0\xb3\xb2}u\x02yd\x1eK\xa6x\xae\x009\xc1\x8a%\xb0\xd3\x06U\xd3\xf3\xe9\x97\"\x01\xf2\xcbd\xa63w\x82\x9e\x1e\x1f\x96q\xd4G\x9c\xaeS\x0ey\xaaYz?\x18v\x14\x06\xdd@>\xf3\x86\x0drS\xb9\xb8x=\xffw\xb1\xe0>\xad\x0d\xe4\xear\xf3\xe4\xe6\xd5sbR\xe4\xf86\xf9\xb4Q\x90\xbf\xbc|n\"\xe2\xecA$D5k\xfb\x93\xba\x9f\xae\xd2\xcc)\xde\xfc\xbe\xf9D\xae\xd2C\xe09\x8dZ\xdcW-\xb9\x83\xe0zq\xf9\xa2\xabg\xc8h\x92\x90\xd0\x8d\x85\xda\x18\xa5^?\x81R\xf6\xa4\xef\x7f\xfb\xb2x\xf3\xb4\xf7}K\xcc\x1a\xf4\\r\xe0/\xa9\x11\xba\xda\xb1\xc7\xbb\x00\xc0\xd6W\xd3\x1a\xe6\x80\x96\xab\x04\xf8c)\xd7\x18|\xedb\x91\xc1WN\x9c\x05\x06\x15S\x95M\x89m\xb55%w5\xfb$\x82-\xb3\xa6\x14\x92\xafs\x8c5lY4\xd5>\xf5Bg\x01\xf7\xa7\x8c+\xf3?`\xe8\xdf\xe3\x03\xcdi\xcf\xab?\xd9\xeei\x81T9\xfd\x9fl\x97\xcd0\xc6C\x95\x0fy\xb3\xfd\xb0\xbd\xd9^\xbf\xdf\xce\xfe\xca\xc1Z\xc5\xf0K\xd7\xca\xce:\xd5\xdaW\x9a\xb4V\xdf\\\xf4\xe1\xe7y6\xf6\xbe\xea\x80\xaa\xefM\xddG\xcd\xbaGug\xeb\xd4I/v&>\x93\xe6=\x9d\xee\x08\x13\x18u\x13-8\x1b\xfa\xcfw\xe7\xd4\xa8\x82\xe1\xe8o.\xcc7\x10\xa9\x8f\xb17\xdfs\x1a\xfd\x8e\xcf\xfa\xe3\xc4\x1ei\xa4^\x8d\xc9^T\xc3B\xe9\xf4\xc0QL\xbd\x18\x93\xbd\xd8\xa0A\x9aI9\x1c\x9e\x97gx@\x96|\x1bT3\xdc\x82\xa8\xc1~\x17\xb0A?_\x86O\x1d\x97\x94\xf4\x12\xfd\xe7\x9f\xdb\x9b-\x1a\x06\x83\xae\xae\xd1z\xa4\xc5\x06\xbd\xe2=\xa5\x84\x1e^]\xbe\xb9\xf8\xef\x00PK\x07\x08772\xa1\x1e\n\x00\x00\xcdC\x00\x00PK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xbd\x8cS]\xb3\xfcnU_\x00\x00\x00\x9c\x00\x00\x001\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x00\x00\x00\x00migrations/postgres/0001_import_progress.down.sqlUT\x05\x00\x01wU\xd6jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xbd\x8cS]\xa3\x82\xb4\xfe\x90\x00\x00\x00\xe5\x00\x00\x00/\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xc7\x00\x00\x00migrations/postgres/0001_import_progress.up.sqlUT\x05\x00\x01wU\xd6jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xcc\x8cS]\xfe\xc04\xf7t\x00\x00\x00m\x00\x00\x000\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xbd\x01\x00\x00migrations/postgres/0002_unique_indexes.down.sqlUT\x05\x00\x01\x91U\xd6jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xcc\x8cS]\x9f\x9f\xa6\x9d\x8c\x00\x00\x00\xb5\x00\x00\x00.\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x98\x02\x00\x00migrations/postgres/0002_unique_indexes.up.sqlUT\x05\x00\x01\x91U\xd6jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xbd\x8cS]\xc3\xdc\xcc\xe8\xa4\x00\x00\x00\xae\x03\x00\x00.\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x89\x03\x00\x00migrations/postgres/0003_extra_fields.down.sqlUT\x05\x00\x01wU\xd6jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xbd\x8cS]\xa3 \xb0Z\xaf\x00\x00\x005\x04\x00\x00,\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x92\x04\x00\x00migrations/postgres/0003_extra_fields.up.sqlUT\x05\x00\x01wU\xd6jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x93\x8dS]\xf9t\x9dVL\x00\x00\x00E\x00\x00\x000\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xa4\x05\x00\x00migrations/postgres/0004_fetch_interval.down.sqlUT\x05\x00\x01\x06W\xd6jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x93\x8dS]H\xb9A\xf5W\x00\x00\x00P\x00\x00\x00.\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81W\x06\x00\x00migrations/postgres/0004_fetch_interval.up.sqlUT\x05\x00\x01\x06W\xd6jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xb0\x8dS]\xfd@h\x0e.\x00\x00\x00'\x00\x00\x00&\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x13\x07\x00\x00migrations/postgres/0005_jobs.down.sqlUT\x05\x00\x01<W\xd6jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xd4\x8dS]\xbe\x96`@\xb8\x01\x00\x007\x04\x00\x00$\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x9e\x07\x00\x00migrations/postgres/0005_jobs.up.sqlUT\x05\x00\x01\x81W\xd6jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xcb\x8cS],M3v\x8a\x01\x00\x00\xd6\x05\x00\x00/\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xb1	\x00\x00migrations/sqlite/0001_import_progress.down.sqlUT\x05\x00\x01\x8eU\xd6jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xbd\x8cS],\xaf.\x8at\x00\x00\x00\xb1\x00\x00\x00-\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xa1\x0b\x00\x00migrations/sqlite/0001_import_progress.up.sqlUT\x05\x00\x01wU\xd6jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xbd\x8cS]\xa1\x80\xa0sz\x00\x00\x00\xd4\x01\x00\x00.\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81y\x0c\x00\x00migrations/sqlite/0002_unique_indexes.down.sqlUT\x05\x00\x01wU\xd6jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xbd\x8cS]\x95\xb6\x1fJ\xf1\x00\x00\x000\x04\x00\x00,\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81X\x0d\x00\x00migrations/sqlite/0002_unique_indexes.up.sqlUT\x05\x00\x01wU\xd6jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xcb\x8cS]f\x81X}\xc2	\x00\x00\xa9J\x00\x00,\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xac\x0e\x00\x00migrations/sqlite/0003_extra_fields.down.sqlUT\x05\x00\x01\x8eU\xd6jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xbd\x8cS]\xe7a\xe1\\\x9a\x00\x00\x00'\x03\x00\x00*\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xd1\x18\x00\x00migrations/sqlite/0003_extra_fields.up.sqlUT\x05\x00\x01wU\xd6jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x97\x8dS]\xc5\xf49\xd6_\x01\x00\x00G\x04\x00\x00.\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xcc\x19\x00\x00migrations/sqlite/0004_fetch_interval.down.sqlUT\x05\x00\x01\x0eW\xd6jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\x93\x8dS]\xda{wDF\x00\x00\x00?\x00\x00\x00,\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x90\x1b\x00\x00migrations/sqlite/0004_fetch_interval.up.sqlUT\x05\x00\x01\x06W\xd6jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xb0\x8dS]\x05]\xfd\xb1)\x00\x00\x00\"\x00\x00\x00$\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x819\x1c\x00\x00migrations/sqlite/0005_jobs.down.sqlUT\x05\x00\x01<W\xd6jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xd4\x8dS].\xc1?\xc7\xa1\x01\x00\x00\xb8\x03\x00\x00\"\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xbd\x1c\x00\x00migrations/sqlite/0005_jobs.up.sqlUT\x05\x00\x01\x81W\xd6jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xd4\x8dS]I\xad~yA\x12\x00\x00\x03\x95\x00\x00\x0e\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\xb7\x1e\x00\x00postgres.pgsqlUT\x05\x00\x01\x81W\xd6jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xb0\x8dS].\xf7\xc0u\x14\x01\x00\x00\x0d\x02\x00\x00\x08\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xed\x81=1\x00\x00regen.shUT\x05\x00\x01<W\xd6jPK\x01\x02\x14\x03\x14\x00\x08\x00\x08\x00\xd4\x8dS]772\xa1\x1e\n\x00\x00\xcdC\x00\x00\n\x00	\x00\x00\x00\x00\x00\x00\x00\x00\x00\xa4\x81\x902\x00\x00sqlite.sqlUT\x05\x00\x01\x81W\xd6jPK\x05\x06\x00\x00\x00\x00\x17\x00\x17\x00}\x08\x00\x00\xef<\x00\x00\x00\x00"
 		fs.Register(data)
 	}
 	
\ No newline at end of file
@@ -0,0 +1,154 @@
+package merge
+
+import (
+	"errors"
+	"time"
+
+	"github.com/interline-io/transitland-lib/tl"
+)
+
+// clipFilter clips service to a date range and namespaces IDs that collide with the other feed version.
+// References are not changed; the Copier maps references using the source IDs.
+type clipFilter struct {
+	start          time.Time                  // first day of service; zero for no limit
+	end            time.Time                  // last day of service; zero for no limit
+	prefix         string                     // namespace for colliding IDs
+	collisions     map[string]map[string]bool // GTFS IDs used by the other feed version, by kind
+	services       map[string]bool            // service IDs with service between start and end
+	calendarless   map[string]bool            // service IDs with only calendar dates
+	skipTrips      map[string]bool            // trips without service
+	skipFeedInfo   bool
+	clearFeedStart bool
+}
+
+// setServices finds the services with service between start and end.
+func (f *clipFilter) setServices(services map[string]*tl.Service) {
+	f.services = map[string]bool{}
+	f.calendarless = map[string]bool{}
+	f.skipTrips = map[string]bool{}
+	for sid, s := range services {
+		if s.StartDate.IsZero() {
+			f.calendarless[sid] = true
+		}
+		start, end := s.ServicePeriod()
+		if !f.start.IsZero() && start.Before(f.start) {
+			start = f.start
+		}
+		if !f.end.IsZero() && end.After(f.end) {
+			end = f.end
+		}
+		for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+			if s.IsActive(d) {
+				f.services[sid] = true
+				break
+			}
+		}
+	}
+}
+
+// inRange returns true if the date is between start and end.
+func (f *clipFilter) inRange(d time.Time) bool {
+	return (f.start.IsZero() || !d.Before(f.start)) && (f.end.IsZero() || !d.After(f.end))
+}
+
+// id returns the namespaced GTFS ID if it is used by the other feed version.
+// Empty IDs are only namespaced for agencies, where a single agency may have no agency_id.
+func (f *clipFilter) id(kind string, id string) string {
+	if f.prefix == "" || !f.collisions[kind][id] {
+		return id
+	} else if id == "" && kind != "agency" {
+		return id
+	} else if id == "" {
+		return f.prefix
+	}
+	return f.prefix + ":" + id
+}
+
+// Filter clips and namespaces the entity.
+func (f *clipFilter) Filter(ent tl.Entity, emap *tl.EntityMap) error {
+	switch v := ent.(type) {
+	case *tl.Agency:
+		v.AgencyID = f.id("agency", v.AgencyID)
+	case *tl.Level:
+		v.LevelID = f.id("level", v.LevelID)
+	case *tl.Stop:
+		v.StopID = f.id("stop", v.StopID)
+		v.ZoneID = f.id("zone", v.ZoneID)
+	case *tl.Pathway:
+		v.PathwayID = f.id("pathway", v.PathwayID)
+	case *tl.FareAttribute:
+		v.FareID = f.id("fare", v.FareID)
+	case *tl.FareRule:
+		v.OriginID = f.id("zone", v.OriginID)
+		v.DestinationID = f.id("zone", v.DestinationID)
+		v.ContainsID = f.id("zone", v.ContainsID)
+	case *tl.Route:
+		v.RouteID = f.id("route", v.RouteID)
+	case *tl.Calendar:
+		if !f.services[v.EntityID()] {
+			return errors.New("no service in date range")
+		}
+		if v.Generated {
+			// Created during import; references use the original service_id
+			emap.Set("calendar.txt", v.EntityID(), f.id("service", v.ServiceID))
+			return errors.New("generated calendar")
+		}
+		f.clipCalendar(v)
+		v.ServiceID = f.id("service", v.ServiceID)
+	case *tl.CalendarDate:
+		if !f.services[v.ServiceID] || !f.inRange(v.Date) {
+			return errors.New("no service in date range")
+		}
+		if f.calendarless[v.ServiceID] {
+			// Services without a Calendar are not checked; see Copier.copyCalendars
+			emap.Set("calendar.txt", v.ServiceID, f.id("service", v.ServiceID))
+		}
+	case *tl.Shape:
+		v.ShapeID = f.id("shape", v.ShapeID)
+	case *tl.Trip:
+		if !f.services[v.ServiceID] {
+			f.skipTrips[v.EntityID()] = true
+			return errors.New("no service in date range")
+		}
+		v.TripID = f.id("trip", v.TripID)
+		v.BlockID = f.id("block", v.BlockID)
+	case *tl.StopTime:
+		if f.skipTrips[v.TripID] {
+			return errors.New("trip has no service in date range")
+		}
+	case *tl.Frequency:
+		if f.skipTrips[v.TripID] {
+			return errors.New("trip has no service in date range")
+		}
+	case *tl.FeedInfo:
+		if f.skipFeedInfo {
+			return errors.New("feed info from older feed version")
+		}
+		if f.clearFeedStart {
+			v.FeedStartDate = tl.OptionalTime{}
+		}
+	}
+	return nil
+}
+
+// clipCalendar clips the calendar dates to the date range.
+// Calendars with only added dates in the range keep a single date and have no regular service.
+func (f *clipFilter) clipCalendar(c *tl.Calendar) {
+	start, end := c.StartDate, c.EndDate
+	if !f.start.IsZero() && start.Before(f.start) {
+		start = f.start
+	}
+	if !f.end.IsZero() && end.After(f.end) {
+		end = f.end
+	}
+	if !start.After(end) {
+		c.StartDate, c.EndDate = start, end
+		return
+	}
+	if !f.start.IsZero() && c.EndDate.Before(f.start) {
+		c.StartDate, c.EndDate = f.start, f.start
+	} else {
+		c.StartDate, c.EndDate = f.end, f.end
+	}
+	c.Monday, c.Tuesday, c.Wednesday, c.Thursday, c.Friday, c.Saturday, c.Sunday = 0, 0, 0, 0, 0, 0, 0
+}
@@ -0,0 +1,180 @@
+// Package merge combines two versions of a feed into a single feed.
+package merge
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/interline-io/transitland-lib/copier"
+	"github.com/interline-io/transitland-lib/tl"
+)
+
+// Options sets options for merging feed versions.
+type Options struct {
+	Cutoff time.Time // the first day of service from the newer feed version; defaults to the first day of service in the newer feed version
+	Prefix string    // namespace for IDs in the older feed version that are also used by the newer feed version; defaults to "old"
+}
+
+// Result contains the results of copying each feed version.
+type Result struct {
+	Cutoff time.Time
+	Older  *copier.CopyResult
+	Newer  *copier.CopyResult
+}
+
+// MergeReaders writes two feed versions as a single feed, e.g. to bridge the gap between an expiring feed version and a newer feed version that starts later.
+// Service from the older feed version is clipped to end the day before the cutoff, and service from the newer feed version starts on the cutoff.
+// Calendars are clipped to these dates, and calendar dates, trips, stop_times, and frequencies without service in the clipped dates are not written.
+// IDs in the older feed version that are also used by the newer feed version are prefixed with the namespace, e.g. "old:STOP1".
+// IDs in the newer feed version are not changed. Only the FeedInfo from the newer feed version is written, without a start date.
+func MergeReaders(ctx context.Context, older tl.Reader, newer tl.Reader, writer tl.Writer, opts Options) (Result, error) {
+	result := Result{}
+	newerServices, err := readServices(newer)
+	if err != nil {
+		return result, err
+	}
+	olderServices, err := readServices(older)
+	if err != nil {
+		return result, err
+	}
+	cutoff := opts.Cutoff
+	if cutoff.IsZero() {
+		for _, s := range newerServices {
+			if start, _ := s.ServicePeriod(); !start.IsZero() && (cutoff.IsZero() || start.Before(cutoff)) {
+				cutoff = start
+			}
+		}
+	}
+	if cutoff.IsZero() {
+		return result, errors.New("newer feed version has no service")
+	}
+	result.Cutoff = cutoff
+	prefix := opts.Prefix
+	if prefix == "" {
+		prefix = "old"
+	}
+	ids, err := readIDs(newer, newerServices)
+	if err != nil {
+		return result, err
+	}
+	olderFilter := &clipFilter{
+		end:          cutoff.AddDate(0, 0, -1),
+		prefix:       prefix,
+		collisions:   ids,
+		skipFeedInfo: true,
+	}
+	olderFilter.setServices(olderServices)
+	newerFilter := &clipFilter{start: cutoff, clearFeedStart: true}
+	newerFilter.setServices(newerServices)
+	// Write the older feed version first
+	cp := copier.NewCopier(older, writer)
+	cp.AddEntityFilter(olderFilter)
+	result.Older = cp.CopyContext(ctx)
+	if result.Older.WriteError != nil {
+		return result, result.Older.WriteError
+	}
+	cp = copier.NewCopier(newer, writer)
+	cp.AddEntityFilter(newerFilter)
+	result.Newer = cp.CopyContext(ctx)
+	if result.Newer.WriteError != nil {
+		return result, result.Newer.WriteError
+	}
+	return result, nil
+}
+
+// readServices returns the Service for each service ID, using the IDs used by references in the reader.
+func readServices(reader tl.Reader) (map[string]*tl.Service, error) {
+	cds := map[string][]tl.CalendarDate{}
+	it := tl.NewIterator(reader, &tl.CalendarDate{})
+	defer it.Close()
+	for it.Next() {
+		cd := *it.Value().(*tl.CalendarDate)
+		cds[cd.ServiceID] = append(cds[cd.ServiceID], cd)
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	services := map[string]*tl.Service{}
+	it = tl.NewIterator(reader, &tl.Calendar{})
+	defer it.Close()
+	for it.Next() {
+		c := *it.Value().(*tl.Calendar)
+		sid := c.EntityID()
+		services[sid] = tl.NewService(c, cds[sid]...)
+		delete(cds, sid)
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	for sid, v := range cds {
+		services[sid] = tl.NewService(tl.Calendar{ServiceID: sid}, v...)
+	}
+	return services, nil
+}
+
+// readIDs returns the GTFS IDs used by the reader, by kind.
+func readIDs(reader tl.Reader, services map[string]*tl.Service) (map[string]map[string]bool, error) {
+	ids := map[string]map[string]bool{}
+	add := func(kind string, id string) {
+		if _, ok := ids[kind]; !ok {
+			ids[kind] = map[string]bool{}
+		}
+		ids[kind][id] = true
+	}
+	for sid, s := range services {
+		if s.StartDate.IsZero() {
+			// Services with only calendar dates
+			add("service", sid)
+		}
+	}
+	ents := []tl.Entity{
+		&tl.Agency{},
+		&tl.Level{},
+		&tl.Stop{},
+		&tl.Pathway{},
+		&tl.FareAttribute{},
+		&tl.FareRule{},
+		&tl.Route{},
+		&tl.Calendar{},
+		&tl.Shape{},
+		&tl.Trip{},
+	}
+	for _, ent := range ents {
+		it := tl.NewIterator(reader, ent)
+		for it.Next() {
+			switch v := it.Value().(type) {
+			case *tl.Agency:
+				add("agency", v.AgencyID)
+			case *tl.Level:
+				add("level", v.LevelID)
+			case *tl.Stop:
+				add("stop", v.StopID)
+				add("zone", v.ZoneID)
+			case *tl.Pathway:
+				add("pathway", v.PathwayID)
+			case *tl.FareAttribute:
+				add("fare", v.FareID)
+			case *tl.FareRule:
+				add("zone", v.OriginID)
+				add("zone", v.DestinationID)
+				add("zone", v.ContainsID)
+			case *tl.Route:
+				add("route", v.RouteID)
+			case *tl.Calendar:
+				add("service", v.ServiceID)
+			case *tl.Shape:
+				add("shape", v.ShapeID)
+			case *tl.Trip:
+				add("trip", v.TripID)
+				add("block", v.BlockID)
+			}
+		}
+		err := it.Err()
+		it.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,189 @@
+package merge
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/interline-io/transitland-lib/copier"
+	"github.com/interline-io/transitland-lib/internal/mock"
+	"github.com/interline-io/transitland-lib/internal/testutil"
+	"github.com/interline-io/transitland-lib/tl"
+	"github.com/interline-io/transitland-lib/tlcsv"
+)
+
+func date(s string) time.Time {
+	t, _ := time.Parse("2006-01-02", s)
+	return t
+}
+
+// newerExample creates a copy of the example feed with service from 2009 to 2011.
+func newerExample(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "merge")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, err := ioutil.ReadDir(testutil.ExampleDir.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, fi := range files {
+		if fi.IsDir() {
+			continue
+		}
+		data, err := ioutil.ReadFile(filepath.Join(testutil.ExampleDir.URL, fi.Name()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		switch fi.Name() {
+		case "calendar.txt":
+			data = []byte("service_id,monday,tuesday,wednesday,thursday,friday,saturday,sunday,start_date,end_date\nFULLW,1,1,1,1,1,1,1,20090101,20111231\nWE,0,0,0,0,0,1,1,20090101,20111231\n")
+		case "calendar_dates.txt":
+			data = []byte("service_id,date,exception_type\nFULLW,20090604,2\nGENCAL,20090604,1\n")
+		}
+		if err := ioutil.WriteFile(filepath.Join(dir, fi.Name()), data, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// mergeExample merges the example feed with the newer example feed and returns a reader for the result.
+// The returned function removes the result.
+func mergeExample(t *testing.T, opts Options) (Result, tl.Reader, func()) {
+	newerDir := newerExample(t)
+	defer os.RemoveAll(newerDir)
+	older, err := tlcsv.NewReader(testutil.ExampleDir.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newer, err := tlcsv.NewReader(newerDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range []*tlcsv.Reader{older, newer} {
+		if err := r.Open(); err != nil {
+			t.Fatal(err)
+		}
+		defer r.Close()
+	}
+	outDir, err := ioutil.TempDir("", "merge")
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer, err := tlcsv.NewWriter(outDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := MergeReaders(context.Background(), older, newer, writer, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer.Close()
+	reader, err := tlcsv.NewReader(outDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := reader.Open(); err != nil {
+		t.Fatal(err)
+	}
+	return result, reader, func() {
+		reader.Close()
+		os.RemoveAll(outDir)
+	}
+}
+
+func TestMergeReaders(t *testing.T) {
+	result, reader, cleanup := mergeExample(t, Options{})
+	defer cleanup()
+	if !result.Cutoff.Equal(date("2009-01-01")) {
+		t.Errorf("got cutoff %s, expected 2009-01-01", result.Cutoff)
+	}
+	// Older calendars end the day before the cutoff; IDs used by both feed versions are namespaced
+	calendars := map[string][2]string{}
+	for c := range reader.Calendars() {
+		calendars[c.ServiceID] = [2]string{c.StartDate.Format("2006-01-02"), c.EndDate.Format("2006-01-02")}
+	}
+	expectCalendars := map[string][2]string{
+		"old:FULLW": {"2007-01-01", "2008-12-31"},
+		"old:WE":    {"2007-01-01", "2008-12-31"},
+		"FULLW":     {"2009-01-01", "2011-12-31"},
+		"WE":        {"2009-01-01", "2011-12-31"},
+	}
+	if !testutil.CompareSliceString(keys(calendars), keys(expectCalendars)) {
+		t.Errorf("got calendars %v, expected %v", keys(calendars), keys(expectCalendars))
+	}
+	for k, v := range expectCalendars {
+		if calendars[k] != v {
+			t.Errorf("calendar %s: got %v, expected %v", k, calendars[k], v)
+		}
+	}
+	cds := []string{}
+	for cd := range reader.CalendarDates() {
+		cds = append(cds, cd.ServiceID+":"+cd.Date.Format("20060102"))
+	}
+	if exp := []string{"old:FULLW:20070604", "old:GENCAL:20070604", "FULLW:20090604", "GENCAL:20090604"}; !testutil.CompareSliceString(cds, exp) {
+		t.Errorf("got calendar dates %v, expected %v", cds, exp)
+	}
+	feedInfos := 0
+	for fi := range reader.FeedInfos() {
+		feedInfos++
+		if fi.FeedStartDate.Valid {
+			t.Errorf("got feed_start_date %s, expected none", fi.FeedStartDate.Time)
+		}
+	}
+	if feedInfos != 1 {
+		t.Errorf("got %d feed_info rows, expected 1", feedInfos)
+	}
+	// All references are valid
+	cp := copier.NewCopier(reader, mock.NewWriter())
+	cpResult := cp.Copy()
+	for _, fn := range []string{"agency.txt", "routes.txt", "stops.txt", "trips.txt", "stop_times.txt", "fare_attributes.txt", "fare_rules.txt"} {
+		if got, exp := cpResult.EntityCount[fn], testutil.ExampleDir.Counts[fn]*2; got != exp {
+			t.Errorf("%s: got %d entities, expected %d", fn, got, exp)
+		}
+	}
+	trips := map[string]bool{}
+	for trip := range reader.Trips() {
+		trips[trip.TripID] = true
+	}
+	for _, tripID := range testutil.ExampleDir.EntityIDs["trips.txt"] {
+		if !trips[tripID] || !trips["old:"+tripID] {
+			t.Errorf("expected trips %s and old:%s", tripID, tripID)
+		}
+	}
+}
+
+func TestMergeReaders_Cutoff(t *testing.T) {
+	// Service from the newer feed version starts after its first date
+	result, reader, cleanup := mergeExample(t, Options{Cutoff: date("2010-01-01"), Prefix: "v1"})
+	defer cleanup()
+	if got := result.Older.EntityCount["calendar_dates.txt"]; got != 2 {
+		t.Errorf("got %d older calendar dates, expected 2", got)
+	}
+	// GENCAL has no service after the cutoff
+	if got := result.Newer.SkipEntityFilterCount["calendar_dates.txt"]; got != 2 {
+		t.Errorf("got %d newer calendar dates skipped, expected 2", got)
+	}
+	calendars := map[string]string{}
+	for c := range reader.Calendars() {
+		calendars[c.ServiceID] = c.StartDate.Format("2006-01-02") + "/" + c.EndDate.Format("2006-01-02")
+	}
+	if v := calendars["v1:FULLW"]; v != "2007-01-01/2009-12-31" {
+		t.Errorf("got %s, expected 2007-01-01/2009-12-31", v)
+	}
+	if v := calendars["FULLW"]; v != "2010-01-01/2011-12-31" {
+		t.Errorf("got %s, expected 2010-01-01/2011-12-31", v)
+	}
+}
+
+func keys(m map[string][2]string) []string {
+	ret := []string{}
+	for k := range m {
+		ret = append(ret, k)
+	}
+	return ret
+}
@@ -0,0 +1,2 @@
+ALTER TABLE public.feed_version_gtfs_imports DROP COLUMN IF EXISTS checkpoint;
+ALTER TABLE public.feed_version_gtfs_imports DROP COLUMN IF EXISTS progress;
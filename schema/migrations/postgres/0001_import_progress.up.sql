@@ -0,0 +1,2 @@
+ALTER TABLE public.feed_version_gtfs_imports ADD COLUMN IF NOT EXISTS progress double precision DEFAULT 0 NOT NULL;
+ALTER TABLE public.feed_version_gtfs_imports ADD COLUMN IF NOT EXISTS checkpoint text DEFAULT ''::text NOT NULL;
@@ -0,0 +1,2 @@
+-- The unique indexes were part of the Postgres schema before migrations were added, and are kept.
+SELECT 1;
@@ -0,0 +1,3 @@
+-- The unique indexes on (feed_version_id, <GTFS ID>) are already part of the Postgres schema.
+-- This migration keeps the Postgres and SQLite migration versions in step.
+SELECT 1;
@@ -0,0 +1,15 @@
+ALTER TABLE public.gtfs_stops DROP COLUMN IF EXISTS extra;
+ALTER TABLE public.gtfs_pathways DROP COLUMN IF EXISTS extra;
+ALTER TABLE public.gtfs_levels DROP COLUMN IF EXISTS extra;
+ALTER TABLE public.gtfs_shapes DROP COLUMN IF EXISTS extra;
+ALTER TABLE public.gtfs_feed_infos DROP COLUMN IF EXISTS extra;
+ALTER TABLE public.gtfs_frequencies DROP COLUMN IF EXISTS extra;
+ALTER TABLE public.gtfs_trips DROP COLUMN IF EXISTS extra;
+ALTER TABLE public.gtfs_agencies DROP COLUMN IF EXISTS extra;
+ALTER TABLE public.gtfs_transfers DROP COLUMN IF EXISTS extra;
+ALTER TABLE public.gtfs_calendars DROP COLUMN IF EXISTS extra;
+ALTER TABLE public.gtfs_calendar_dates DROP COLUMN IF EXISTS extra;
+ALTER TABLE public.gtfs_routes DROP COLUMN IF EXISTS extra;
+ALTER TABLE public.gtfs_stop_times DROP COLUMN IF EXISTS extra;
+ALTER TABLE public.gtfs_fare_rules DROP COLUMN IF EXISTS extra;
+ALTER TABLE public.gtfs_fare_attributes DROP COLUMN IF EXISTS extra;
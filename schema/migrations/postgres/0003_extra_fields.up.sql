@@ -0,0 +1,15 @@
+ALTER TABLE public.gtfs_stops ADD COLUMN IF NOT EXISTS extra jsonb;
+ALTER TABLE public.gtfs_pathways ADD COLUMN IF NOT EXISTS extra jsonb;
+ALTER TABLE public.gtfs_levels ADD COLUMN IF NOT EXISTS extra jsonb;
+ALTER TABLE public.gtfs_shapes ADD COLUMN IF NOT EXISTS extra jsonb;
+ALTER TABLE public.gtfs_feed_infos ADD COLUMN IF NOT EXISTS extra jsonb;
+ALTER TABLE public.gtfs_frequencies ADD COLUMN IF NOT EXISTS extra jsonb;
+ALTER TABLE public.gtfs_trips ADD COLUMN IF NOT EXISTS extra jsonb;
+ALTER TABLE public.gtfs_agencies ADD COLUMN IF NOT EXISTS extra jsonb;
+ALTER TABLE public.gtfs_transfers ADD COLUMN IF NOT EXISTS extra jsonb;
+ALTER TABLE public.gtfs_calendars ADD COLUMN IF NOT EXISTS extra jsonb;
+ALTER TABLE public.gtfs_calendar_dates ADD COLUMN IF NOT EXISTS extra jsonb;
+ALTER TABLE public.gtfs_routes ADD COLUMN IF NOT EXISTS extra jsonb;
+ALTER TABLE public.gtfs_stop_times ADD COLUMN IF NOT EXISTS extra jsonb;
+ALTER TABLE public.gtfs_fare_rules ADD COLUMN IF NOT EXISTS extra jsonb;
+ALTER TABLE public.gtfs_fare_attributes ADD COLUMN IF NOT EXISTS extra jsonb;
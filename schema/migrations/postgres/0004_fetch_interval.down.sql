@@ -0,0 +1 @@
+ALTER TABLE public.feed_states DROP COLUMN IF EXISTS fetch_interval;
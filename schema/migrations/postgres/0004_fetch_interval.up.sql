@@ -0,0 +1 @@
+ALTER TABLE public.feed_states ADD COLUMN IF NOT EXISTS fetch_interval integer;
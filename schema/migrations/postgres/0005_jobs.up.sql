@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS public.dmfr_jobs (
+    id bigserial PRIMARY KEY,
+    job_type character varying NOT NULL,
+    feed_id bigint REFERENCES public.current_feeds(id),
+    feed_version_id bigint REFERENCES public.feed_versions(id),
+    status character varying DEFAULT 'pending'::character varying NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    run_at timestamp without time zone NOT NULL,
+    locked_at timestamp without time zone,
+    locked_by character varying DEFAULT ''::character varying NOT NULL,
+    last_error text DEFAULT ''::text NOT NULL,
+    created_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL
+);
+CREATE INDEX IF NOT EXISTS index_dmfr_jobs_on_status_and_run_at ON public.dmfr_jobs USING btree (status, run_at);
+-- At most one pending or running job of each type for a feed or feed version; the other ID is NULL
+CREATE UNIQUE INDEX IF NOT EXISTS index_dmfr_jobs_unique ON public.dmfr_jobs USING btree (job_type, COALESCE(feed_id, 0), COALESCE(feed_version_id, 0)) WHERE status IN ('pending', 'running');
@@ -0,0 +1,22 @@
+CREATE TABLE "feed_version_gtfs_imports_down" (
+  "id" integer primary key autoincrement,
+  "feed_version_id" integer NOT NULL,
+  "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL,
+  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL,
+  "success" bool,
+  "import_log" blob,
+  "in_progress" bool,
+  "exception_log" blob,
+  "import_level" integer not null,
+  "interpolated_stop_time_count" integer not null,
+  "skip_entity_error_count" blob,
+  "skip_entity_reference_count" blob,
+  "skip_entity_marked_count" blob,
+  "skip_entity_filter_count" blob,
+  "generated_count" blob,
+  "warning_count" blob,
+  "entity_count" blob
+);
+INSERT INTO "feed_version_gtfs_imports_down" ("id", "feed_version_id", "created_at", "updated_at", "success", "import_log", "in_progress", "exception_log", "import_level", "interpolated_stop_time_count", "skip_entity_error_count", "skip_entity_reference_count", "skip_entity_marked_count", "skip_entity_filter_count", "generated_count", "warning_count", "entity_count") SELECT "id", "feed_version_id", "created_at", "updated_at", "success", "import_log", "in_progress", "exception_log", "import_level", "interpolated_stop_time_count", "skip_entity_error_count", "skip_entity_reference_count", "skip_entity_marked_count", "skip_entity_filter_count", "generated_count", "warning_count", "entity_count" FROM "feed_version_gtfs_imports";
+DROP TABLE "feed_version_gtfs_imports";
+ALTER TABLE "feed_version_gtfs_imports_down" RENAME TO "feed_version_gtfs_imports";
@@ -0,0 +1,2 @@
+ALTER TABLE "feed_version_gtfs_imports" ADD COLUMN "progress" real DEFAULT 0 NOT NULL;
+ALTER TABLE "feed_version_gtfs_imports" ADD COLUMN "checkpoint" text DEFAULT '' NOT NULL;
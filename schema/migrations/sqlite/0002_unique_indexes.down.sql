@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS idx_gtfs_pathways_unique;
+DROP INDEX IF EXISTS idx_gtfs_levels_unique;
+DROP INDEX IF EXISTS idx_gtfs_stops_unique;
+DROP INDEX IF EXISTS idx_gtfs_shapes_unique;
+DROP INDEX IF EXISTS idx_gtfs_trips_unique;
+DROP INDEX IF EXISTS idx_gtfs_agencies_unique;
+DROP INDEX IF EXISTS idx_gtfs_calendars_unique;
+DROP INDEX IF EXISTS idx_gtfs_routes_unique;
+DROP INDEX IF EXISTS idx_gtfs_stop_times_unique;
+DROP INDEX IF EXISTS idx_gtfs_fare_attributes_unique;
@@ -0,0 +1,10 @@
+CREATE UNIQUE INDEX IF NOT EXISTS idx_gtfs_pathways_unique ON "gtfs_pathways"(feed_version_id, pathway_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_gtfs_levels_unique ON "gtfs_levels"(feed_version_id, level_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_gtfs_stops_unique ON "gtfs_stops"(feed_version_id, stop_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_gtfs_shapes_unique ON "gtfs_shapes"(feed_version_id, shape_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_gtfs_trips_unique ON "gtfs_trips"(feed_version_id, trip_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_gtfs_agencies_unique ON "gtfs_agencies"(feed_version_id, agency_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_gtfs_calendars_unique ON "gtfs_calendars"(feed_version_id, service_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_gtfs_routes_unique ON "gtfs_routes"(feed_version_id, route_id);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_gtfs_stop_times_unique ON "gtfs_stop_times"(feed_version_id, trip_id, stop_sequence);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_gtfs_fare_attributes_unique ON "gtfs_fare_attributes"(feed_version_id, fare_id);
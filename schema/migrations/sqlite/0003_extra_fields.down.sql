@@ -0,0 +1,316 @@
+CREATE TABLE "gtfs_stops_down" (
+  "id" integer primary key autoincrement,
+  "feed_version_id" integer NOT NULL,
+  "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL,
+  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL,
+  "stop_id" varchar(255) NOT NULL,
+  "stop_name" varchar(255) NOT NULL,
+  "stop_code" varchar(255) NOT NULL,
+  "stop_desc" varchar(255) NOT NULL,
+  "zone_id" varchar(255) NOT NULL,
+  "stop_url" varchar(255) NOT NULL,
+  "location_type" integer NOT NULL,
+  "parent_station" integer,
+  "stop_timezone" varchar(255) NOT NULL,
+  "wheelchair_boarding" integer NOT NULL,
+  "level_id" integer,
+  "geometry" BLOB NOT NULL
+);
+INSERT INTO "gtfs_stops_down" ("id", "feed_version_id", "created_at", "updated_at", "stop_id", "stop_name", "stop_code", "stop_desc", "zone_id", "stop_url", "location_type", "parent_station", "stop_timezone", "wheelchair_boarding", "level_id", "geometry") SELECT "id", "feed_version_id", "created_at", "updated_at", "stop_id", "stop_name", "stop_code", "stop_desc", "zone_id", "stop_url", "location_type", "parent_station", "stop_timezone", "wheelchair_boarding", "level_id", "geometry" FROM "gtfs_stops";
+DROP TABLE "gtfs_stops";
+ALTER TABLE "gtfs_stops_down" RENAME TO "gtfs_stops";
+CREATE INDEX idx_gtfs_stops_stop_id ON "gtfs_stops"(stop_id);
+CREATE INDEX idx_gtfs_stops_parent_station ON "gtfs_stops"(parent_station);
+CREATE INDEX idx_gtfs_stops_feed_version_id ON "gtfs_stops"(feed_version_id);
+CREATE UNIQUE INDEX idx_gtfs_stops_unique ON "gtfs_stops"(feed_version_id, stop_id);
+
+CREATE TABLE "gtfs_pathways_down" (
+  "id" integer primary key autoincrement,
+  "feed_version_id" integer NOT NULL,
+  "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL,
+  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL,
+  "pathway_id" varchar(255) NOT NULL,
+  "from_stop_id" integer NOT NULL,
+  "to_stop_id" integer NOT NULL,
+  "pathway_mode" integer NOT NULL,
+  "is_bidirectional" integer NOT NULL,
+  "length" real NOT NULL,
+  "traversal_time" integer NOT NULL,
+  "stair_count" integer NOT NULL,
+  "max_slope" real NOT NULL,
+  "min_width" real NOT NULL,
+  "signposted_as" varchar(255) NOT NULL,
+  "reverse_signposted_as" varchar(255) NOT NULL
+);
+INSERT INTO "gtfs_pathways_down" ("id", "feed_version_id", "created_at", "updated_at", "pathway_id", "from_stop_id", "to_stop_id", "pathway_mode", "is_bidirectional", "length", "traversal_time", "stair_count", "max_slope", "min_width", "signposted_as", "reverse_signposted_as") SELECT "id", "feed_version_id", "created_at", "updated_at", "pathway_id", "from_stop_id", "to_stop_id", "pathway_mode", "is_bidirectional", "length", "traversal_time", "stair_count", "max_slope", "min_width", "signposted_as", "reverse_signposted_as" FROM "gtfs_pathways";
+DROP TABLE "gtfs_pathways";
+ALTER TABLE "gtfs_pathways_down" RENAME TO "gtfs_pathways";
+CREATE UNIQUE INDEX idx_gtfs_pathways_unique ON "gtfs_pathways"(feed_version_id, pathway_id);
+
+CREATE TABLE "gtfs_levels_down" (
+  "id" integer primary key autoincrement,
+  "feed_version_id" integer NOT NULL,
+  "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL,
+  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL,
+  "level_id" varchar(255) NOT NULL,
+  "level_index" real NOT NULL,
+  "level_name" varchar(255) NOT NULL
+);
+INSERT INTO "gtfs_levels_down" ("id", "feed_version_id", "created_at", "updated_at", "level_id", "level_index", "level_name") SELECT "id", "feed_version_id", "created_at", "updated_at", "level_id", "level_index", "level_name" FROM "gtfs_levels";
+DROP TABLE "gtfs_levels";
+ALTER TABLE "gtfs_levels_down" RENAME TO "gtfs_levels";
+CREATE UNIQUE INDEX idx_gtfs_levels_unique ON "gtfs_levels"(feed_version_id, level_id);
+
+CREATE TABLE "gtfs_shapes_down" (
+  "id" integer primary key autoincrement,
+  "feed_version_id" integer NOT NULL,
+  "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL,
+  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL,
+  "shape_id" varchar(255) NOT NULL,
+  "generated" bool NOT NULL,
+  "geometry" BLOB NOT NULL
+);
+INSERT INTO "gtfs_shapes_down" ("id", "feed_version_id", "created_at", "updated_at", "shape_id", "generated", "geometry") SELECT "id", "feed_version_id", "created_at", "updated_at", "shape_id", "generated", "geometry" FROM "gtfs_shapes";
+DROP TABLE "gtfs_shapes";
+ALTER TABLE "gtfs_shapes_down" RENAME TO "gtfs_shapes";
+CREATE INDEX idx_gtfs_shapes_shape_id ON "gtfs_shapes"(shape_id);
+CREATE INDEX idx_gtfs_shapes_feed_version_id ON "gtfs_shapes"(feed_version_id);
+CREATE UNIQUE INDEX idx_gtfs_shapes_unique ON "gtfs_shapes"(feed_version_id, shape_id);
+
+CREATE TABLE "gtfs_feed_infos_down" (
+  "feed_publisher_name" varchar(255) NOT NULL,
+  "feed_publisher_url" varchar(255) NOT NULL,
+  "feed_lang" varchar(255) NOT NULL,
+  "feed_start_date" datetime,
+  "feed_end_date" datetime,
+  "feed_version_name" varchar(255) NOT NULL,
+  "id" integer primary key autoincrement,
+  "feed_version_id" integer NOT NULL,
+  "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL,
+  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+INSERT INTO "gtfs_feed_infos_down" ("feed_publisher_name", "feed_publisher_url", "feed_lang", "feed_start_date", "feed_end_date", "feed_version_name", "id", "feed_version_id", "created_at", "updated_at") SELECT "feed_publisher_name", "feed_publisher_url", "feed_lang", "feed_start_date", "feed_end_date", "feed_version_name", "id", "feed_version_id", "created_at", "updated_at" FROM "gtfs_feed_infos";
+DROP TABLE "gtfs_feed_infos";
+ALTER TABLE "gtfs_feed_infos_down" RENAME TO "gtfs_feed_infos";
+CREATE INDEX idx_gtfs_feed_infos_feed_version_id ON "gtfs_feed_infos"(feed_version_id);
+
+CREATE TABLE "gtfs_frequencies_down" (
+  "trip_id" int NOT NULL,
+  "start_time" int NOT NULL,
+  "end_time" int NOT NULL,
+  "headway_secs" integer NOT NULL,
+  "exact_times" integer NOT NULL,
+  "id" integer primary key autoincrement,
+  "feed_version_id" integer NOT NULL,
+  "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL,
+  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+INSERT INTO "gtfs_frequencies_down" ("trip_id", "start_time", "end_time", "headway_secs", "exact_times", "id", "feed_version_id", "created_at", "updated_at") SELECT "trip_id", "start_time", "end_time", "headway_secs", "exact_times", "id", "feed_version_id", "created_at", "updated_at" FROM "gtfs_frequencies";
+DROP TABLE "gtfs_frequencies";
+ALTER TABLE "gtfs_frequencies_down" RENAME TO "gtfs_frequencies";
+CREATE INDEX idx_gtfs_frequencies_trip_id ON "gtfs_frequencies"(trip_id);
+CREATE INDEX idx_gtfs_frequencies_feed_version_id ON "gtfs_frequencies"(feed_version_id);
+
+CREATE TABLE "gtfs_trips_down" (
+  "route_id" int NOT NULL,
+  "service_id" int NOT NULL,
+  "trip_id" varchar(255) NOT NULL,
+  "trip_headsign" varchar(255) NOT NULL,
+  "trip_short_name" varchar(255) NOT NULL,
+  "direction_id" integer NOT NULL,
+  "block_id" varchar(255) NOT NULL,
+  "shape_id" int,
+  "wheelchair_accessible" integer NOT NULL,
+  "bikes_allowed" integer NOT NULL,
+  "stop_pattern_id" integer NOT NULL,
+  "id" integer primary key autoincrement,
+  "feed_version_id" integer NOT NULL,
+  "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL,
+  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+INSERT INTO "gtfs_trips_down" ("route_id", "service_id", "trip_id", "trip_headsign", "trip_short_name", "direction_id", "block_id", "shape_id", "wheelchair_accessible", "bikes_allowed", "stop_pattern_id", "id", "feed_version_id", "created_at", "updated_at") SELECT "route_id", "service_id", "trip_id", "trip_headsign", "trip_short_name", "direction_id", "block_id", "shape_id", "wheelchair_accessible", "bikes_allowed", "stop_pattern_id", "id", "feed_version_id", "created_at", "updated_at" FROM "gtfs_trips";
+DROP TABLE "gtfs_trips";
+ALTER TABLE "gtfs_trips_down" RENAME TO "gtfs_trips";
+CREATE INDEX idx_gtfs_trips_route_id ON "gtfs_trips"(route_id);
+CREATE INDEX idx_gtfs_trips_service_id ON "gtfs_trips"(service_id);
+CREATE INDEX idx_gtfs_trips_trip_id ON "gtfs_trips"(trip_id);
+CREATE INDEX idx_gtfs_trips_shape_id ON "gtfs_trips"(shape_id);
+CREATE INDEX idx_gtfs_trips_stop_pattern_id ON "gtfs_trips"(stop_pattern_id);
+CREATE INDEX idx_gtfs_trips_feed_version_id ON "gtfs_trips"(feed_version_id);
+CREATE UNIQUE INDEX idx_gtfs_trips_unique ON "gtfs_trips"(feed_version_id, trip_id);
+
+CREATE TABLE "gtfs_agencies_down" (
+  "agency_id" varchar(255) NOT NULL,
+  "agency_name" varchar(255) NOT NULL,
+  "agency_url" varchar(255) NOT NULL,
+  "agency_timezone" varchar(255) NOT NULL,
+  "agency_lang" varchar(255) NOT NULL,
+  "agency_phone" varchar(255) NOT NULL,
+  "agency_fare_url" varchar(255) NOT NULL,
+  "agency_email" varchar(255) NOT NULL,
+  "id" integer primary key autoincrement,
+  "feed_version_id" int NOT NULL,
+  "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL,
+  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+INSERT INTO "gtfs_agencies_down" ("agency_id", "agency_name", "agency_url", "agency_timezone", "agency_lang", "agency_phone", "agency_fare_url", "agency_email", "id", "feed_version_id", "created_at", "updated_at") SELECT "agency_id", "agency_name", "agency_url", "agency_timezone", "agency_lang", "agency_phone", "agency_fare_url", "agency_email", "id", "feed_version_id", "created_at", "updated_at" FROM "gtfs_agencies";
+DROP TABLE "gtfs_agencies";
+ALTER TABLE "gtfs_agencies_down" RENAME TO "gtfs_agencies";
+CREATE INDEX idx_gtfs_agencies_agency_id ON "gtfs_agencies"(agency_id);
+CREATE INDEX idx_gtfs_agencies_feed_version_id ON "gtfs_agencies"(feed_version_id);
+CREATE UNIQUE INDEX idx_gtfs_agencies_unique ON "gtfs_agencies"(feed_version_id, agency_id);
+
+CREATE TABLE "gtfs_transfers_down" (
+  "from_stop_id" int NOT NULL,
+  "to_stop_id" int NOT NULL,
+  "transfer_type" integer NOT NULL,
+  "min_transfer_time" integer,
+  "id" integer primary key autoincrement,
+  "feed_version_id" integer NOT NULL,
+  "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL,
+  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+INSERT INTO "gtfs_transfers_down" ("from_stop_id", "to_stop_id", "transfer_type", "min_transfer_time", "id", "feed_version_id", "created_at", "updated_at") SELECT "from_stop_id", "to_stop_id", "transfer_type", "min_transfer_time", "id", "feed_version_id", "created_at", "updated_at" FROM "gtfs_transfers";
+DROP TABLE "gtfs_transfers";
+ALTER TABLE "gtfs_transfers_down" RENAME TO "gtfs_transfers";
+CREATE INDEX idx_gtfs_transfers_transfer_type ON "gtfs_transfers"(transfer_type);
+CREATE INDEX idx_gtfs_transfers_feed_version_id ON "gtfs_transfers"(feed_version_id);
+CREATE INDEX idx_gtfs_transfers_from_stop_id ON "gtfs_transfers"(from_stop_id);
+CREATE INDEX idx_gtfs_transfers_to_stop_id ON "gtfs_transfers"(to_stop_id);
+
+CREATE TABLE "gtfs_calendars_down" (
+  "service_id" varchar(255) NOT NULL,
+  "monday" integer NOT NULL,
+  "tuesday" integer NOT NULL,
+  "wednesday" integer NOT NULL,
+  "thursday" integer NOT NULL,
+  "friday" integer NOT NULL,
+  "saturday" integer NOT NULL,
+  "sunday" integer NOT NULL,
+  "start_date" datetime NOT NULL,
+  "end_date" datetime NOT NULL,
+  "generated" bool NOT NULL,
+  "id" integer primary key autoincrement,
+  "feed_version_id" integer NOT NULL,
+  "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL,
+  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+INSERT INTO "gtfs_calendars_down" ("service_id", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday", "start_date", "end_date", "generated", "id", "feed_version_id", "created_at", "updated_at") SELECT "service_id", "monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday", "start_date", "end_date", "generated", "id", "feed_version_id", "created_at", "updated_at" FROM "gtfs_calendars";
+DROP TABLE "gtfs_calendars";
+ALTER TABLE "gtfs_calendars_down" RENAME TO "gtfs_calendars";
+CREATE INDEX idx_gtfs_calendars_wednesday ON "gtfs_calendars"("wednesday");
+CREATE INDEX idx_gtfs_calendars_start_date ON "gtfs_calendars"(start_date);
+CREATE INDEX idx_gtfs_calendars_feed_version_id ON "gtfs_calendars"(feed_version_id);
+CREATE UNIQUE INDEX idx_gtfs_calendars_unique ON "gtfs_calendars"(feed_version_id, service_id);
+CREATE INDEX idx_gtfs_calendars_end_date ON "gtfs_calendars"(end_date);
+CREATE INDEX idx_gtfs_calendars_service_id ON "gtfs_calendars"(service_id);
+CREATE INDEX idx_gtfs_calendars_monday ON "gtfs_calendars"("monday");
+CREATE INDEX idx_gtfs_calendars_tuesday ON "gtfs_calendars"("tuesday");
+CREATE INDEX idx_gtfs_calendars_thursday ON "gtfs_calendars"("thursday");
+CREATE INDEX idx_gtfs_calendars_friday ON "gtfs_calendars"("friday");
+CREATE INDEX idx_gtfs_calendars_saturday ON "gtfs_calendars"("saturday");
+CREATE INDEX idx_gtfs_calendars_sunday ON "gtfs_calendars"("sunday");
+
+CREATE TABLE "gtfs_calendar_dates_down" (
+  "service_id" int NOT NULL,
+  "date" datetime NOT NULL,
+  "exception_type" integer NOT NULL,
+  "id" integer primary key autoincrement,
+  "feed_version_id" integer NOT NULL,
+  "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL,
+  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+INSERT INTO "gtfs_calendar_dates_down" ("service_id", "date", "exception_type", "id", "feed_version_id", "created_at", "updated_at") SELECT "service_id", "date", "exception_type", "id", "feed_version_id", "created_at", "updated_at" FROM "gtfs_calendar_dates";
+DROP TABLE "gtfs_calendar_dates";
+ALTER TABLE "gtfs_calendar_dates_down" RENAME TO "gtfs_calendar_dates";
+CREATE INDEX idx_gtfs_calendar_dates_date ON "gtfs_calendar_dates"("date");
+CREATE INDEX idx_gtfs_calendar_dates_exception_type ON "gtfs_calendar_dates"(exception_type);
+CREATE INDEX idx_gtfs_calendar_dates_feed_version_id ON "gtfs_calendar_dates"(feed_version_id);
+CREATE INDEX idx_gtfs_calendar_dates_service_id ON "gtfs_calendar_dates"(service_id);
+
+CREATE TABLE "gtfs_routes_down" (
+  "route_id" varchar(255) NOT NULL,
+  "agency_id" int NOT NULL,
+  "route_short_name" varchar(255) NOT NULL,
+  "route_long_name" varchar(255) NOT NULL,
+  "route_desc" varchar(255) NOT NULL,
+  "route_type" integer NOT NULL,
+  "route_url" varchar(255) NOT NULL,
+  "route_color" varchar(255) NOT NULL,
+  "route_text_color" varchar(255) NOT NULL,
+  "route_sort_order" integer NOT NULL,
+  "id" integer primary key autoincrement,
+  "feed_version_id" integer NOT NULL,
+  "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL,
+  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+INSERT INTO "gtfs_routes_down" ("route_id", "agency_id", "route_short_name", "route_long_name", "route_desc", "route_type", "route_url", "route_color", "route_text_color", "route_sort_order", "id", "feed_version_id", "created_at", "updated_at") SELECT "route_id", "agency_id", "route_short_name", "route_long_name", "route_desc", "route_type", "route_url", "route_color", "route_text_color", "route_sort_order", "id", "feed_version_id", "created_at", "updated_at" FROM "gtfs_routes";
+DROP TABLE "gtfs_routes";
+ALTER TABLE "gtfs_routes_down" RENAME TO "gtfs_routes";
+CREATE INDEX idx_gtfs_routes_route_id ON "gtfs_routes"(route_id);
+CREATE INDEX idx_gtfs_routes_agency_id ON "gtfs_routes"(agency_id);
+CREATE INDEX idx_gtfs_routes_route_type ON "gtfs_routes"(route_type);
+CREATE INDEX idx_gtfs_routes_feed_version_id ON "gtfs_routes"(feed_version_id);
+CREATE UNIQUE INDEX idx_gtfs_routes_unique ON "gtfs_routes"(feed_version_id, route_id);
+
+CREATE TABLE "gtfs_stop_times_down" (
+  "trip_id" int NOT NULL,
+  "arrival_time" int NOT NULL,
+  "departure_time" int NOT NULL,
+  "stop_id" int NOT NULL,
+  "stop_sequence" integer NOT NULL,
+  "stop_headsign" varchar(255) NOT NULL,
+  "pickup_type" integer NOT NULL,
+  "drop_off_type" integer NOT NULL,
+  "shape_dist_traveled" real NOT NULL,
+  "timepoint" integer NOT NULL,
+  "interpolated" integer NOT NULL,
+  "id" integer primary key autoincrement,
+  "feed_version_id" integer NOT NULL,
+  "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL,
+  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+INSERT INTO "gtfs_stop_times_down" ("trip_id", "arrival_time", "departure_time", "stop_id", "stop_sequence", "stop_headsign", "pickup_type", "drop_off_type", "shape_dist_traveled", "timepoint", "interpolated", "id", "feed_version_id", "created_at", "updated_at") SELECT "trip_id", "arrival_time", "departure_time", "stop_id", "stop_sequence", "stop_headsign", "pickup_type", "drop_off_type", "shape_dist_traveled", "timepoint", "interpolated", "id", "feed_version_id", "created_at", "updated_at" FROM "gtfs_stop_times";
+DROP TABLE "gtfs_stop_times";
+ALTER TABLE "gtfs_stop_times_down" RENAME TO "gtfs_stop_times";
+CREATE INDEX idx_stop_times_trip_id ON "gtfs_stop_times"(trip_id);
+CREATE INDEX idx_gtfs_stop_times_stop_id ON "gtfs_stop_times"(stop_id);
+CREATE INDEX idx_gtfs_stop_times_feed_version_id ON "gtfs_stop_times"(feed_version_id);
+CREATE UNIQUE INDEX idx_gtfs_stop_times_unique ON "gtfs_stop_times"(feed_version_id, trip_id, stop_sequence);
+
+CREATE TABLE "gtfs_fare_rules_down" (
+  "fare_id" int NOT NULL,
+  "route_id" int,
+  "origin_id" varchar(255) NOT NULL,
+  "destination_id" varchar(255) NOT NULL,
+  "contains_id" varchar(255) NOT NULL,
+  "id" integer primary key autoincrement,
+  "feed_version_id" integer NOT NULL,
+  "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL,
+  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+INSERT INTO "gtfs_fare_rules_down" ("fare_id", "route_id", "origin_id", "destination_id", "contains_id", "id", "feed_version_id", "created_at", "updated_at") SELECT "fare_id", "route_id", "origin_id", "destination_id", "contains_id", "id", "feed_version_id", "created_at", "updated_at" FROM "gtfs_fare_rules";
+DROP TABLE "gtfs_fare_rules";
+ALTER TABLE "gtfs_fare_rules_down" RENAME TO "gtfs_fare_rules";
+CREATE INDEX idx_gtfs_fare_rules_fare_id ON "gtfs_fare_rules"(fare_id);
+CREATE INDEX idx_gtfs_fare_rules_feed_version_id ON "gtfs_fare_rules"(feed_version_id);
+
+CREATE TABLE "gtfs_fare_attributes_down" (
+  "fare_id" varchar(255) NOT NULL,
+  "price" real NOT NULL,
+  "currency_type" varchar(255) NOT NULL,
+  "payment_method" integer NOT NULL,
+  "transfers" varchar(255),
+  "agency_id" int,
+  "transfer_duration" integer NOT NULL,
+  "id" integer primary key autoincrement,
+  "feed_version_id" integer NOT NULL,
+  "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL,
+  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+INSERT INTO "gtfs_fare_attributes_down" ("fare_id", "price", "currency_type", "payment_method", "transfers", "agency_id", "transfer_duration", "id", "feed_version_id", "created_at", "updated_at") SELECT "fare_id", "price", "currency_type", "payment_method", "transfers", "agency_id", "transfer_duration", "id", "feed_version_id", "created_at", "updated_at" FROM "gtfs_fare_attributes";
+DROP TABLE "gtfs_fare_attributes";
+ALTER TABLE "gtfs_fare_attributes_down" RENAME TO "gtfs_fare_attributes";
+CREATE INDEX idx_gtfs_fare_attributes_fare_id ON "gtfs_fare_attributes"(fare_id);
+CREATE INDEX idx_gtfs_fare_attributes_feed_version_id ON "gtfs_fare_attributes"(feed_version_id);
+CREATE UNIQUE INDEX idx_gtfs_fare_attributes_unique ON "gtfs_fare_attributes"(feed_version_id, fare_id);
@@ -0,0 +1,15 @@
+ALTER TABLE "gtfs_stops" ADD COLUMN "extra" blob;
+ALTER TABLE "gtfs_pathways" ADD COLUMN "extra" blob;
+ALTER TABLE "gtfs_levels" ADD COLUMN "extra" blob;
+ALTER TABLE "gtfs_shapes" ADD COLUMN "extra" blob;
+ALTER TABLE "gtfs_feed_infos" ADD COLUMN "extra" blob;
+ALTER TABLE "gtfs_frequencies" ADD COLUMN "extra" blob;
+ALTER TABLE "gtfs_trips" ADD COLUMN "extra" blob;
+ALTER TABLE "gtfs_agencies" ADD COLUMN "extra" blob;
+ALTER TABLE "gtfs_transfers" ADD COLUMN "extra" blob;
+ALTER TABLE "gtfs_calendars" ADD COLUMN "extra" blob;
+ALTER TABLE "gtfs_calendar_dates" ADD COLUMN "extra" blob;
+ALTER TABLE "gtfs_routes" ADD COLUMN "extra" blob;
+ALTER TABLE "gtfs_stop_times" ADD COLUMN "extra" blob;
+ALTER TABLE "gtfs_fare_rules" ADD COLUMN "extra" blob;
+ALTER TABLE "gtfs_fare_attributes" ADD COLUMN "extra" blob;
@@ -0,0 +1,18 @@
+CREATE TABLE "feed_states_down" (
+    "id" integer primary key autoincrement,
+    "feed_id" integer NOT NULL,
+    "feed_version_id" integer,
+    "feed_realtime_enabled" bool not null,
+    "feed_priority" integer,
+    "last_fetched_at" datetime,
+    "last_successful_fetch_at" datetime,
+    "last_imported_at" datetime,
+    "last_fetch_error" varchar(255) NOT NULL,
+    "tags" BLOB,
+    "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    "geometry" BLOB
+);
+INSERT INTO "feed_states_down" ("id", "feed_id", "feed_version_id", "feed_realtime_enabled", "feed_priority", "last_fetched_at", "last_successful_fetch_at", "last_imported_at", "last_fetch_error", "tags", "created_at", "updated_at", "geometry") SELECT "id", "feed_id", "feed_version_id", "feed_realtime_enabled", "feed_priority", "last_fetched_at", "last_successful_fetch_at", "last_imported_at", "last_fetch_error", "tags", "created_at", "updated_at", "geometry" FROM "feed_states";
+DROP TABLE "feed_states";
+ALTER TABLE "feed_states_down" RENAME TO "feed_states";
@@ -0,0 +1 @@
+ALTER TABLE "feed_states" ADD COLUMN "fetch_interval" integer;
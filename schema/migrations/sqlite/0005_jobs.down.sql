@@ -0,0 +1 @@
+DROP TABLE IF EXISTS "dmfr_jobs";
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS "dmfr_jobs" (
+  "id" integer primary key autoincrement,
+  "job_type" varchar(255) NOT NULL,
+  "feed_id" integer REFERENCES "current_feeds"("id"),
+  "feed_version_id" integer REFERENCES "feed_versions"("id"),
+  "status" varchar(255) DEFAULT 'pending' NOT NULL,
+  "attempts" integer DEFAULT 0 NOT NULL,
+  "run_at" datetime NOT NULL,
+  "locked_at" datetime,
+  "locked_by" varchar(255) DEFAULT '' NOT NULL,
+  "last_error" text DEFAULT '' NOT NULL,
+  "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL,
+  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+CREATE INDEX IF NOT EXISTS idx_dmfr_jobs_status_run_at ON "dmfr_jobs"(status, run_at);
+-- At most one pending or running job of each type for a feed or feed version; the other ID is NULL
+CREATE UNIQUE INDEX IF NOT EXISTS idx_dmfr_jobs_unique ON "dmfr_jobs"(job_type, coalesce(feed_id, 0), coalesce(feed_version_id, 0)) WHERE status IN ('pending', 'running');
@@ -8,6 +8,7 @@ pg_dump \
     -t 'feed_version_file_infos' \
     -t 'feed_version_service_levels' \
     -t 'feed_states' \
+    -t 'dmfr_jobs' \
     -t 'gtfs_*' \
     --no-owner \
     -s \
@@ -31,7 +31,9 @@ CREATE TABLE IF NOT EXISTS "feed_version_gtfs_imports" (
   "skip_entity_filter_count" blob,
   "generated_count" blob,
   "warning_count" blob,
-  "entity_count" blob
+  "entity_count" blob,
+  "progress" real DEFAULT 0 NOT NULL,
+  "checkpoint" text DEFAULT '' NOT NULL
 );
 CREATE TABLE IF NOT EXISTS "gtfs_stops" (
   "id" integer primary key autoincrement, 
@@ -49,7 +51,8 @@ CREATE TABLE IF NOT EXISTS "gtfs_stops" (
   "stop_timezone" varchar(255) NOT NULL, 
   "wheelchair_boarding" integer NOT NULL, 
   "level_id" integer,
-  "geometry" BLOB NOT NULL
+  "geometry" BLOB NOT NULL, 
+  "extra" blob
 );
 CREATE TABLE IF NOT EXISTS "gtfs_pathways" (
   "id" integer primary key autoincrement, 
@@ -67,8 +70,10 @@ CREATE TABLE IF NOT EXISTS "gtfs_pathways" (
   "max_slope" real NOT NULL,
   "min_width" real NOT NULL,
   "signposted_as" varchar(255) NOT NULL,
-  "reverse_signposted_as" varchar(255) NOT NULL
+  "reverse_signposted_as" varchar(255) NOT NULL, 
+  "extra" blob
 );
+CREATE UNIQUE INDEX idx_gtfs_pathways_unique ON "gtfs_pathways"(feed_version_id, pathway_id);
 CREATE TABLE IF NOT EXISTS "gtfs_levels" (
   "id" integer primary key autoincrement, 
   "feed_version_id" integer NOT NULL, 
@@ -76,8 +81,10 @@ CREATE TABLE IF NOT EXISTS "gtfs_levels" (
   "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL, 
   "level_id" varchar(255) NOT NULL,
   "level_index" real NOT NULL,
-  "level_name" varchar(255) NOT NULL
+  "level_name" varchar(255) NOT NULL, 
+  "extra" blob
 );
+CREATE UNIQUE INDEX idx_gtfs_levels_unique ON "gtfs_levels"(feed_version_id, level_id);
 CREATE TABLE IF NOT EXISTS "gtfs_shapes" (
   "id" integer primary key autoincrement, 
   "feed_version_id" integer NOT NULL, 
@@ -85,7 +92,8 @@ CREATE TABLE IF NOT EXISTS "gtfs_shapes" (
   "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL, 
   "shape_id" varchar(255) NOT NULL, 
   "generated" bool NOT NULL,
-  "geometry" BLOB NOT NULL
+  "geometry" BLOB NOT NULL, 
+  "extra" blob
 );
 CREATE TABLE IF NOT EXISTS "feed_versions" (
   "feed_id" integer, 
@@ -115,7 +123,8 @@ CREATE TABLE IF NOT EXISTS "feed_states" (
     "tags" BLOB,
     "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL, 
     "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL,
-    "geometry" BLOB
+    "geometry" BLOB,
+    "fetch_interval" integer
 );
 
 CREATE INDEX idx_feed_versions_sha1 ON "feed_versions"("sha1");
@@ -126,8 +135,10 @@ CREATE INDEX idx_feed_versions_feed_type ON "feed_versions"(feed_type);
 CREATE INDEX idx_gtfs_stops_stop_id ON "gtfs_stops"(stop_id);
 CREATE INDEX idx_gtfs_stops_parent_station ON "gtfs_stops"(parent_station);
 CREATE INDEX idx_gtfs_stops_feed_version_id ON "gtfs_stops"(feed_version_id);
+CREATE UNIQUE INDEX idx_gtfs_stops_unique ON "gtfs_stops"(feed_version_id, stop_id);
 CREATE INDEX idx_gtfs_shapes_shape_id ON "gtfs_shapes"(shape_id);
 CREATE INDEX idx_gtfs_shapes_feed_version_id ON "gtfs_shapes"(feed_version_id);
+CREATE UNIQUE INDEX idx_gtfs_shapes_unique ON "gtfs_shapes"(feed_version_id, shape_id);
 CREATE TABLE IF NOT EXISTS "gtfs_feed_infos" (
   "feed_publisher_name" varchar(255) NOT NULL, 
   "feed_publisher_url" varchar(255) NOT NULL, 
@@ -138,7 +149,8 @@ CREATE TABLE IF NOT EXISTS "gtfs_feed_infos" (
   "id" integer primary key autoincrement, 
   "feed_version_id" integer NOT NULL, 
   "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL, 
-  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL
+  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL, 
+  "extra" blob
 );
 CREATE INDEX idx_gtfs_feed_infos_feed_version_id ON "gtfs_feed_infos"(feed_version_id);
 CREATE TABLE IF NOT EXISTS "gtfs_frequencies" (
@@ -150,7 +162,8 @@ CREATE TABLE IF NOT EXISTS "gtfs_frequencies" (
   "id" integer primary key autoincrement, 
   "feed_version_id" integer NOT NULL, 
   "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL, 
-  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL
+  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL, 
+  "extra" blob
 );
 CREATE INDEX idx_gtfs_frequencies_trip_id ON "gtfs_frequencies"(trip_id);
 CREATE INDEX idx_gtfs_frequencies_feed_version_id ON "gtfs_frequencies"(feed_version_id);
@@ -169,7 +182,8 @@ CREATE TABLE IF NOT EXISTS "gtfs_trips" (
   "id" integer primary key autoincrement, 
   "feed_version_id" integer NOT NULL, 
   "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL, 
-  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL
+  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL, 
+  "extra" blob
 );
 CREATE INDEX idx_gtfs_trips_route_id ON "gtfs_trips"(route_id);
 CREATE INDEX idx_gtfs_trips_service_id ON "gtfs_trips"(service_id);
@@ -177,6 +191,7 @@ CREATE INDEX idx_gtfs_trips_trip_id ON "gtfs_trips"(trip_id);
 CREATE INDEX idx_gtfs_trips_shape_id ON "gtfs_trips"(shape_id);
 CREATE INDEX idx_gtfs_trips_stop_pattern_id ON "gtfs_trips"(stop_pattern_id);
 CREATE INDEX idx_gtfs_trips_feed_version_id ON "gtfs_trips"(feed_version_id);
+CREATE UNIQUE INDEX idx_gtfs_trips_unique ON "gtfs_trips"(feed_version_id, trip_id);
 CREATE TABLE IF NOT EXISTS "gtfs_agencies" (
   "agency_id" varchar(255) NOT NULL, 
   "agency_name" varchar(255) NOT NULL, 
@@ -189,10 +204,12 @@ CREATE TABLE IF NOT EXISTS "gtfs_agencies" (
   "id" integer primary key autoincrement, 
   "feed_version_id" int NOT NULL, 
   "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL, 
-  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL
+  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL, 
+  "extra" blob
 );
 CREATE INDEX idx_gtfs_agencies_agency_id ON "gtfs_agencies"(agency_id);
 CREATE INDEX idx_gtfs_agencies_feed_version_id ON "gtfs_agencies"(feed_version_id);
+CREATE UNIQUE INDEX idx_gtfs_agencies_unique ON "gtfs_agencies"(feed_version_id, agency_id);
 CREATE TABLE IF NOT EXISTS "gtfs_transfers" (
   "from_stop_id" int NOT NULL, 
   "to_stop_id" int NOT NULL, 
@@ -201,7 +218,8 @@ CREATE TABLE IF NOT EXISTS "gtfs_transfers" (
   "id" integer primary key autoincrement, 
   "feed_version_id" integer NOT NULL, 
   "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL, 
-  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL
+  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL, 
+  "extra" blob
 );
 CREATE INDEX idx_gtfs_transfers_transfer_type ON "gtfs_transfers"(transfer_type);
 CREATE INDEX idx_gtfs_transfers_feed_version_id ON "gtfs_transfers"(feed_version_id);
@@ -222,11 +240,13 @@ CREATE TABLE IF NOT EXISTS "gtfs_calendars" (
   "id" integer primary key autoincrement, 
   "feed_version_id" integer NOT NULL, 
   "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL, 
-  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL
+  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL, 
+  "extra" blob
 );
 CREATE INDEX idx_gtfs_calendars_wednesday ON "gtfs_calendars"("wednesday");
 CREATE INDEX idx_gtfs_calendars_start_date ON "gtfs_calendars"(start_date);
 CREATE INDEX idx_gtfs_calendars_feed_version_id ON "gtfs_calendars"(feed_version_id);
+CREATE UNIQUE INDEX idx_gtfs_calendars_unique ON "gtfs_calendars"(feed_version_id, service_id);
 CREATE INDEX idx_gtfs_calendars_end_date ON "gtfs_calendars"(end_date);
 CREATE INDEX idx_gtfs_calendars_service_id ON "gtfs_calendars"(service_id);
 CREATE INDEX idx_gtfs_calendars_monday ON "gtfs_calendars"("monday");
@@ -242,7 +262,8 @@ CREATE TABLE IF NOT EXISTS "gtfs_calendar_dates" (
   "id" integer primary key autoincrement, 
   "feed_version_id" integer NOT NULL, 
   "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL, 
-  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL
+  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL, 
+  "extra" blob
 );
 CREATE INDEX idx_gtfs_calendar_dates_date ON "gtfs_calendar_dates"("date");
 CREATE INDEX idx_gtfs_calendar_dates_exception_type ON "gtfs_calendar_dates"(exception_type);
@@ -262,12 +283,14 @@ CREATE TABLE IF NOT EXISTS "gtfs_routes" (
   "id" integer primary key autoincrement, 
   "feed_version_id" integer NOT NULL, 
   "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL, 
-  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL
+  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL, 
+  "extra" blob
 );
 CREATE INDEX idx_gtfs_routes_route_id ON "gtfs_routes"(route_id);
 CREATE INDEX idx_gtfs_routes_agency_id ON "gtfs_routes"(agency_id);
 CREATE INDEX idx_gtfs_routes_route_type ON "gtfs_routes"(route_type);
 CREATE INDEX idx_gtfs_routes_feed_version_id ON "gtfs_routes"(feed_version_id);
+CREATE UNIQUE INDEX idx_gtfs_routes_unique ON "gtfs_routes"(feed_version_id, route_id);
 CREATE TABLE IF NOT EXISTS "gtfs_stop_times" (
   "trip_id" int NOT NULL, 
   "arrival_time" int NOT NULL, 
@@ -283,11 +306,13 @@ CREATE TABLE IF NOT EXISTS "gtfs_stop_times" (
   "id" integer primary key autoincrement, 
   "feed_version_id" integer NOT NULL, 
   "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL, 
-  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL
+  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL, 
+  "extra" blob
 );
 CREATE INDEX idx_stop_times_trip_id ON "gtfs_stop_times"(trip_id);
 CREATE INDEX idx_gtfs_stop_times_stop_id ON "gtfs_stop_times"(stop_id);
 CREATE INDEX idx_gtfs_stop_times_feed_version_id ON "gtfs_stop_times"(feed_version_id);
+CREATE UNIQUE INDEX idx_gtfs_stop_times_unique ON "gtfs_stop_times"(feed_version_id, trip_id, stop_sequence);
 CREATE TABLE IF NOT EXISTS "gtfs_fare_rules" (
   "fare_id" int NOT NULL, 
   "route_id" int, 
@@ -297,7 +322,8 @@ CREATE TABLE IF NOT EXISTS "gtfs_fare_rules" (
   "id" integer primary key autoincrement, 
   "feed_version_id" integer NOT NULL, 
   "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL, 
-  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL
+  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL, 
+  "extra" blob
 );
 CREATE INDEX idx_gtfs_fare_rules_fare_id ON "gtfs_fare_rules"(fare_id);
 CREATE INDEX idx_gtfs_fare_rules_feed_version_id ON "gtfs_fare_rules"(feed_version_id);
@@ -312,10 +338,12 @@ CREATE TABLE IF NOT EXISTS "gtfs_fare_attributes" (
   "id" integer primary key autoincrement, 
   "feed_version_id" integer NOT NULL, 
   "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL, 
-  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL
+  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL, 
+  "extra" blob
 );
 CREATE INDEX idx_gtfs_fare_attributes_fare_id ON "gtfs_fare_attributes"(fare_id);
 CREATE INDEX idx_gtfs_fare_attributes_feed_version_id ON "gtfs_fare_attributes"(feed_version_id);
+CREATE UNIQUE INDEX idx_gtfs_fare_attributes_unique ON "gtfs_fare_attributes"(feed_version_id, fare_id);
 
 
 -------------------
@@ -358,4 +386,24 @@ CREATE TABLE IF NOT EXISTS "feed_version_service_levels" (
     "sunday" integer NOT NULL
 );
 
-CREATE INDEX idx_feed_version_service_levels_feed_version_id ON "feed_version_service_levels"(feed_version_id);
\ No newline at end of file
+CREATE INDEX idx_feed_version_service_levels_feed_version_id ON "feed_version_service_levels"(feed_version_id);
+
+--------------------
+
+CREATE TABLE IF NOT EXISTS "dmfr_jobs" (
+  "id" integer primary key autoincrement,
+  "job_type" varchar(255) NOT NULL,
+  "feed_id" integer REFERENCES "current_feeds"("id"),
+  "feed_version_id" integer REFERENCES "feed_versions"("id"),
+  "status" varchar(255) DEFAULT 'pending' NOT NULL,
+  "attempts" integer DEFAULT 0 NOT NULL,
+  "run_at" datetime NOT NULL,
+  "locked_at" datetime,
+  "locked_by" varchar(255) DEFAULT '' NOT NULL,
+  "last_error" text DEFAULT '' NOT NULL,
+  "created_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL,
+  "updated_at" datetime DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_dmfr_jobs_status_run_at ON "dmfr_jobs"(status, run_at);
+CREATE UNIQUE INDEX idx_dmfr_jobs_unique ON "dmfr_jobs"(job_type, coalesce(feed_id, 0), coalesce(feed_version_id, 0)) WHERE status IN ('pending', 'running');
@@ -2,7 +2,9 @@ package causes
 
 import (
 	"fmt"
+	"reflect"
 	"strconv"
+	"unicode"
 )
 
 // TODO
@@ -52,6 +54,91 @@ func (e *Context) Error() string {
 	return ""
 }
 
+type hasCause interface {
+	Cause() error
+}
+
+type hasContext interface {
+	Context() *Context
+}
+
+// ErrorCode returns a stable identifier for the innermost cause of an error, e.g. "InvalidFieldError".
+// Errors that are not exported types, such as those created with errors.New, return "UnknownError".
+func ErrorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+	for {
+		v, ok := err.(hasCause)
+		if !ok || v.Cause() == nil {
+			break
+		}
+		err = v.Cause()
+	}
+	t := reflect.TypeOf(err)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	name := t.Name()
+	if len(name) == 0 || !unicode.IsUpper([]rune(name)[0]) {
+		return "UnknownError"
+	}
+	return name
+}
+
+// ErrorContext merges the Context of an error and its causes, walking from the outermost error to the innermost cause.
+// Inner values take precedence, except Filename and EntityID. Message is the message of the innermost cause.
+func ErrorContext(err error) Context {
+	ret := Context{}
+	for err != nil {
+		if v, ok := err.(hasContext); ok {
+			if ctx := v.Context(); ctx != nil {
+				if ret.Filename == "" {
+					ret.Filename = ctx.Filename
+				}
+				if ret.EntityID == "" {
+					ret.EntityID = ctx.EntityID
+				}
+				if ctx.Line > 0 {
+					ret.Line = ctx.Line
+				}
+				if ctx.Field != "" {
+					ret.Field = ctx.Field
+				}
+				if ctx.Value != "" {
+					ret.Value = ctx.Value
+				}
+			}
+		}
+		ret.Message = err.Error()
+		v, ok := err.(hasCause)
+		if !ok {
+			break
+		}
+		err = v.Cause()
+	}
+	return ret
+}
+
+// UpdateLine sets the line number on an error and its causes, if they have a Context without a line number.
+func UpdateLine(err error, line int) {
+	if line <= 0 {
+		return
+	}
+	for err != nil {
+		if v, ok := err.(hasContext); ok {
+			if ctx := v.Context(); ctx != nil && ctx.Line == 0 {
+				ctx.Line = line
+			}
+		}
+		v, ok := err.(hasCause)
+		if !ok {
+			break
+		}
+		err = v.Cause()
+	}
+}
+
 ////////////////////////////
 // Feed level errors
 ////////////////////////////
@@ -1,6 +1,10 @@
 package tl
 
 import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
 	"strconv"
 	"time"
 )
@@ -34,7 +38,8 @@ type BaseEntity struct {
 	Timestamps
 	ID            int
 	FeedVersionID int
-	extra         []string
+	extra         ExtraFields
+	line          int
 	loadErrors    []error
 	loadWarnings  []error
 	// DeletedAt     OptionalTime
@@ -50,20 +55,42 @@ func (ent *BaseEntity) SetFeedVersionID(fvid int) {
 	ent.FeedVersionID = fvid
 }
 
+// SetLine sets the source line number, e.g. from a CSV file.
+func (ent *BaseEntity) SetLine(line int) {
+	ent.line = line
+}
+
+// Line returns the source line number, or 0 if unknown.
+func (ent *BaseEntity) Line() int {
+	return ent.line
+}
+
 // Extra provides any additional fields that were present.
 func (ent *BaseEntity) Extra() map[string]string {
 	ret := map[string]string{}
-	for i := 0; i < len(ent.extra); i += 2 {
+	for i := 0; i+1 < len(ent.extra); i += 2 {
 		ret[ent.extra[i]] = ent.extra[i+1]
 	}
 	return ret
 }
 
+// ExtraKeys returns the names of the extra fields, in order.
+func (ent *BaseEntity) ExtraKeys() []string {
+	return ent.extra.Keys()
+}
+
+// ExtraFields returns the extra fields as key, value pairs, e.g. for saving to a database.
+func (ent *BaseEntity) ExtraFields() ExtraFields {
+	return ent.extra
+}
+
+// SetExtraFields replaces the extra fields, e.g. when loading from a database.
+func (ent *BaseEntity) SetExtraFields(extra ExtraFields) {
+	ent.extra = extra
+}
+
 // SetExtra adds a string key, value pair to the entity's extra fields.
 func (ent *BaseEntity) SetExtra(key string, value string) {
-	if ent.extra == nil {
-		ent.extra = []string{}
-	}
 	ent.extra = append(ent.extra, key, value)
 }
 
@@ -98,6 +125,92 @@ func (ent *BaseEntity) EntityID() string { return "" }
 // UpdateKeys updates entity referencespdates foreign keys based on an EntityMap.
 func (ent *BaseEntity) UpdateKeys(emap *EntityMap) error { return nil }
 
+// ExtraFields are additional fields that are not part of the specification, as key, value pairs.
+// They are stored in a database as a JSON object.
+type ExtraFields []string
+
+// Keys returns the field names, in order, without duplicates.
+func (r ExtraFields) Keys() []string {
+	keys := []string{}
+	seen := map[string]bool{}
+	for i := 0; i+1 < len(r); i += 2 {
+		if !seen[r[i]] {
+			seen[r[i]] = true
+			keys = append(keys, r[i])
+		}
+	}
+	return keys
+}
+
+// Value returns a JSON object, or nil if there are no fields.
+func (r ExtraFields) Value() (driver.Value, error) {
+	if len(r) < 2 {
+		return nil, nil
+	}
+	values := map[string]string{}
+	for i := 0; i+1 < len(r); i += 2 {
+		values[r[i]] = r[i+1]
+	}
+	// Encode manually to keep the original field order
+	b := bytes.Buffer{}
+	b.WriteString("{")
+	for i, k := range r.Keys() {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		kb, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		vb, err := json.Marshal(values[k])
+		if err != nil {
+			return nil, err
+		}
+		b.Write(kb)
+		b.WriteString(":")
+		b.Write(vb)
+	}
+	b.WriteString("}")
+	return b.Bytes(), nil
+}
+
+// Scan reads a JSON object, keeping the stored field order.
+func (r *ExtraFields) Scan(src interface{}) error {
+	*r = nil
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return errors.New("cannot scan extra fields")
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if t, err := dec.Token(); err != nil {
+		return err
+	} else if t != json.Delim('{') {
+		return errors.New("extra fields must be a JSON object")
+	}
+	ret := ExtraFields{}
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		k, _ := t.(string)
+		v := ""
+		if err := dec.Decode(&v); err != nil {
+			return err
+		}
+		ret = append(ret, k, v)
+	}
+	*r = ret
+	return nil
+}
+
 func entID(id int, gtfsid string) string {
 	if id > 0 {
 		return strconv.Itoa(id)
@@ -153,3 +153,42 @@ func TestFrequencyRepeatCount(t *testing.T) {
 		})
 	}
 }
+
+/////////////////////////
+
+// extra fields
+
+func TestExtraFields(t *testing.T) {
+	ent := Route{}
+	ent.SetExtra("route_sort_order_alt", "2")
+	ent.SetExtra("internal_code", "a \"quoted\" value")
+	ent.SetExtra("route_sort_order_alt", "3")
+	if keys := ent.ExtraKeys(); len(keys) != 2 || keys[0] != "route_sort_order_alt" || keys[1] != "internal_code" {
+		t.Errorf("got keys %v", keys)
+	}
+	val, err := ent.ExtraFields().Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := `{"route_sort_order_alt":"3","internal_code":"a \"quoted\" value"}`
+	if b, ok := val.([]byte); !ok || string(b) != exp {
+		t.Errorf("got %v, expected %s", val, exp)
+	}
+	got := ExtraFields{}
+	if err := got.Scan(val); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 4 || got[0] != "route_sort_order_alt" || got[1] != "3" || got[2] != "internal_code" || got[3] != `a "quoted" value` {
+		t.Errorf("got %v", got)
+	}
+	// No fields
+	if val, err := (ExtraFields{}).Value(); err != nil || val != nil {
+		t.Errorf("got %v, expected nil", val)
+	}
+	if err := got.Scan(nil); err != nil || len(got) != 0 {
+		t.Errorf("got %v, expected no fields", got)
+	}
+	if err := got.Scan("[]"); err == nil {
+		t.Errorf("expected error for JSON array")
+	}
+}
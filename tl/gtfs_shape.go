@@ -29,11 +29,15 @@ func ValidateShapes(shapes []Shape) []error {
 	for _, shape := range shapes {
 		// Check for duplicate ID errors
 		if shape.ShapePtSequence == last {
-			errs = append(errs, causes.NewSequenceError("shape_pt_sequence", strconv.Itoa(last)))
+			err := causes.NewSequenceError("shape_pt_sequence", strconv.Itoa(last))
+			err.Line = shape.Line()
+			errs = append(errs, err)
 		}
 		last = shape.ShapePtSequence
 		if shape.ShapeDistTraveled < dist {
-			errs = append(errs, causes.NewSequenceError("shape_dist_traveled", fmt.Sprintf("%f", shape.ShapeDistTraveled)))
+			err := causes.NewSequenceError("shape_dist_traveled", fmt.Sprintf("%f", shape.ShapeDistTraveled))
+			err.Line = shape.Line()
+			errs = append(errs, err)
 		} else if shape.ShapeDistTraveled > 0 {
 			dist = shape.ShapeDistTraveled
 		}
@@ -56,10 +60,14 @@ func NewShapeFromShapes(shapes []Shape) Shape {
 		}
 	}
 	// expectError is just for validation tests.
-	// Add to coords, add base errors
+	// Add to coords, add base errors with the line number of each point
+	if len(shapes) > 0 {
+		ent.SetLine(shapes[0].Line())
+	}
 	for _, shape := range shapes {
 		coords = append(coords, shape.ShapePtLon, shape.ShapePtLat, shape.ShapeDistTraveled)
 		for _, err := range shape.Errors() {
+			causes.UpdateLine(err, shape.Line())
 			ent.AddError(err)
 		}
 		// For tests...
@@ -0,0 +1,289 @@
+package tl
+
+import (
+	"fmt"
+	"sync"
+)
+
+// EntityIterator reads entities one at a time and reports any error that stopped reading.
+//
+//	it := NewIterator(reader, &Stop{})
+//	defer it.Close()
+//	for it.Next() {
+//		stop := it.Value().(*Stop)
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type EntityIterator interface {
+	// Next advances to the next entity; it returns false when there are no more entities or an error occurred.
+	Next() bool
+	// Value returns the current entity, as a pointer to a new entity of the requested type.
+	Value() Entity
+	// Err returns the error that stopped the iterator, if any.
+	Err() error
+	// Close stops the iterator and releases its resources; it is safe to call more than once.
+	Close() error
+}
+
+// StopTimeIterator reads StopTimes grouped by TripID; each group is sorted by stop_sequence.
+type StopTimeIterator interface {
+	Next() bool
+	Value() []StopTime
+	Err() error
+	Close() error
+}
+
+// IteratorReader is implemented by Readers that provide iterators that report read errors.
+type IteratorReader interface {
+	Iterate(Entity) EntityIterator
+	IterateStopTimesByTripID(...string) StopTimeIterator
+}
+
+// NewIterator returns an EntityIterator for entities of the same type as ent.
+// Readers that do not implement IteratorReader are read using their channel methods and never return an error.
+func NewIterator(reader Reader, ent Entity) EntityIterator {
+	if v, ok := reader.(IteratorReader); ok {
+		return v.Iterate(ent)
+	}
+	return NewEntityIterator(func(send func(Entity) bool) error {
+		return readerChannel(reader, ent, send)
+	})
+}
+
+// NewStopTimeIterator returns a StopTimeIterator for the selected trips, or all trips if none are specified.
+// Readers that do not implement IteratorReader are read using StopTimesByTripID and never return an error.
+func NewStopTimeIterator(reader Reader, tripIDs ...string) StopTimeIterator {
+	if v, ok := reader.(IteratorReader); ok {
+		return v.IterateStopTimesByTripID(tripIDs...)
+	}
+	return NewStopTimeGroupIterator(func(send func([]StopTime) bool) error {
+		ok := true
+		for ents := range reader.StopTimesByTripID(tripIDs...) {
+			// Keep draining after a stop so the reader goroutine can exit
+			ok = ok && send(ents)
+		}
+		return nil
+	})
+}
+
+// readerChannel sends each entity from the matching Reader channel method.
+func readerChannel(reader Reader, ent Entity, send func(Entity) bool) error {
+	// Keep draining after a stop so the reader goroutine can exit
+	ok := true
+	switch ent.(type) {
+	case *Agency:
+		for e := range reader.Agencies() {
+			e := e
+			ok = ok && send(&e)
+		}
+	case *Route:
+		for e := range reader.Routes() {
+			e := e
+			ok = ok && send(&e)
+		}
+	case *Level:
+		for e := range reader.Levels() {
+			e := e
+			ok = ok && send(&e)
+		}
+	case *Stop:
+		for e := range reader.Stops() {
+			e := e
+			ok = ok && send(&e)
+		}
+	case *Pathway:
+		for e := range reader.Pathways() {
+			e := e
+			ok = ok && send(&e)
+		}
+	case *FareAttribute:
+		for e := range reader.FareAttributes() {
+			e := e
+			ok = ok && send(&e)
+		}
+	case *FareRule:
+		for e := range reader.FareRules() {
+			e := e
+			ok = ok && send(&e)
+		}
+	case *Calendar:
+		for e := range reader.Calendars() {
+			e := e
+			ok = ok && send(&e)
+		}
+	case *CalendarDate:
+		for e := range reader.CalendarDates() {
+			e := e
+			ok = ok && send(&e)
+		}
+	case *Shape:
+		for e := range reader.Shapes() {
+			e := e
+			ok = ok && send(&e)
+		}
+	case *Trip:
+		for e := range reader.Trips() {
+			e := e
+			ok = ok && send(&e)
+		}
+	case *StopTime:
+		for e := range reader.StopTimes() {
+			e := e
+			ok = ok && send(&e)
+		}
+	case *Frequency:
+		for e := range reader.Frequencies() {
+			e := e
+			ok = ok && send(&e)
+		}
+	case *Transfer:
+		for e := range reader.Transfers() {
+			e := e
+			ok = ok && send(&e)
+		}
+	case *FeedInfo:
+		for e := range reader.FeedInfos() {
+			e := e
+			ok = ok && send(&e)
+		}
+	default:
+		return fmt.Errorf("cannot read entities of type %T", ent)
+	}
+	return nil
+}
+
+// NewEntityIterator returns an EntityIterator that runs the producer in a new goroutine.
+// The producer calls send for each entity and should stop when send returns false, which happens after Close.
+// The error returned by the producer is returned by Err.
+func NewEntityIterator(producer func(send func(Entity) bool) error) EntityIterator {
+	it := &entityIterator{
+		out:  make(chan Entity, iteratorBufferSize),
+		stop: make(chan struct{}),
+	}
+	go func() {
+		it.err = producer(func(ent Entity) bool {
+			select {
+			case it.out <- ent:
+				return true
+			case <-it.stop:
+				return false
+			}
+		})
+		close(it.out)
+	}()
+	return it
+}
+
+// NewStopTimeGroupIterator returns a StopTimeIterator that runs the producer in a new goroutine.
+// See NewEntityIterator.
+func NewStopTimeGroupIterator(producer func(send func([]StopTime) bool) error) StopTimeIterator {
+	it := &stopTimeIterator{
+		out:  make(chan []StopTime, iteratorBufferSize),
+		stop: make(chan struct{}),
+	}
+	go func() {
+		it.err = producer(func(ents []StopTime) bool {
+			select {
+			case it.out <- ents:
+				return true
+			case <-it.stop:
+				return false
+			}
+		})
+		close(it.out)
+	}()
+	return it
+}
+
+const iteratorBufferSize = 1000
+
+// entityIterator is an EntityIterator backed by a producer goroutine.
+// err is written before out is closed, so it is safe to read once out is drained.
+type entityIterator struct {
+	out   chan Entity
+	stop  chan struct{}
+	once  sync.Once
+	value Entity
+	err   error
+	done  bool
+}
+
+func (it *entityIterator) Next() bool {
+	if it.done {
+		return false
+	}
+	v, ok := <-it.out
+	if !ok {
+		it.done = true
+		it.value = nil
+		return false
+	}
+	it.value = v
+	return true
+}
+
+func (it *entityIterator) Value() Entity {
+	return it.value
+}
+
+func (it *entityIterator) Err() error {
+	if !it.done {
+		return nil
+	}
+	return it.err
+}
+
+func (it *entityIterator) Close() error {
+	it.once.Do(func() { close(it.stop) })
+	// Wait for the producer to exit
+	for range it.out {
+	}
+	it.done = true
+	it.value = nil
+	return nil
+}
+
+// stopTimeIterator is a StopTimeIterator backed by a producer goroutine.
+type stopTimeIterator struct {
+	out   chan []StopTime
+	stop  chan struct{}
+	once  sync.Once
+	value []StopTime
+	err   error
+	done  bool
+}
+
+func (it *stopTimeIterator) Next() bool {
+	if it.done {
+		return false
+	}
+	v, ok := <-it.out
+	if !ok {
+		it.done = true
+		it.value = nil
+		return false
+	}
+	it.value = v
+	return true
+}
+
+func (it *stopTimeIterator) Value() []StopTime {
+	return it.value
+}
+
+func (it *stopTimeIterator) Err() error {
+	if !it.done {
+		return nil
+	}
+	return it.err
+}
+
+func (it *stopTimeIterator) Close() error {
+	it.once.Do(func() { close(it.stop) })
+	for range it.out {
+	}
+	it.done = true
+	it.value = nil
+	return nil
+}
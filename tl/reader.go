@@ -1,5 +1,7 @@
 package tl
 
+import "context"
+
 // Reader defines an interface for reading entities from a GTFS feed.
 type Reader interface {
 	Open() error
@@ -24,3 +26,9 @@ type Reader interface {
 	Levels() chan Level
 	Trips() chan Trip
 }
+
+// ContextSetter is implemented by Readers and Writers that can stop reading or writing when a context is done.
+// Channels returned by a Reader are closed early, and Writer methods return the context error.
+type ContextSetter interface {
+	SetContext(context.Context)
+}
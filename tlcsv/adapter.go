@@ -2,6 +2,7 @@ package tlcsv
 
 import (
 	"archive/zip"
+	"context"
 	"crypto/sha1"
 	"encoding/csv"
 	"errors"
@@ -232,11 +233,24 @@ func (adapter ZipAdapter) OpenFile(filename string, cb func(io.Reader)) error {
 	return nil
 }
 
+// readFileRows opens a file and runs the callback on each Row, until ctx is done.
+// An error is returned if the file cannot be opened or read; an empty file has no rows and is not an error.
+func readFileRows(ctx context.Context, openFile func(string, func(io.Reader)) error, filename string, cb func(Row)) error {
+	var readErr error
+	if err := openFile(filename, func(in io.Reader) {
+		readErr = ReadRowsContext(ctx, in, cb)
+	}); err != nil {
+		return err
+	}
+	if readErr == io.EOF {
+		return nil
+	}
+	return readErr
+}
+
 // ReadRows opens the specified file and runs the callback on each Row. An error is returned if the file cannot be read.
 func (adapter ZipAdapter) ReadRows(filename string, cb func(Row)) error {
-	return adapter.OpenFile(filename, func(in io.Reader) {
-		ReadRows(in, cb)
-	})
+	return readFileRows(context.Background(), adapter.OpenFile, filename, cb)
 }
 
 // SHA1 returns the SHA1 checksum of the zip archive.
@@ -397,7 +411,9 @@ func (adapter *DirAdapter) Path() string {
 // OpenFile opens a file in the directory. Returns an error if the file cannot be read.
 func (adapter *DirAdapter) OpenFile(filename string, cb func(io.Reader)) error {
 	in, err := os.Open(filepath.Join(adapter.path, filename))
-	if err != nil {
+	if os.IsNotExist(err) {
+		return causes.NewFileNotPresentError(filename)
+	} else if err != nil {
 		return err
 	}
 	defer in.Close()
@@ -407,9 +423,7 @@ func (adapter *DirAdapter) OpenFile(filename string, cb func(io.Reader)) error {
 
 // ReadRows opens the file and runs the callback for each row. An error is returned if the file cannot be read.
 func (adapter *DirAdapter) ReadRows(filename string, cb func(Row)) error {
-	return adapter.OpenFile(filename, func(in io.Reader) {
-		ReadRows(in, cb)
-	})
+	return readFileRows(context.Background(), adapter.OpenFile, filename, cb)
 }
 
 // Exists checks if the specified directory exists.
@@ -1,14 +1,16 @@
 package tlcsv
 
 import (
+	"context"
 	"crypto/sha1"
-	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+
+	"github.com/interline-io/transitland-lib/tl/causes"
 )
 
 // OverlayAdapter searches a specified list of directories for the specified file.
@@ -87,14 +89,12 @@ func (adapter OverlayAdapter) OpenFile(filename string, cb func(io.Reader)) erro
 		cb(in)
 		return nil
 	}
-	return errors.New("file not found")
+	return causes.NewFileNotPresentError(filename)
 }
 
 // ReadRows implements CSV Adapter ReadRows.
 func (adapter OverlayAdapter) ReadRows(filename string, cb func(Row)) error {
-	return adapter.OpenFile(filename, func(in io.Reader) {
-		ReadRows(in, cb)
-	})
+	return readFileRows(context.Background(), adapter.OpenFile, filename, cb)
 }
 
 // Open implements CSV Adapter Open.
@@ -1,6 +1,7 @@
 package tlcsv
 
 import (
+	"context"
 	"io"
 	"os"
 	"reflect"
@@ -18,6 +19,7 @@ type s2D = [][]string
 // Reader reads GTFS entities from CSV files.
 type Reader struct {
 	Adapter
+	ctx context.Context
 }
 
 // NewReader returns an initialized CSV Reader.
@@ -37,6 +39,27 @@ func NewReader(path string) (*Reader, error) {
 	return &Reader{Adapter: a}, nil
 }
 
+// SetContext sets a context; when it is done, remaining rows are skipped and channels are closed.
+func (reader *Reader) SetContext(ctx context.Context) {
+	reader.ctx = ctx
+}
+
+// done returns the context Done channel, or nil if there is no context.
+func (reader *Reader) done() <-chan struct{} {
+	if reader.ctx == nil {
+		return nil
+	}
+	return reader.ctx.Done()
+}
+
+// readRows calls ReadRows on the Adapter; with a context, reading stops with the context error when it is done.
+func (reader *Reader) readRows(filename string, cb func(Row)) error {
+	if reader.ctx == nil {
+		return reader.Adapter.ReadRows(filename, cb)
+	}
+	return readFileRows(reader.ctx, reader.Adapter.OpenFile, filename, cb)
+}
+
 // ReadEntities provides a generic interface for reading entities.
 func (reader *Reader) ReadEntities(c interface{}) error {
 	// Magic
@@ -48,11 +71,14 @@ func (reader *Reader) ReadEntities(c interface{}) error {
 		return causes.NewSourceUnreadableError("not a valid entity", nil)
 	}
 	go func() {
-		reader.Adapter.ReadRows(ent.Filename(), func(row Row) {
+		reader.readRows(ent.Filename(), func(row Row) {
 			a := reflect.New(outInnerType)
 			e := a.Interface().(tl.Entity)
 			loadRow(e, row)
-			outValue.Send(a.Elem())
+			reflect.Select([]reflect.SelectCase{
+				{Dir: reflect.SelectSend, Chan: outValue, Send: a.Elem()},
+				{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(reader.done())},
+			})
 		})
 		outValue.Close()
 	}()
@@ -164,84 +190,84 @@ func (reader *Reader) ContainsFile(filename string) bool {
 
 // StopTimesByTripID sends StopTimes for selected trips.
 func (reader *Reader) StopTimesByTripID(tripIDs ...string) chan []tl.StopTime {
-	chunks := s2D{}
-	grouped := false
-	// Get chunks and check if the file is already grouped by ID
-	if len(tripIDs) == 0 {
-		grouped = true
-		counter := map[string]int{}
+	out := make(chan []tl.StopTime, bufferSize)
+	go func() {
+		reader.readStopTimeGroups(tripIDs, func(v []tl.StopTime) bool {
+			select {
+			case out <- v:
+				return true
+			case <-reader.done():
+				return false
+			}
+		})
+		close(out)
+	}()
+	return out
+}
+
+// readStopTimeGroups calls cb with the StopTimes for each selected trip, sorted by stop_sequence, until cb returns false.
+func (reader *Reader) readStopTimeGroups(tripIDs []string, cb func([]tl.StopTime) bool) error {
+	chunks, grouped, err := reader.readChunks("stop_times.txt", "trip_id", tripIDs)
+	if err != nil {
+		return err
+	}
+	stopped := false
+	for _, chunk := range chunks {
+		set := stringsToSet(chunk)
+		m := map[string][]tl.StopTime{}
 		last := ""
-		// for ent := range reader.StopTimes() {
-		reader.Adapter.ReadRows("stop_times.txt", func(row Row) {
-			// Only check trip_id
+		send := func(v []tl.StopTime) {
+			sort.Slice(v, func(i, j int) bool {
+				return v[i].StopSequence < v[j].StopSequence
+			})
+			stopped = stopped || !cb(v)
+		}
+		err := reader.readRows("stop_times.txt", func(row Row) {
+			if stopped {
+				return
+			}
 			sid, _ := row.Get("trip_id")
-			// If ID transition, have we seen this ID
-			if sid != last && grouped == true && last != "" {
-				if _, ok := counter[sid]; ok {
-					grouped = false
-				}
+			if _, ok := set[sid]; ok {
+				ent := tl.StopTime{Timepoint: -1, ShapeDistTraveled: -1} // Set shape_dist_traveled and timepoint to -1, assuming column is missing
+				loadRowFast(&ent, row)
+				m[sid] = append(m[sid], ent)
+			}
+			// If we know the file is grouped, send the stoptimes at transition
+			if grouped && sid != last && last != "" {
+				send(m[last])
+				delete(m, last)
 			}
-			counter[sid]++
 			last = sid
 		})
-		if grouped {
-			keys := []string{}
-			for k := range counter {
-				keys = append(keys, k)
-			}
-			chunks = s2D{keys}
-		} else {
-			chunks = chunkMSI(counter, chunkSize)
+		if err != nil {
+			return err
 		}
-	} else {
-		chunks = s2D{tripIDs}
-	}
-	//
-	out := make(chan []tl.StopTime, bufferSize)
-	go func(chunks s2D, grouped bool) {
-		for _, chunk := range chunks {
-			set := stringsToSet(chunk)
-			m := map[string][]tl.StopTime{}
-			last := ""
-			reader.Adapter.ReadRows("stop_times.txt", func(row Row) {
-				sid, _ := row.Get("trip_id")
-				if _, ok := set[sid]; ok {
-					ent := tl.StopTime{Timepoint: -1, ShapeDistTraveled: -1} // Set shape_dist_traveled and timepoint to -1, assuming column is missing
-					loadRowFast(&ent, row)
-					m[sid] = append(m[sid], ent)
-				}
-				// If we know the file is grouped, send the stoptimes at transition
-				if grouped && sid != last && last != "" {
-					v := m[last]
-					sort.Slice(v, func(i, j int) bool {
-						return v[i].StopSequence < v[j].StopSequence
-					})
-					out <- v
-					delete(m, last)
-				}
-				last = sid
-			})
-			for _, v := range m {
-				sort.Slice(v, func(i, j int) bool {
-					return v[i].StopSequence < v[j].StopSequence
-				})
-				out <- v
+		for _, v := range m {
+			if !stopped {
+				send(v)
 			}
 		}
-		close(out)
-	}(chunks, grouped)
-	return out
+		if stopped {
+			break
+		}
+	}
+	return nil
 }
 
 // Shapes sends single-geometry LineString Shapes
 func (reader *Reader) Shapes() chan tl.Shape {
 	out := make(chan tl.Shape, bufferSize)
 	go func() {
-		for shapes := range reader.shapesByShapeID() {
+		reader.readShapeGroups(nil, func(shapes []tl.Shape) bool {
 			shape := tl.NewShapeFromShapes(shapes)
 			shape.ShapeID = shapes[0].ShapeID
-			out <- shape
-		}
+			select {
+			case out <- shape:
+				return true
+			case <-reader.done():
+				return false
+			}
+		})
 		close(out)
 	}()
 	return out
@@ -249,72 +275,162 @@ func (reader *Reader) Shapes() chan tl.Shape {
 
 // shapesByShapeID returns a map with grouped Shapes.
 func (reader *Reader) shapesByShapeID(shapeIDs ...string) chan []tl.Shape {
-	chunks := s2D{}
-	grouped := false
-	// Get chunks and check if the file is already grouped by ID
-	if len(shapeIDs) == 0 {
-		grouped = true
-		counter := map[string]int{}
+	out := make(chan []tl.Shape, bufferSize)
+	go func() {
+		reader.readShapeGroups(shapeIDs, func(v []tl.Shape) bool {
+			select {
+			case out <- v:
+				return true
+			case <-reader.done():
+				return false
+			}
+		})
+		close(out)
+	}()
+	return out
+}
+
+// readShapeGroups calls cb with the points for each selected shape, sorted by shape_pt_sequence, until cb returns false.
+func (reader *Reader) readShapeGroups(shapeIDs []string, cb func([]tl.Shape) bool) error {
+	chunks, grouped, err := reader.readChunks("shapes.txt", "shape_id", shapeIDs)
+	if err != nil {
+		return err
+	}
+	stopped := false
+	for _, chunk := range chunks {
+		set := stringsToSet(chunk)
+		m := map[string][]tl.Shape{}
 		last := ""
-		reader.Adapter.ReadRows("shapes.txt", func(row Row) {
-			// Only check shape_id
+		send := func(v []tl.Shape) {
+			sort.Slice(v, func(i, j int) bool {
+				return v[i].ShapePtSequence < v[j].ShapePtSequence
+			})
+			stopped = stopped || !cb(v)
+		}
+		err := reader.readRows("shapes.txt", func(row Row) {
+			if stopped {
+				return
+			}
 			sid, _ := row.Get("shape_id")
-			// If ID transition, have we seen this ID
-			if sid != last && grouped == true && last != "" {
-				if _, ok := counter[sid]; ok {
-					grouped = false
-				}
+			if _, ok := set[sid]; ok {
+				ent := tl.Shape{}
+				loadRow(&ent, row)
+				m[sid] = append(m[sid], ent)
+			}
+			// If we know the file is grouped, send the shape at transition
+			if grouped && sid != last && last != "" {
+				send(m[last])
+				delete(m, last)
 			}
-			counter[sid]++
 			last = sid
 		})
-		if grouped {
-			keys := []string{}
-			for k := range counter {
-				keys = append(keys, k)
-			}
-			chunks = s2D{keys}
-		} else {
-			chunks = chunkMSI(counter, chunkSize)
+		if err != nil {
+			return err
+		}
+		for _, v := range m {
+			if !stopped {
+				send(v)
+			}
+		}
+		if stopped {
+			break
 		}
-	} else {
-		chunks = s2D{shapeIDs}
 	}
-	//
-	out := make(chan []tl.Shape, bufferSize)
-	go func(chunks s2D, grouped bool) {
-		for _, chunk := range chunks {
-			set := stringsToSet(chunk)
-			m := map[string][]tl.Shape{}
-			last := ""
-			reader.Adapter.ReadRows("shapes.txt", func(row Row) {
-				sid, _ := row.Get("shape_id")
-				if _, ok := set[sid]; ok {
-					ent := tl.Shape{}
-					loadRow(&ent, row)
-					m[sid] = append(m[sid], ent)
-				}
-				// If we know the file is grouped, send the shape at transition
-				if grouped && sid != last && last != "" {
-					v := m[last]
-					sort.Slice(v, func(i, j int) bool {
-						return v[i].ShapePtSequence < v[j].ShapePtSequence
-					})
-					out <- v
-					delete(m, last)
-				}
-				last = sid
-			})
-			for _, v := range m {
-				sort.Slice(v, func(i, j int) bool {
-					return v[i].ShapePtSequence < v[j].ShapePtSequence
-				})
-				out <- v
+	return nil
+}
+
+// readChunks returns the IDs to read in each pass through a file, and if the file is already grouped by ID.
+// If ids are provided, they are read in a single pass.
+func (reader *Reader) readChunks(filename string, key string, ids []string) (s2D, bool, error) {
+	if len(ids) > 0 {
+		return s2D{ids}, false, nil
+	}
+	grouped := true
+	counter := map[string]int{}
+	last := ""
+	err := reader.readRows(filename, func(row Row) {
+		// Only check the ID
+		sid, _ := row.Get(key)
+		// If ID transition, have we seen this ID
+		if sid != last && grouped == true && last != "" {
+			if _, ok := counter[sid]; ok {
+				grouped = false
 			}
 		}
-		close(out)
-	}(chunks, grouped)
-	return out
+		counter[sid]++
+		last = sid
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	if !grouped {
+		return chunkMSI(counter, chunkSize), false, nil
+	}
+	keys := []string{}
+	for k := range counter {
+		keys = append(keys, k)
+	}
+	return s2D{keys}, true, nil
+}
+
+//////////////////////////////
+// Iterators
+//////////////////////////////
+
+// Iterate returns an EntityIterator for the file of the provided entity type.
+// A missing file has no entities; other read errors are returned by Err.
+func (reader *Reader) Iterate(ent tl.Entity) tl.EntityIterator {
+	efn := ent.Filename()
+	if _, ok := ent.(*tl.Shape); ok {
+		return tl.NewEntityIterator(func(send func(tl.Entity) bool) error {
+			return checkReadError(efn, reader.readShapeGroups(nil, func(shapes []tl.Shape) bool {
+				shape := tl.NewShapeFromShapes(shapes)
+				shape.ShapeID = shapes[0].ShapeID
+				return send(&shape)
+			}))
+		})
+	}
+	entType := reflect.TypeOf(ent).Elem()
+	return tl.NewEntityIterator(func(send func(tl.Entity) bool) error {
+		stopped := false
+		return checkReadError(efn, reader.readRows(efn, func(row Row) {
+			if stopped {
+				return
+			}
+			e := reflect.New(entType).Interface().(tl.Entity)
+			switch v := e.(type) {
+			case *tl.StopTime:
+				v.Timepoint = -1
+				v.ShapeDistTraveled = -1
+				loadRowFast(v, row)
+			case *tl.Stop:
+				loadRow(v, row)
+				v.SetCoordinates([2]float64{v.StopLon, v.StopLat})
+			default:
+				loadRow(e, row)
+			}
+			stopped = !send(e)
+		}))
+	})
+}
+
+// IterateStopTimesByTripID returns a StopTimeIterator for the selected trips, or all trips if none are specified.
+func (reader *Reader) IterateStopTimesByTripID(tripIDs ...string) tl.StopTimeIterator {
+	return tl.NewStopTimeGroupIterator(func(send func([]tl.StopTime) bool) error {
+		return checkReadError("stop_times.txt", reader.readStopTimeGroups(tripIDs, send))
+	})
+}
+
+// checkReadError ignores missing files and wraps other errors as a FileUnreadableError.
+func checkReadError(filename string, err error) error {
+	if err == nil {
+		return nil
+	} else if _, ok := err.(*causes.FileNotPresentError); ok {
+		return nil
+	} else if err == context.Canceled || err == context.DeadlineExceeded {
+		return err
+	}
+	return causes.NewFileUnreadableError(filename, err)
 }
 
 //////////////////////////////
@@ -326,11 +442,14 @@ func (reader *Reader) Stops() (out chan tl.Stop) {
 	out = make(chan tl.Stop, bufferSize)
 	go func() {
 		ent := tl.Stop{}
-		reader.Adapter.ReadRows(ent.Filename(), func(row Row) {
+		reader.readRows(ent.Filename(), func(row Row) {
 			e := tl.Stop{}
 			loadRow(&e, row)
 			e.SetCoordinates([2]float64{e.StopLon, e.StopLat})
-			out <- e
+			select {
+			case out <- e:
+			case <-reader.done():
+			}
 		})
 		close(out)
 	}()
@@ -342,10 +461,13 @@ func (reader *Reader) StopTimes() (out chan tl.StopTime) {
 	out = make(chan tl.StopTime, bufferSize)
 	go func() {
 		ent := tl.StopTime{}
-		reader.Adapter.ReadRows(ent.Filename(), func(row Row) {
+		reader.readRows(ent.Filename(), func(row Row) {
 			e := tl.StopTime{Timepoint: -1, ShapeDistTraveled: -1}
 			loadRowFast(&e, row)
-			out <- e
+			select {
+			case out <- e:
+			case <-reader.done():
+			}
 		})
 		close(out)
 	}()
@@ -357,10 +479,13 @@ func (reader *Reader) Agencies() (out chan tl.Agency) {
 	out = make(chan tl.Agency, bufferSize)
 	go func() {
 		ent := tl.Agency{}
-		reader.Adapter.ReadRows(ent.Filename(), func(row Row) {
+		reader.readRows(ent.Filename(), func(row Row) {
 			e := tl.Agency{}
 			loadRow(&e, row)
-			out <- e
+			select {
+			case out <- e:
+			case <-reader.done():
+			}
 		})
 		close(out)
 	}()
@@ -372,10 +497,13 @@ func (reader *Reader) Calendars() (out chan tl.Calendar) {
 	out = make(chan tl.Calendar, bufferSize)
 	go func() {
 		ent := tl.Calendar{}
-		reader.Adapter.ReadRows(ent.Filename(), func(row Row) {
+		reader.readRows(ent.Filename(), func(row Row) {
 			e := tl.Calendar{}
 			loadRow(&e, row)
-			out <- e
+			select {
+			case out <- e:
+			case <-reader.done():
+			}
 		})
 		close(out)
 	}()
@@ -387,10 +515,13 @@ func (reader *Reader) CalendarDates() (out chan tl.CalendarDate) {
 	out = make(chan tl.CalendarDate, bufferSize)
 	go func() {
 		ent := tl.CalendarDate{}
-		reader.Adapter.ReadRows(ent.Filename(), func(row Row) {
+		reader.readRows(ent.Filename(), func(row Row) {
 			e := tl.CalendarDate{}
 			loadRow(&e, row)
-			out <- e
+			select {
+			case out <- e:
+			case <-reader.done():
+			}
 		})
 		close(out)
 	}()
@@ -402,10 +533,13 @@ func (reader *Reader) FareAttributes() (out chan tl.FareAttribute) {
 	out = make(chan tl.FareAttribute, bufferSize)
 	go func() {
 		ent := tl.FareAttribute{}
-		reader.Adapter.ReadRows(ent.Filename(), func(row Row) {
+		reader.readRows(ent.Filename(), func(row Row) {
 			e := tl.FareAttribute{}
 			loadRow(&e, row)
-			out <- e
+			select {
+			case out <- e:
+			case <-reader.done():
+			}
 		})
 		close(out)
 	}()
@@ -417,10 +551,13 @@ func (reader *Reader) FareRules() (out chan tl.FareRule) {
 	out = make(chan tl.FareRule, bufferSize)
 	go func() {
 		ent := tl.FareRule{}
-		reader.Adapter.ReadRows(ent.Filename(), func(row Row) {
+		reader.readRows(ent.Filename(), func(row Row) {
 			e := tl.FareRule{}
 			loadRow(&e, row)
-			out <- e
+			select {
+			case out <- e:
+			case <-reader.done():
+			}
 		})
 		close(out)
 	}()
@@ -432,10 +569,13 @@ func (reader *Reader) FeedInfos() (out chan tl.FeedInfo) {
 	out = make(chan tl.FeedInfo, bufferSize)
 	go func() {
 		ent := tl.FeedInfo{}
-		reader.Adapter.ReadRows(ent.Filename(), func(row Row) {
+		reader.readRows(ent.Filename(), func(row Row) {
 			e := tl.FeedInfo{}
 			loadRow(&e, row)
-			out <- e
+			select {
+			case out <- e:
+			case <-reader.done():
+			}
 		})
 		close(out)
 	}()
@@ -447,10 +587,13 @@ func (reader *Reader) Frequencies() (out chan tl.Frequency) {
 	out = make(chan tl.Frequency, bufferSize)
 	go func() {
 		ent := tl.Frequency{}
-		reader.Adapter.ReadRows(ent.Filename(), func(row Row) {
+		reader.readRows(ent.Filename(), func(row Row) {
 			e := tl.Frequency{}
 			loadRow(&e, row)
-			out <- e
+			select {
+			case out <- e:
+			case <-reader.done():
+			}
 		})
 		close(out)
 	}()
@@ -462,10 +605,13 @@ func (reader *Reader) Routes() (out chan tl.Route) {
 	out = make(chan tl.Route, bufferSize)
 	go func() {
 		ent := tl.Route{}
-		reader.Adapter.ReadRows(ent.Filename(), func(row Row) {
+		reader.readRows(ent.Filename(), func(row Row) {
 			e := tl.Route{}
 			loadRow(&e, row)
-			out <- e
+			select {
+			case out <- e:
+			case <-reader.done():
+			}
 		})
 		close(out)
 	}()
@@ -477,10 +623,13 @@ func (reader *Reader) Transfers() (out chan tl.Transfer) {
 	out = make(chan tl.Transfer, bufferSize)
 	go func() {
 		ent := tl.Transfer{}
-		reader.Adapter.ReadRows(ent.Filename(), func(row Row) {
+		reader.readRows(ent.Filename(), func(row Row) {
 			e := tl.Transfer{}
 			loadRow(&e, row)
-			out <- e
+			select {
+			case out <- e:
+			case <-reader.done():
+			}
 		})
 		close(out)
 	}()
@@ -492,10 +641,13 @@ func (reader *Reader) Trips() (out chan tl.Trip) {
 	out = make(chan tl.Trip, bufferSize)
 	go func() {
 		ent := tl.Trip{}
-		reader.Adapter.ReadRows(ent.Filename(), func(row Row) {
+		reader.readRows(ent.Filename(), func(row Row) {
 			e := tl.Trip{}
 			loadRow(&e, row)
-			out <- e
+			select {
+			case out <- e:
+			case <-reader.done():
+			}
 		})
 		close(out)
 	}()
@@ -507,10 +659,13 @@ func (reader *Reader) Levels() (out chan tl.Level) {
 	out = make(chan tl.Level, bufferSize)
 	go func() {
 		ent := tl.Level{}
-		reader.Adapter.ReadRows(ent.Filename(), func(row Row) {
+		reader.readRows(ent.Filename(), func(row Row) {
 			e := tl.Level{}
 			loadRow(&e, row)
-			out <- e
+			select {
+			case out <- e:
+			case <-reader.done():
+			}
 		})
 		close(out)
 	}()
@@ -522,10 +677,13 @@ func (reader *Reader) Pathways() (out chan tl.Pathway) {
 	out = make(chan tl.Pathway, bufferSize)
 	go func() {
 		ent := tl.Pathway{}
-		reader.Adapter.ReadRows(ent.Filename(), func(row Row) {
+		reader.readRows(ent.Filename(), func(row Row) {
 			e := tl.Pathway{}
 			loadRow(&e, row)
-			out <- e
+			select {
+			case out <- e:
+			case <-reader.done():
+			}
 		})
 		close(out)
 	}()
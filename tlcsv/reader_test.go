@@ -1,9 +1,12 @@
 package tlcsv
 
 import (
+	"context"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/interline-io/transitland-lib/internal/testutil"
@@ -31,3 +34,146 @@ func TestReader(t *testing.T) {
 		})
 	}
 }
+
+func TestReader_SetContext(t *testing.T) {
+	reader, err := NewReader(testutil.ExampleDir.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := reader.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	reader.SetContext(ctx)
+	count := 0
+	for range reader.Stops() {
+		count++
+	}
+	if expect := testutil.ExampleDir.Counts["stops.txt"]; count != expect {
+		t.Errorf("got %d stops, expected %d", count, expect)
+	}
+	// No further entities after the context is cancelled
+	cancel()
+	count = 0
+	for range reader.Stops() {
+		count++
+	}
+	for range reader.StopTimesByTripID() {
+		count++
+	}
+	if count != 0 {
+		t.Errorf("got %d entities after cancel, expected 0", count)
+	}
+}
+
+func TestReader_SetContext_Cancel(t *testing.T) {
+	reader, err := NewReader(testutil.ExampleDir.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := reader.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reader.SetContext(ctx)
+	// Cancelling stops reading the rest of the file
+	count := 0
+	err = reader.readRows("stop_times.txt", func(row Row) {
+		count++
+		cancel()
+	})
+	if err != context.Canceled {
+		t.Errorf("got error '%v', expected '%v'", err, context.Canceled)
+	}
+	if count != 1 {
+		t.Errorf("got %d rows, expected 1", count)
+	}
+}
+
+func TestReader_Iterate(t *testing.T) {
+	reader, err := NewReader(testutil.ExampleDir.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := reader.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	ents := []tl.Entity{&tl.Agency{}, &tl.Route{}, &tl.Trip{}, &tl.Stop{}, &tl.StopTime{}, &tl.Shape{}, &tl.Calendar{}, &tl.FareRule{}, &tl.Transfer{}}
+	for _, ent := range ents {
+		t.Run(ent.Filename(), func(t *testing.T) {
+			it := reader.Iterate(ent)
+			defer it.Close()
+			count := 0
+			for it.Next() {
+				count++
+			}
+			// transfers.txt is not present, which is not an error
+			if err := it.Err(); err != nil {
+				t.Error(err)
+			}
+			if expect := testutil.ExampleDir.Counts[ent.Filename()]; count != expect {
+				t.Errorf("got %d entities, expected %d", count, expect)
+			}
+		})
+	}
+	t.Run("StopTimesByTripID", func(t *testing.T) {
+		it := reader.IterateStopTimesByTripID()
+		defer it.Close()
+		count := 0
+		for it.Next() {
+			count += len(it.Value())
+		}
+		if err := it.Err(); err != nil {
+			t.Error(err)
+		}
+		if expect := testutil.ExampleDir.Counts["stop_times.txt"]; count != expect {
+			t.Errorf("got %d stop_times, expected %d", count, expect)
+		}
+	})
+	t.Run("Close", func(t *testing.T) {
+		it := reader.Iterate(&tl.StopTime{})
+		if !it.Next() {
+			t.Fatal("expected an entity")
+		}
+		if err := it.Close(); err != nil {
+			t.Error(err)
+		}
+		if it.Next() {
+			t.Error("expected no entities after Close")
+		}
+	})
+}
+
+func TestReader_Iterate_Error(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gtfs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	fn := filepath.Join(dir, "bad.zip")
+	if err := ioutil.WriteFile(fn, []byte("not a zip file"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	reader, err := NewReader(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	it := reader.Iterate(&tl.Stop{})
+	defer it.Close()
+	for it.Next() {
+	}
+	if it.Err() == nil {
+		t.Error("expected an error")
+	}
+	stit := reader.IterateStopTimesByTripID()
+	defer stit.Close()
+	for stit.Next() {
+	}
+	if stit.Err() == nil {
+		t.Error("expected an error")
+	}
+}
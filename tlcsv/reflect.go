@@ -21,6 +21,10 @@ type canSetString interface {
 	AddError(error)
 }
 
+type canSetLine interface {
+	SetLine(int)
+}
+
 // check for Value
 type canValue interface {
 	Value() (driver.Value, error)
@@ -188,6 +192,9 @@ func loadRow(ent tl.Entity, row Row) {
 
 // LoadRowFast uses a fast path for entities that support SetString and AddError.
 func loadRowFast(ent canSetString, row Row) {
+	if v, ok := ent.(canSetLine); ok {
+		v.SetLine(row.Line)
+	}
 	// Return if there was a row parsing error
 	if row.Err != nil {
 		ent.AddError(causes.NewFileParseError(row.Line, row.Err))
@@ -204,6 +211,9 @@ func loadRowFast(ent canSetString, row Row) {
 
 // loadRowReflect is the Reflect path
 func loadRowReflect(ent tl.Entity, row Row) {
+	if v, ok := ent.(canSetLine); ok {
+		v.SetLine(row.Line)
+	}
 	// Return if there was a row parsing error
 	if row.Err != nil {
 		ent.AddError(causes.NewFileParseError(row.Line, row.Err))
@@ -87,3 +87,24 @@ func TestSetString(t *testing.T) {
 		}
 	})
 }
+
+func TestLoadRow_Line(t *testing.T) {
+	row := Row{
+		Row:    []string{"123", "1"},
+		Header: []string{"route_id", "route_type"},
+		Hindex: map[string]int{"route_id": 0, "route_type": 1},
+		Line:   5,
+	}
+	// Reflect path
+	route := tl.Route{}
+	loadRow(&route, row)
+	if route.Line() != 5 {
+		t.Errorf("got line %d, expected 5", route.Line())
+	}
+	// Fast path
+	st := tl.StopTime{}
+	loadRow(&st, row)
+	if st.Line() != 5 {
+		t.Errorf("got line %d, expected 5", st.Line())
+	}
+}
@@ -1,6 +1,7 @@
 package tlcsv
 
 import (
+	"context"
 	"encoding/csv"
 	"io"
 	"strings"
@@ -29,6 +30,12 @@ func (row *Row) Get(k string) (string, bool) {
 
 // ReadRows iterates through csv rows with callback.
 func ReadRows(in io.Reader, cb func(Row)) error {
+	return ReadRowsContext(context.Background(), in, cb)
+}
+
+// ReadRowsContext iterates through csv rows with callback, stopping with the context error when ctx is done.
+func ReadRowsContext(ctx context.Context, in io.Reader, cb func(Row)) error {
+	done := ctx.Done()
 	// Handle byte-order-marks.
 	r := csv.NewReader(utfbom.SkipOnly(in))
 	// Allow variable columns - very common in GTFS
@@ -56,6 +63,11 @@ func ReadRows(in io.Reader, cb func(Row)) error {
 	}
 	line := 2 // lines are 1-indexed, plus header
 	for {
+		select {
+		case <-done:
+			return ctx.Err()
+		default:
+		}
 		row, err := r.Read()
 		if err == nil {
 			// ok
@@ -1,7 +1,9 @@
 package tlcsv
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"math"
 	"strings"
 
@@ -12,10 +14,20 @@ type hasEntityKey interface {
 	EntityKey() string
 }
 
+type hasExtraKeys interface {
+	ExtraKeys() []string
+}
+
 // Writer implements a GTFS CSV Writer.
 type Writer struct {
 	WriterAdapter
-	headers map[string][]string
+	// Write extra fields that are not part of the specification as additional columns.
+	// The extra columns for each file are the fields present in the first batch of entities written to the file;
+	// later entities with other extra fields return an error.
+	WriteExtraColumns bool
+	headers           map[string][]string
+	extraHeaders      map[string][]string
+	ctx               context.Context
 }
 
 // NewWriter returns a new Writer.
@@ -29,9 +41,15 @@ func NewWriter(path string) (*Writer, error) {
 	return &Writer{
 		WriterAdapter: a,
 		headers:       map[string][]string{},
+		extraHeaders:  map[string][]string{},
 	}, nil
 }
 
+// SetContext sets a context; when it is done, entities are no longer written.
+func (writer *Writer) SetContext(ctx context.Context) {
+	writer.ctx = ctx
+}
+
 // Create the necessary files for the Writer.
 func (writer *Writer) Create() error {
 	// TODO: return error when output path exists
@@ -51,6 +69,9 @@ func (writer *Writer) NewReader() (tl.Reader, error) {
 // AddEntities writes entities to the output.
 func (writer *Writer) AddEntities(ents []tl.Entity) ([]string, error) {
 	eids := []string{}
+	if writer.ctx != nil && writer.ctx.Err() != nil {
+		return eids, writer.ctx.Err()
+	}
 	if len(ents) == 0 {
 		return eids, nil
 	}
@@ -69,8 +90,18 @@ func (writer *Writer) AddEntities(ents []tl.Entity) ([]string, error) {
 		}
 		header = h
 		writer.headers[efn] = header
-		writer.WriterAdapter.WriteRows(efn, [][]string{header})
+		if writer.WriteExtraColumns {
+			writer.extraHeaders[efn] = extraHeader(ents, header)
+		}
+		writer.WriterAdapter.WriteRows(efn, [][]string{append(append([]string{}, header...), writer.extraHeaders[efn]...)})
+	} else if writer.WriteExtraColumns {
+		// The header has been written; extra fields cannot be added as columns
+		written := append(append([]string{}, header...), writer.extraHeaders[efn]...)
+		if missing := extraHeader(ents, written); len(missing) > 0 {
+			return eids, fmt.Errorf("%s: extra column '%s' is not in the header, which was written with the first batch of entities", efn, missing[0])
+		}
 	}
+	extraHeader := writer.extraHeaders[efn]
 	rows := [][]string{}
 	for _, ent := range ents {
 		sid := ""
@@ -81,6 +112,12 @@ func (writer *Writer) AddEntities(ents []tl.Entity) ([]string, error) {
 		if err != nil {
 			return eids, err
 		}
+		if len(extraHeader) > 0 {
+			extra := ent.Extra()
+			for _, k := range extraHeader {
+				row = append(row, extra[k])
+			}
+		}
 		rows = append(rows, row)
 		eids = append(eids, sid)
 	}
@@ -111,6 +148,28 @@ func (writer *Writer) AddEntity(ent tl.Entity) (string, error) {
 	return eids[0], nil
 }
 
+// extraHeader returns the names of the extra fields in the entities that are not in the header.
+func extraHeader(ents []tl.Entity, header []string) []string {
+	seen := map[string]bool{}
+	for _, k := range header {
+		seen[k] = true
+	}
+	ret := []string{}
+	for _, ent := range ents {
+		v, ok := ent.(hasExtraKeys)
+		if !ok {
+			continue
+		}
+		for _, k := range v.ExtraKeys() {
+			if !seen[k] {
+				seen[k] = true
+				ret = append(ret, k)
+			}
+		}
+	}
+	return ret
+}
+
 func (writer *Writer) flattenShape(ent tl.Shape) []tl.Shape {
 	coords := ent.Geometry.FlatCoords()
 	shapes := []tl.Shape{}
@@ -1,6 +1,7 @@
 package tlcsv
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
 	"testing"
@@ -29,3 +30,91 @@ func TestWriter(t *testing.T) {
 		t.Error("did not remove temporary directory!", tmpdir)
 	}
 }
+
+func TestWriter_WriteExtraColumns(t *testing.T) {
+	for _, writeExtra := range []bool{false, true} {
+		t.Run(fmt.Sprintf("WriteExtraColumns=%t", writeExtra), func(t *testing.T) {
+			tmpdir, err := ioutil.TempDir("", "gtfs")
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer os.RemoveAll(tmpdir)
+			writer, err := NewWriter(tmpdir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			writer.WriteExtraColumns = writeExtra
+			ents := []tl.Entity{}
+			for _, aid := range []string{"a", "b"} {
+				ent := tl.Agency{AgencyID: aid, AgencyName: aid, AgencyURL: "http://example.com", AgencyTimezone: "America/Los_Angeles"}
+				ent.SetExtra("internal_code", aid+"1")
+				if aid == "b" {
+					ent.SetExtra("agency_color", "red")
+				}
+				ents = append(ents, &ent)
+			}
+			if _, err := writer.AddEntities(ents); err != nil {
+				t.Fatal(err)
+			}
+			writer.Close()
+			reader, err := NewReader(tmpdir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := reader.Open(); err != nil {
+				t.Fatal(err)
+			}
+			defer reader.Close()
+			got := map[string]map[string]string{}
+			for ent := range reader.Agencies() {
+				got[ent.AgencyID] = ent.Extra()
+			}
+			if len(got) != 2 {
+				t.Fatalf("got %d agencies, expected 2", len(got))
+			}
+			exp := map[string]map[string]string{"a": {}, "b": {}}
+			if writeExtra {
+				exp = map[string]map[string]string{
+					"a": {"internal_code": "a1", "agency_color": ""},
+					"b": {"internal_code": "b1", "agency_color": "red"},
+				}
+			}
+			for aid, extra := range exp {
+				if len(got[aid]) != len(extra) {
+					t.Errorf("agency '%s': got extra %v, expected %v", aid, got[aid], extra)
+					continue
+				}
+				for k, v := range extra {
+					if got[aid][k] != v {
+						t.Errorf("agency '%s': got '%s' for '%s', expected '%s'", aid, got[aid][k], k, v)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestWriter_WriteExtraColumns_Later(t *testing.T) {
+	// Extra fields that first appear after the header is written are an error, not silently dropped
+	tmpdir, err := ioutil.TempDir("", "gtfs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpdir)
+	writer, err := NewWriter(tmpdir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer writer.Close()
+	writer.WriteExtraColumns = true
+	for i, key := range []string{"internal_code", "internal_code", "agency_color"} {
+		ent := tl.Agency{AgencyID: fmt.Sprintf("a%d", i), AgencyName: "a", AgencyURL: "http://example.com", AgencyTimezone: "America/Los_Angeles"}
+		ent.SetExtra(key, "x")
+		_, err := writer.AddEntities([]tl.Entity{&ent})
+		if expectErr := key == "agency_color"; expectErr && err == nil {
+			t.Errorf("expected an error for extra field '%s'", key)
+		} else if !expectErr && err != nil {
+			t.Error(err)
+		}
+	}
+}
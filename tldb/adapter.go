@@ -43,5 +43,6 @@ type Adapter interface {
 	Get(interface{}, string, ...interface{}) error
 	Select(interface{}, string, ...interface{}) error
 	MultiInsert([]interface{}) ([]int, error)
+	MultiUpsert([]interface{}, []string) ([]int, error)
 	CopyInsert([]interface{}) error
 }
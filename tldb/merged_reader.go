@@ -0,0 +1,304 @@
+package tldb
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/interline-io/transitland-lib/tl"
+	"github.com/interline-io/transitland-lib/tl/causes"
+)
+
+// MergedReader reads multiple feed versions as a single feed.
+// GTFS IDs are prefixed with the feed Onestop ID, e.g. "f-9q9-caltrain:70011", so IDs from different feeds do not collide.
+// Agencies with the same name, URL, and timezone are only read once, and references to the duplicates use the first agency.
+// FeedInfos are not read, since they cannot describe the merged feed.
+type MergedReader struct {
+	*Reader
+	once       sync.Once
+	loadErr    error
+	namespaces map[int]string // feed version ID to ID prefix
+	agencies   map[int]int    // duplicate agency ID to first agency ID
+}
+
+// NewMergedReader returns a MergedReader for the feed versions.
+func NewMergedReader(adapter Adapter, fvids []int) *MergedReader {
+	return &MergedReader{Reader: &Reader{Adapter: adapter, PageSize: 1000, FeedVersionIDs: fvids}}
+}
+
+// ValidateStructure also checks that each feed version exists and is from a different feed.
+func (reader *MergedReader) ValidateStructure() []error {
+	errs := reader.Reader.ValidateStructure()
+	if err := reader.load(); err != nil {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// load finds the namespace for each feed version and the duplicate agencies.
+func (reader *MergedReader) load() error {
+	reader.once.Do(func() {
+		reader.loadErr = reader.loadNamespaces()
+		if reader.loadErr == nil {
+			reader.loadErr = reader.loadAgencies()
+		}
+	})
+	return reader.loadErr
+}
+
+func (reader *MergedReader) loadNamespaces() error {
+	rows := []struct {
+		ID        int
+		OnestopID string
+	}{}
+	qstr, args, err := reader.Adapter.Sqrl().
+		Select("feed_versions.id", "current_feeds.onestop_id").
+		From("feed_versions").
+		Join("current_feeds ON current_feeds.id = feed_versions.feed_id").
+		Where(sq.Eq{"feed_versions.id": reader.FeedVersionIDs}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	if err := reader.Adapter.Select(&rows, qstr, args...); err != nil {
+		return causes.NewSourceUnreadableError("could not read feed versions", err)
+	}
+	reader.namespaces = map[int]string{}
+	seen := map[string]int{}
+	for _, row := range rows {
+		if other, ok := seen[row.OnestopID]; ok {
+			msg := fmt.Sprintf("feed versions %d and %d are both from feed '%s'", other, row.ID, row.OnestopID)
+			return causes.NewSourceUnreadableError(msg, nil)
+		}
+		seen[row.OnestopID] = row.ID
+		reader.namespaces[row.ID] = row.OnestopID
+	}
+	for _, fvid := range reader.FeedVersionIDs {
+		if _, ok := reader.namespaces[fvid]; !ok {
+			return causes.NewSourceUnreadableError(fmt.Sprintf("feed version %d not found", fvid), nil)
+		}
+	}
+	return nil
+}
+
+func (reader *MergedReader) loadAgencies() error {
+	rows := []struct {
+		ID             int
+		AgencyName     string
+		AgencyURL      string
+		AgencyTimezone string
+	}{}
+	qstr, args, err := reader.Adapter.Sqrl().
+		Select("id", "agency_name", "agency_url", "agency_timezone").
+		From("gtfs_agencies").
+		Where(sq.Eq{"feed_version_id": reader.FeedVersionIDs}).
+		OrderBy("id").
+		ToSql()
+	if err != nil {
+		return err
+	}
+	if err := reader.Adapter.Select(&rows, qstr, args...); err != nil {
+		return causes.NewSourceUnreadableError("could not read agencies", err)
+	}
+	reader.agencies = map[int]int{}
+	first := map[string]int{}
+	for _, row := range rows {
+		if row.AgencyName == "" {
+			continue
+		}
+		key := strings.ToLower(strings.TrimSpace(row.AgencyName)) + "|" + strings.ToLower(strings.TrimSpace(row.AgencyURL)) + "|" + row.AgencyTimezone
+		if id, ok := first[key]; ok {
+			reader.agencies[row.ID] = id
+		} else {
+			first[key] = row.ID
+		}
+	}
+	return nil
+}
+
+// agencyKey returns the agency reference, replacing duplicate agencies with the first agency.
+func (reader *MergedReader) agencyKey(key string) string {
+	id, err := strconv.Atoi(key)
+	if err != nil {
+		return key
+	}
+	if first, ok := reader.agencies[id]; ok {
+		return strconv.Itoa(first)
+	}
+	return key
+}
+
+// prefix returns the GTFS ID prefixed with the feed version namespace, or an empty string.
+func (reader *MergedReader) prefix(fvid int, id string) string {
+	if id == "" {
+		return ""
+	}
+	return reader.namespaces[fvid] + ":" + id
+}
+
+// merge namespaces the GTFS IDs in the entity; it returns false if the entity should be skipped.
+func (reader *MergedReader) merge(ent tl.Entity) bool {
+	switch v := ent.(type) {
+	case *tl.Agency:
+		if _, ok := reader.agencies[v.ID]; ok {
+			return false
+		}
+		if v.AgencyID == "" {
+			v.AgencyID = reader.namespaces[v.FeedVersionID]
+		} else {
+			v.AgencyID = reader.prefix(v.FeedVersionID, v.AgencyID)
+		}
+	case *tl.Route:
+		v.RouteID = reader.prefix(v.FeedVersionID, v.RouteID)
+		v.AgencyID = reader.agencyKey(v.AgencyID)
+	case *tl.Stop:
+		v.StopID = reader.prefix(v.FeedVersionID, v.StopID)
+		v.ZoneID = reader.prefix(v.FeedVersionID, v.ZoneID)
+	case *tl.Trip:
+		v.TripID = reader.prefix(v.FeedVersionID, v.TripID)
+		v.BlockID = reader.prefix(v.FeedVersionID, v.BlockID)
+	case *tl.Calendar:
+		v.ServiceID = reader.prefix(v.FeedVersionID, v.ServiceID)
+	case *tl.Shape:
+		v.ShapeID = reader.prefix(v.FeedVersionID, v.ShapeID)
+	case *tl.FareAttribute:
+		v.FareID = reader.prefix(v.FeedVersionID, v.FareID)
+		if v.AgencyID.Valid {
+			v.AgencyID.Key = reader.agencyKey(v.AgencyID.Key)
+		}
+	case *tl.FareRule:
+		v.OriginID = reader.prefix(v.FeedVersionID, v.OriginID)
+		v.DestinationID = reader.prefix(v.FeedVersionID, v.DestinationID)
+		v.ContainsID = reader.prefix(v.FeedVersionID, v.ContainsID)
+	case *tl.Level:
+		v.LevelID = reader.prefix(v.FeedVersionID, v.LevelID)
+	case *tl.Pathway:
+		v.PathwayID = reader.prefix(v.FeedVersionID, v.PathwayID)
+	case *tl.FeedInfo:
+		return false
+	}
+	return true
+}
+
+// Iterate returns an EntityIterator for the merged entities.
+func (reader *MergedReader) Iterate(ent tl.Entity) tl.EntityIterator {
+	return tl.NewEntityIterator(func(send func(tl.Entity) bool) error {
+		if err := reader.load(); err != nil {
+			return err
+		}
+		it := reader.Reader.Iterate(ent)
+		defer it.Close()
+		for it.Next() {
+			e := it.Value()
+			if reader.merge(e) && !send(e) {
+				return nil
+			}
+		}
+		return it.Err()
+	})
+}
+
+// ReadEntities provides a generic interface for reading merged entities.
+func (reader *MergedReader) ReadEntities(c interface{}) error {
+	outValue := reflect.ValueOf(c)
+	ent, ok := reflect.New(outValue.Type().Elem()).Interface().(tl.Entity)
+	if !ok {
+		return causes.NewSourceUnreadableError("not an entity", nil)
+	}
+	if err := reader.load(); err != nil {
+		return err
+	}
+	go reader.send(ent, func(e tl.Entity) { outValue.Send(reflect.ValueOf(e).Elem()) }, outValue.Close)
+	return nil
+}
+
+// send reads the merged entities of the same type as ent, then calls done.
+func (reader *MergedReader) send(ent tl.Entity, cb func(tl.Entity), done func()) {
+	it := reader.Iterate(ent)
+	for it.Next() {
+		cb(it.Value())
+	}
+	check(it.Err())
+	it.Close()
+	done()
+}
+
+// Agencies sends merged Agencies.
+func (reader *MergedReader) Agencies() chan tl.Agency {
+	out := make(chan tl.Agency, bufferSize)
+	go reader.send(&tl.Agency{}, func(e tl.Entity) { out <- *e.(*tl.Agency) }, func() { close(out) })
+	return out
+}
+
+// Routes sends merged Routes.
+func (reader *MergedReader) Routes() chan tl.Route {
+	out := make(chan tl.Route, bufferSize)
+	go reader.send(&tl.Route{}, func(e tl.Entity) { out <- *e.(*tl.Route) }, func() { close(out) })
+	return out
+}
+
+// Stops sends merged Stops.
+func (reader *MergedReader) Stops() chan tl.Stop {
+	out := make(chan tl.Stop, bufferSize)
+	go reader.send(&tl.Stop{}, func(e tl.Entity) { out <- *e.(*tl.Stop) }, func() { close(out) })
+	return out
+}
+
+// Trips sends merged Trips.
+func (reader *MergedReader) Trips() chan tl.Trip {
+	out := make(chan tl.Trip, bufferSize)
+	go reader.send(&tl.Trip{}, func(e tl.Entity) { out <- *e.(*tl.Trip) }, func() { close(out) })
+	return out
+}
+
+// Calendars sends merged Calendars.
+func (reader *MergedReader) Calendars() chan tl.Calendar {
+	out := make(chan tl.Calendar, bufferSize)
+	go reader.send(&tl.Calendar{}, func(e tl.Entity) { out <- *e.(*tl.Calendar) }, func() { close(out) })
+	return out
+}
+
+// Shapes sends merged Shapes.
+func (reader *MergedReader) Shapes() chan tl.Shape {
+	out := make(chan tl.Shape, bufferSize)
+	go reader.send(&tl.Shape{}, func(e tl.Entity) { out <- *e.(*tl.Shape) }, func() { close(out) })
+	return out
+}
+
+// FareAttributes sends merged FareAttributes.
+func (reader *MergedReader) FareAttributes() chan tl.FareAttribute {
+	out := make(chan tl.FareAttribute, bufferSize)
+	go reader.send(&tl.FareAttribute{}, func(e tl.Entity) { out <- *e.(*tl.FareAttribute) }, func() { close(out) })
+	return out
+}
+
+// FareRules sends merged FareRules.
+func (reader *MergedReader) FareRules() chan tl.FareRule {
+	out := make(chan tl.FareRule, bufferSize)
+	go reader.send(&tl.FareRule{}, func(e tl.Entity) { out <- *e.(*tl.FareRule) }, func() { close(out) })
+	return out
+}
+
+// Levels sends merged Levels.
+func (reader *MergedReader) Levels() chan tl.Level {
+	out := make(chan tl.Level, bufferSize)
+	go reader.send(&tl.Level{}, func(e tl.Entity) { out <- *e.(*tl.Level) }, func() { close(out) })
+	return out
+}
+
+// Pathways sends merged Pathways.
+func (reader *MergedReader) Pathways() chan tl.Pathway {
+	out := make(chan tl.Pathway, bufferSize)
+	go reader.send(&tl.Pathway{}, func(e tl.Entity) { out <- *e.(*tl.Pathway) }, func() { close(out) })
+	return out
+}
+
+// FeedInfos sends nothing; see MergedReader.
+func (reader *MergedReader) FeedInfos() chan tl.FeedInfo {
+	out := make(chan tl.FeedInfo)
+	close(out)
+	return out
+}
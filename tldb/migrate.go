@@ -0,0 +1,295 @@
+package tldb
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/interline-io/transitland-lib/internal/log"
+	"github.com/rakyll/statik/fs"
+)
+
+// Migrations are embedded with the schema, in /migrations/<driver>/<version>_<name>.up.sql and .down.sql.
+// The schema snapshots used by Create include all migrations; databases created before migrations were added are at version 0.
+
+// Migration is a versioned change to the database schema.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string // empty if the migration cannot be reverted
+}
+
+// MigrationStatus describes a Migration and whether it has been applied.
+type MigrationStatus struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+type schemaMigration struct {
+	Version   int
+	Name      string
+	AppliedAt time.Time
+}
+
+var migrationTables = map[string]string{
+	"postgres": `CREATE TABLE IF NOT EXISTS public.schema_migrations (version bigint PRIMARY KEY, name character varying NOT NULL, applied_at timestamp without time zone NOT NULL)`,
+	"sqlite":   `CREATE TABLE IF NOT EXISTS "schema_migrations" ("version" integer PRIMARY KEY, "name" varchar(255) NOT NULL, "applied_at" datetime NOT NULL)`,
+}
+
+var migrationTableExists = map[string]string{
+	"postgres": `SELECT count(*) FROM information_schema.tables WHERE table_schema = 'public' AND table_name = 'schema_migrations'`,
+	"sqlite":   `SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = 'schema_migrations'`,
+}
+
+// migrationDriver returns the migrations directory for the adapter's database driver.
+func migrationDriver(adapter Adapter) (string, error) {
+	switch name := adapter.DBX().DriverName(); name {
+	case "postgres":
+		return "postgres", nil
+	case "sqlite3", "sqlite3_w_funcs":
+		return "sqlite", nil
+	default:
+		return "", fmt.Errorf("migrations are not supported for database driver '%s'", name)
+	}
+}
+
+// Migrations returns the migrations for the adapter's database, in order.
+func Migrations(adapter Adapter) ([]Migration, error) {
+	driver, err := migrationDriver(adapter)
+	if err != nil {
+		return nil, err
+	}
+	return driverMigrations(driver)
+}
+
+// driverMigrations returns the migrations in the directory for a driver, in order.
+func driverMigrations(driver string) ([]Migration, error) {
+	statikFS, err := fs.New()
+	if err != nil {
+		return nil, err
+	}
+	byVersion := map[int]*Migration{}
+	root := path.Join("/migrations", driver)
+	err = fs.Walk(statikFS, root, func(fn string, fi os.FileInfo, err error) error {
+		if err != nil || fi.IsDir() {
+			return err
+		}
+		base := filepath.Base(fn)
+		direction := ""
+		if strings.HasSuffix(base, ".up.sql") {
+			direction = "up"
+		} else if strings.HasSuffix(base, ".down.sql") {
+			direction = "down"
+		} else {
+			return nil
+		}
+		name := strings.TrimSuffix(base, "."+direction+".sql")
+		split := strings.SplitN(name, "_", 2)
+		version, err := strconv.Atoi(split[0])
+		if err != nil || len(split) != 2 {
+			return fmt.Errorf("invalid migration filename '%s'", base)
+		}
+		data, err := fs.ReadFile(statikFS, fn)
+		if err != nil {
+			return err
+		}
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: split[1]}
+			byVersion[version] = m
+		}
+		if direction == "up" {
+			m.Up = string(data)
+		} else {
+			m.Down = string(data)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	ret := []Migration{}
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration %d has no up migration", m.Version)
+		}
+		ret = append(ret, *m)
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Version < ret[j].Version })
+	return ret, nil
+}
+
+// LatestSchemaVersion returns the version of the last migration for the adapter's database.
+func LatestSchemaVersion(adapter Adapter) (int, error) {
+	migrations, err := Migrations(adapter)
+	if err != nil || len(migrations) == 0 {
+		return 0, err
+	}
+	return migrations[len(migrations)-1].Version, nil
+}
+
+// SchemaVersion returns the version of the last applied migration, or 0 if none have been applied.
+func SchemaVersion(adapter Adapter) (int, error) {
+	applied, err := appliedMigrations(adapter)
+	if err != nil {
+		return 0, err
+	}
+	version := 0
+	for v := range applied {
+		if v > version {
+			version = v
+		}
+	}
+	return version, nil
+}
+
+// MigrationStatuses returns all migrations and whether they have been applied.
+func MigrationStatuses(adapter Adapter) ([]MigrationStatus, error) {
+	migrations, err := Migrations(adapter)
+	if err != nil {
+		return nil, err
+	}
+	applied, err := appliedMigrations(adapter)
+	if err != nil {
+		return nil, err
+	}
+	ret := []MigrationStatus{}
+	for _, m := range migrations {
+		s := MigrationStatus{Migration: m}
+		if a, ok := applied[m.Version]; ok {
+			s.Applied = true
+			s.AppliedAt = a.AppliedAt
+		}
+		ret = append(ret, s)
+	}
+	return ret, nil
+}
+
+// MigrateUp applies migrations that have not been applied, up to and including the target version, or all if target is 0.
+// Each migration is applied in a separate transaction. The applied migrations are returned.
+func MigrateUp(adapter Adapter, target int) ([]Migration, error) {
+	ret := []Migration{}
+	if err := createMigrationsTable(adapter); err != nil {
+		return ret, err
+	}
+	statuses, err := MigrationStatuses(adapter)
+	if err != nil {
+		return ret, err
+	}
+	for _, s := range statuses {
+		if s.Applied || (target > 0 && s.Version > target) {
+			continue
+		}
+		log.Info("Applying migration %d: %s", s.Version, s.Name)
+		err := adapter.Tx(func(atx Adapter) error {
+			if _, err := atx.DBX().Exec(s.Up); err != nil {
+				return err
+			}
+			return recordMigration(atx, s.Migration)
+		})
+		if err != nil {
+			return ret, fmt.Errorf("migration %d failed: %s", s.Version, err.Error())
+		}
+		ret = append(ret, s.Migration)
+	}
+	return ret, nil
+}
+
+// MigrateDown reverts the given number of applied migrations, starting with the most recent.
+// Each migration is reverted in a separate transaction. The reverted migrations are returned.
+func MigrateDown(adapter Adapter, steps int) ([]Migration, error) {
+	ret := []Migration{}
+	statuses, err := MigrationStatuses(adapter)
+	if err != nil {
+		return ret, err
+	}
+	for i := len(statuses) - 1; i >= 0 && len(ret) < steps; i-- {
+		s := statuses[i]
+		if !s.Applied {
+			continue
+		}
+		if s.Down == "" {
+			return ret, fmt.Errorf("migration %d cannot be reverted", s.Version)
+		}
+		log.Info("Reverting migration %d: %s", s.Version, s.Name)
+		err := adapter.Tx(func(atx Adapter) error {
+			if _, err := atx.DBX().Exec(s.Down); err != nil {
+				return err
+			}
+			_, err := atx.Sqrl().Delete("schema_migrations").Where("version = ?", s.Version).Exec()
+			return err
+		})
+		if err != nil {
+			return ret, fmt.Errorf("reverting migration %d failed: %s", s.Version, err.Error())
+		}
+		ret = append(ret, s.Migration)
+	}
+	return ret, nil
+}
+
+// markMigrationsApplied records all migrations as applied; used after creating the schema from a snapshot.
+func markMigrationsApplied(adapter Adapter) error {
+	migrations, err := Migrations(adapter)
+	if err != nil {
+		return err
+	}
+	if err := createMigrationsTable(adapter); err != nil {
+		return err
+	}
+	for _, m := range migrations {
+		if err := recordMigration(adapter, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func recordMigration(adapter Adapter, m Migration) error {
+	_, err := adapter.Sqrl().
+		Insert("schema_migrations").
+		Columns("version", "name", "applied_at").
+		Values(m.Version, m.Name, time.Now().UTC()).
+		Exec()
+	return err
+}
+
+func createMigrationsTable(adapter Adapter) error {
+	driver, err := migrationDriver(adapter)
+	if err != nil {
+		return err
+	}
+	_, err = adapter.DBX().Exec(migrationTables[driver])
+	return err
+}
+
+// appliedMigrations returns the applied migrations by version; none if the schema_migrations table does not exist.
+func appliedMigrations(adapter Adapter) (map[int]schemaMigration, error) {
+	ret := map[int]schemaMigration{}
+	driver, err := migrationDriver(adapter)
+	if err != nil {
+		return nil, err
+	}
+	// Check the catalog instead of querying the table, which would abort a Postgres transaction if missing
+	count := 0
+	if err := adapter.Get(&count, migrationTableExists[driver]); err != nil {
+		return nil, err
+	}
+	if count == 0 {
+		return ret, nil
+	}
+	rows := []schemaMigration{}
+	if err := adapter.Select(&rows, "SELECT version, name, applied_at FROM schema_migrations"); err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		ret[row.Version] = row
+	}
+	return ret, nil
+}
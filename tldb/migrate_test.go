@@ -0,0 +1,151 @@
+// +build cgo
+
+package tldb
+
+import (
+	"testing"
+)
+
+func TestMigrate(t *testing.T) {
+	adapter := &SQLiteAdapter{DBURL: "sqlite3://:memory:"}
+	if err := adapter.Open(); err != nil {
+		t.Fatal(err)
+	}
+	defer adapter.Close()
+	if err := adapter.Create(); err != nil {
+		t.Fatal(err)
+	}
+	reader := &Reader{Adapter: adapter}
+	migrations, err := Migrations(adapter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("expected migrations")
+	}
+	latest := migrations[len(migrations)-1].Version
+	t.Run("Create", func(t *testing.T) {
+		// Create applies the schema snapshot, which includes all migrations
+		if v, err := SchemaVersion(adapter); err != nil {
+			t.Error(err)
+		} else if v != latest {
+			t.Errorf("got version %d, expected %d", v, latest)
+		}
+		if errs := reader.ValidateStructure(); len(errs) > 0 {
+			t.Errorf("got errors: %v", errs)
+		}
+	})
+	t.Run("Up", func(t *testing.T) {
+		// Mark the unique indexes migration as not applied
+		if _, err := adapter.Sqrl().Delete("schema_migrations").Where("version = ?", 2).Exec(); err != nil {
+			t.Fatal(err)
+		}
+		if errs := reader.ValidateStructure(); len(errs) != 1 {
+			t.Errorf("got %d errors, expected 1", len(errs))
+		}
+		applied, err := MigrateUp(adapter, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(applied) != 1 || applied[0].Version != 2 {
+			t.Errorf("got %v, expected migration 2", applied)
+		}
+		if errs := reader.ValidateStructure(); len(errs) > 0 {
+			t.Errorf("got errors: %v", errs)
+		}
+		// No pending migrations
+		if applied, err := MigrateUp(adapter, 0); err != nil {
+			t.Error(err)
+		} else if len(applied) != 0 {
+			t.Errorf("got %d applied migrations, expected 0", len(applied))
+		}
+	})
+	t.Run("Down", func(t *testing.T) {
+		// Revert the jobs migration
+		if reverted, err := MigrateDown(adapter, 1); err != nil {
+			t.Fatal(err)
+		} else if len(reverted) != 1 || reverted[0].Version != latest {
+			t.Errorf("got %v, expected migration %d", reverted, latest)
+		}
+		// Revert the remaining migrations
+		reverted, err := MigrateDown(adapter, len(migrations))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(reverted) != len(migrations)-1 {
+			t.Errorf("got %d reverted migrations, expected %d", len(reverted), len(migrations)-1)
+		}
+		statuses, err := MigrationStatuses(adapter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, s := range statuses {
+			if s.Applied {
+				t.Errorf("migration %d: expected not applied", s.Version)
+			}
+		}
+		count := 0
+		if err := adapter.Get(&count, "SELECT count(*) FROM sqlite_master WHERE type = 'index' AND name = 'idx_gtfs_stops_unique'"); err != nil {
+			t.Fatal(err)
+		}
+		if count != 0 {
+			t.Errorf("expected index to be dropped")
+		}
+		if err := adapter.Get(&count, "SELECT count(*) FROM sqlite_master WHERE type = 'table' AND name = 'dmfr_jobs'"); err != nil {
+			t.Fatal(err)
+		}
+		if count != 0 {
+			t.Errorf("expected table to be dropped")
+		}
+		columns := []struct {
+			table  string
+			column string
+		}{
+			{"feed_version_gtfs_imports", "progress"},
+			{"feed_version_gtfs_imports", "checkpoint"},
+			{"gtfs_stops", "extra"},
+			{"gtfs_stop_times", "extra"},
+			{"feed_states", "fetch_interval"},
+		}
+		for _, c := range columns {
+			if err := adapter.Get(&count, "SELECT count(*) FROM pragma_table_info(?) WHERE name = ?", c.table, c.column); err != nil {
+				t.Fatal(err)
+			}
+			if count != 0 {
+				t.Errorf("expected %s.%s to be dropped", c.table, c.column)
+			}
+		}
+	})
+	t.Run("Redo", func(t *testing.T) {
+		applied, err := MigrateUp(adapter, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(applied) != len(migrations) {
+			t.Errorf("got %d applied migrations, expected %d", len(applied), len(migrations))
+		}
+		if errs := reader.ValidateStructure(); len(errs) > 0 {
+			t.Errorf("got errors: %v", errs)
+		}
+	})
+}
+
+func TestMigrations_Versions(t *testing.T) {
+	// Postgres and SQLite migrations are numbered the same way, without gaps
+	versions := map[string][]int{}
+	for _, driver := range []string{"postgres", "sqlite"} {
+		migrations, err := driverMigrations(driver)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, m := range migrations {
+			if m.Version != i+1 {
+				t.Errorf("%s: got migration %d, expected %d", driver, m.Version, i+1)
+			}
+			versions[driver] = append(versions[driver], m.Version)
+		}
+	}
+	if len(versions["postgres"]) != len(versions["sqlite"]) {
+		t.Errorf("got postgres migrations %v, sqlite migrations %v", versions["postgres"], versions["sqlite"])
+	}
+}
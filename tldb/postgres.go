@@ -2,6 +2,8 @@ package tldb
 
 import (
 	"database/sql"
+	"database/sql/driver"
+	"errors"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/interline-io/transitland-lib/ext"
@@ -57,8 +59,10 @@ func (adapter *PostgresAdapter) Create() error {
 	if err != nil {
 		return err
 	}
-	_, err = adapter.db.Exec(schema)
-	return err
+	if _, err := adapter.db.Exec(schema); err != nil {
+		return err
+	}
+	return markMigrationsApplied(adapter)
 }
 
 // DBX returns sqlx.Ext
@@ -166,13 +170,91 @@ func (adapter *PostgresAdapter) MultiInsert(ents []interface{}) ([]int, error) {
 	return retids, err
 }
 
+// MultiUpsert builds and executes a multi-insert statement that updates the existing entities with the same values in the unique key columns.
+func (adapter *PostgresAdapter) MultiUpsert(ents []interface{}, keys []string) ([]int, error) {
+	retids := []int{}
+	if len(ents) == 0 {
+		return retids, nil
+	}
+	cols, _, err := getInsert(ents[0])
+	if err != nil {
+		return retids, err
+	}
+	table := getTableName(ents[0])
+	suffix := getUpsertSuffix(cols, keys) + ` RETURNING "id"`
+	batchSize := 65536 / (len(cols) + 1)
+	for i := 0; i < len(ents); i += batchSize {
+		batch := ents[i:min(i+batchSize, len(ents))]
+		q := adapter.Sqrl().Insert(table).Columns(cols...)
+		for _, d := range batch {
+			_, vals, _ := getInsert(d)
+			q = q.Values(vals...)
+		}
+		rows, err := q.Suffix(suffix).Query()
+		if err != nil {
+			return retids, err
+		}
+		var eid sql.NullInt64
+		for rows.Next() {
+			if err := rows.Scan(&eid); err != nil {
+				rows.Close()
+				return retids, err
+			}
+			retids = append(retids, int(eid.Int64))
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return retids, err
+		}
+	}
+	return retids, nil
+}
+
 // CopyInsert inserts data using COPY.
 func (adapter *PostgresAdapter) CopyInsert(ents []interface{}) error {
+	return adapter.copyInsert(ents, nil)
+}
+
+// CopyInsertWithIDs allocates IDs from the table sequence, sets them on the entities, and inserts the entities using COPY.
+func (adapter *PostgresAdapter) CopyInsertWithIDs(ents []interface{}) ([]int, error) {
+	retids := []int{}
+	if len(ents) == 0 {
+		return retids, nil
+	}
+	table := getTableName(ents[0])
+	q := "SELECT nextval(pg_get_serial_sequence(?, 'id')) FROM generate_series(1, ?)"
+	if err := adapter.Select(&retids, q, table, len(ents)); err != nil {
+		return []int{}, err
+	}
+	if len(retids) != len(ents) {
+		return []int{}, errors.New("failed to allocate expected ids")
+	}
+	for i, ent := range ents {
+		if v, ok := ent.(canSetID); ok {
+			v.SetID(retids[i])
+		}
+	}
+	if err := adapter.copyInsert(ents, retids); err != nil {
+		return []int{}, err
+	}
+	return retids, nil
+}
+
+// copyInsert writes the entities using COPY, including the id column if ids are provided.
+// COPY must run in a transaction; a new transaction is used if the adapter is not already in one.
+func (adapter *PostgresAdapter) copyInsert(ents []interface{}, ids []int) error {
 	if len(ents) == 0 {
 		return nil
 	}
+	cols, _, err := getInsert(ents[0])
+	if err != nil {
+		return err
+	}
+	if ids != nil {
+		cols = append([]string{"id"}, cols...)
+	}
+	table := getTableName(ents[0])
 	// Must be in a txn
-	var err error
 	var tx *sqlx.Tx
 	commit := true
 	if a, ok := adapter.db.(*queryLogger); ok {
@@ -187,29 +269,65 @@ func (adapter *PostgresAdapter) CopyInsert(ents []interface{}) error {
 	if err != nil {
 		return err
 	}
-	cols, _, err := getInsert(ents[0])
-	table := getTableName(ents[0])
+	if tx == nil {
+		return errors.New("COPY requires a transaction")
+	}
+	err = copyIn(tx, table, cols, ents, ids)
+	if !commit {
+		return err
+	}
+	if err != nil {
+		if errTx := tx.Rollback(); errTx != nil {
+			return errTx
+		}
+		return err
+	}
+	return tx.Commit()
+}
+
+func copyIn(tx *sqlx.Tx, table string, cols []string, ents []interface{}, ids []int) error {
 	stmt, err := tx.Prepare(pq.CopyIn(table, cols...))
-	defer stmt.Close()
 	if err != nil {
 		return err
 	}
-	for _, d := range ents {
+	defer stmt.Close()
+	row := make([]interface{}, 0, len(cols))
+	for i, d := range ents {
 		_, vals, err := getInsert(d)
 		if err != nil {
 			return err
 		}
-		_, err = stmt.Exec(vals...)
-		if err != nil {
+		row = row[:0]
+		if ids != nil {
+			row = append(row, ids[i])
+		}
+		for _, val := range vals {
+			v, err := copyValue(val)
+			if err != nil {
+				return err
+			}
+			row = append(row, v)
+		}
+		if _, err := stmt.Exec(row...); err != nil {
 			return err
 		}
 	}
 	_, err = stmt.Exec()
-	if err != nil {
-		return err
+	return err
+}
+
+// copyValue converts a value to a form that COPY can encode.
+// COPY encodes byte slices as bytea, but the geography and jsonb columns expect text:
+// geometries are hex encoded EWKB, and JSON values are encoded documents.
+func copyValue(val interface{}) (interface{}, error) {
+	if v, ok := val.(driver.Valuer); ok {
+		var err error
+		if val, err = v.Value(); err != nil {
+			return nil, err
+		}
 	}
-	if commit {
-		return tx.Commit()
+	if b, ok := val.([]byte); ok {
+		return string(b), nil
 	}
-	return nil
+	return val, nil
 }
@@ -2,7 +2,10 @@ package tldb
 
 import (
 	"os"
+	"strconv"
 	"testing"
+
+	"github.com/interline-io/transitland-lib/tl"
 )
 
 func init() {
@@ -21,3 +24,77 @@ func TestPostgresAdapter(t *testing.T) {
 	adapter := &PostgresAdapter{DBURL: dburl}
 	testAdapter(t, adapter)
 }
+
+func TestPostgresAdapter_CopyInsertWithIDs(t *testing.T) {
+	dburl := os.Getenv("TRANSITLAND_TEST_POSTGRES_URL")
+	if dburl == "" {
+		t.Skip("TRANSITLAND_TEST_POSTGRES_URL is not set")
+		return
+	}
+	adapter := &PostgresAdapter{DBURL: dburl}
+	if err := adapter.Open(); err != nil {
+		t.Fatal(err)
+	}
+	fvid, err := createTestFeedVersion(adapter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ents := []interface{}{}
+	for i := 0; i < 3; i++ {
+		ent := tl.Shape{}
+		ent.FeedVersionID = fvid
+		ent.ShapeID = strconv.Itoa(i)
+		ent.Geometry = tl.NewLineStringFromFlatCoords([]float64{-122.0, 37.0, 0, -122.1, 37.1, float64(i + 1)})
+		ents = append(ents, &ent)
+	}
+	ids, err := adapter.CopyInsertWithIDs(ents)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != len(ents) {
+		t.Fatalf("got %d ids, expected %d", len(ids), len(ents))
+	}
+	for i, ent := range ents {
+		shape := ent.(*tl.Shape)
+		if shape.ID != ids[i] {
+			t.Errorf("got id %d, expected %d", shape.ID, ids[i])
+		}
+		got := tl.Shape{}
+		if err := adapter.Get(&got, "SELECT * FROM gtfs_shapes WHERE id = ?", ids[i]); err != nil {
+			t.Fatal(err)
+		}
+		if got.ShapeID != shape.ShapeID {
+			t.Errorf("got shape_id '%s', expected '%s'", got.ShapeID, shape.ShapeID)
+		}
+		if got.Geometry.NumCoords() != 2 {
+			t.Errorf("got %d coords, expected 2", got.Geometry.NumCoords())
+		}
+	}
+}
+
+func TestCopyValue(t *testing.T) {
+	pt := tl.NewPoint(-122.0, 37.0)
+	ptval, _ := pt.Value()
+	tcs := []struct {
+		name string
+		val  interface{}
+		exp  interface{}
+	}{
+		{"string", "ok", "ok"},
+		{"int", 1, 1},
+		{"geometry", pt, string(ptval.([]byte))},
+		{"null geometry", tl.Point{}, nil},
+		{"json", tl.FeedUrls{StaticCurrent: "http://example.com"}, `{"static_current":"http://example.com"}`},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := copyValue(tc.val)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.exp {
+				t.Errorf("got %#v, expected %#v", got, tc.exp)
+			}
+		})
+	}
+}
@@ -1,7 +1,11 @@
 package tldb
 
 import (
+	"context"
+	"fmt"
 	"reflect"
+	"strconv"
+	"strings"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/interline-io/transitland-lib/internal/log"
@@ -20,6 +24,8 @@ type Reader struct {
 	Adapter        Adapter
 	PageSize       int
 	FeedVersionIDs []int
+	Filter         ReaderFilter
+	ctx            context.Context
 }
 
 // NewReader returns an initialized Reader based on the provided url string.
@@ -27,9 +33,27 @@ func NewReader(dburl string) (*Reader, error) {
 	return &Reader{Adapter: newAdapter(dburl), PageSize: 1000}, nil
 }
 
-// ValidateStructure returns if all the necessary tables are present. Not implemented.
+// ValidateStructure returns an error if any schema migrations have not been applied to the database.
 func (reader *Reader) ValidateStructure() []error {
 	errs := []error{}
+	statuses, err := MigrationStatuses(reader.Adapter)
+	if err != nil {
+		errs = append(errs, causes.NewSourceUnreadableError("could not read schema migrations", err))
+		return errs
+	}
+	version := 0
+	pending := []string{}
+	for _, s := range statuses {
+		if s.Applied {
+			version = s.Version
+		} else {
+			pending = append(pending, strconv.Itoa(s.Version))
+		}
+	}
+	if len(pending) > 0 {
+		msg := fmt.Sprintf("database schema version is %d, migrations %s have not been applied; run 'transitland dmfr migrate up'", version, strings.Join(pending, ", "))
+		errs = append(errs, causes.NewSourceUnreadableError(msg, nil))
+	}
 	return errs
 }
 
@@ -43,6 +67,24 @@ func (reader *Reader) Close() error {
 	return reader.Adapter.Close()
 }
 
+// SetContext sets a context; when it is done, no further pages are read and channels are closed.
+func (reader *Reader) SetContext(ctx context.Context) {
+	reader.ctx = ctx
+}
+
+// done returns the context Done channel, or nil if there is no context.
+func (reader *Reader) done() <-chan struct{} {
+	if reader.ctx == nil {
+		return nil
+	}
+	return reader.ctx.Done()
+}
+
+// stopped returns true if the context is done.
+func (reader *Reader) stopped() bool {
+	return reader.ctx != nil && reader.ctx.Err() != nil
+}
+
 // Where returns a select builder with feed_version_id set
 func (reader *Reader) Where() sq.SelectBuilder {
 	q := reader.Adapter.Sqrl().Select("*")
@@ -54,6 +96,57 @@ func (reader *Reader) Where() sq.SelectBuilder {
 	return q
 }
 
+// selectEntities selects entities into dest, a pointer to a slice, and loads their extra fields.
+func (reader *Reader) selectEntities(dest interface{}, qstr string, args ...interface{}) error {
+	if err := reader.Adapter.Select(dest, qstr, args...); err != nil {
+		return err
+	}
+	return loadExtraFields(reader.Adapter, dest)
+}
+
+// loadExtraFields reads the "extra" column for a pointer to a slice of entities.
+// The extra fields are kept in an unexported field, which cannot be set by Select.
+func loadExtraFields(adapter Adapter, dest interface{}) error {
+	ents := reflect.ValueOf(dest).Elem()
+	table := ""
+	byID := map[int]hasExtraFields{}
+	ids := []int{}
+	for i := 0; i < ents.Len(); i++ {
+		ent := ents.Index(i)
+		if ent.Kind() != reflect.Ptr {
+			ent = ent.Addr()
+		}
+		v, ok := ent.Interface().(hasExtraFields)
+		if !ok {
+			return nil
+		}
+		id := int(ent.Elem().FieldByName("ID").Int())
+		byID[id] = v
+		ids = append(ids, id)
+		table = getTableName(ent.Interface())
+	}
+	// Keep the number of query parameters within the SQLite limit
+	for len(ids) > 0 {
+		chunk := ids[:min(len(ids), 500)]
+		ids = ids[len(chunk):]
+		qstr, args, err := adapter.Sqrl().Select("id", "extra").From(table).Where(sq.Eq{"id": chunk}).Where("extra IS NOT NULL").ToSql()
+		if err != nil {
+			return err
+		}
+		rows := []struct {
+			ID    int            `db:"id"`
+			Extra tl.ExtraFields `db:"extra"`
+		}{}
+		if err := adapter.Select(&rows, qstr, args...); err != nil {
+			return err
+		}
+		for _, row := range rows {
+			byID[row.ID].SetExtraFields(row.Extra)
+		}
+	}
+	return nil
+}
+
 // ReadEntities provides a generic interface for reading entities.
 func (reader *Reader) ReadEntities(c interface{}) error {
 	// Seems to work.
@@ -70,52 +163,152 @@ func (reader *Reader) ReadEntities(c interface{}) error {
 	z := x.Elem()
 	z.Set(slice)
 	//
-	qstr, args, err := reader.Where().From(getTableName(ent)).ToSql()
+	qstr, args, err := reader.selectFrom(getTableName(ent)).ToSql()
 	if err != nil {
 		return err
 	}
-	if err := reader.Adapter.Select(x.Interface(), qstr, args...); err != nil {
+	if err := reader.selectEntities(x.Interface(), qstr, args...); err != nil {
 		check(err)
 		return err
 	}
 	go func() {
-		for i := 0; i < z.Len(); i++ {
+		for i := 0; i < z.Len() && !reader.stopped(); i++ {
 			p := z.Index(i)
-			outValue.Send(p.Elem())
+			reflect.Select([]reflect.SelectCase{
+				{Dir: reflect.SelectSend, Chan: outValue, Send: p.Elem()},
+				{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(reader.done())},
+			})
 		}
 		outValue.Close()
 	}()
 	return nil
 }
 
+// Iterate returns an EntityIterator for the table of the provided entity type, reading PageSize rows at a time.
+// Query errors are returned by Err.
+func (reader *Reader) Iterate(ent tl.Entity) tl.EntityIterator {
+	entType := reflect.TypeOf(ent).Elem()
+	tableName := getTableName(ent)
+	return tl.NewEntityIterator(func(send func(tl.Entity) bool) error {
+		offset := 0
+		for !reader.stopped() {
+			// Select a page into a new []*T
+			page := reflect.New(reflect.SliceOf(reflect.PtrTo(entType)))
+			qstr, args, err := reader.selectFrom(tableName).OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
+			if err != nil {
+				return err
+			}
+			if err := reader.selectEntities(page.Interface(), qstr, args...); err != nil {
+				return err
+			}
+			ents := page.Elem()
+			for i := 0; i < ents.Len(); i++ {
+				ent := ents.Index(i).Interface().(tl.Entity)
+				if v, ok := ent.(*tl.Stop); ok {
+					setStopCoordinates(v)
+				}
+				if !send(ent) {
+					return nil
+				}
+			}
+			if ents.Len() < reader.PageSize {
+				break
+			}
+			offset = offset + reader.PageSize
+		}
+		return nil
+	})
+}
+
+// setStopCoordinates sets StopLon and StopLat from the Geometry; only the Geometry is stored in the database.
+func setStopCoordinates(ent *tl.Stop) {
+	if ent.Geometry.Valid {
+		c := ent.Coordinates()
+		ent.StopLon = c[0]
+		ent.StopLat = c[1]
+	}
+}
+
+// IterateStopTimesByTripID returns a StopTimeIterator for the selected trips, or all trips if none are specified.
+// Query errors are returned by Err.
+func (reader *Reader) IterateStopTimesByTripID(tripIDs ...string) tl.StopTimeIterator {
+	return tl.NewStopTimeGroupIterator(func(send func([]tl.StopTime) bool) error {
+		if len(tripIDs) == 0 {
+			var err error
+			if tripIDs, err = reader.tripIDs(); err != nil {
+				return err
+			}
+		}
+		for _, tripID := range tripIDs {
+			if reader.stopped() {
+				break
+			}
+			ents := []tl.StopTime{}
+			qstr, args, err := reader.Where().From("gtfs_stop_times").Where("trip_id = ?", tripID).OrderBy("stop_sequence").ToSql()
+			if err != nil {
+				return err
+			}
+			if err := reader.selectEntities(&ents, qstr, args...); err != nil {
+				return err
+			}
+			if len(ents) > 0 && !send(ents) {
+				break
+			}
+		}
+		return nil
+	})
+}
+
+// tripIDs returns the IDs of all trips in the selected feed versions that match the Filter.
+func (reader *Reader) tripIDs() ([]string, error) {
+	q := reader.Adapter.Sqrl().Select("id").Distinct().From("gtfs_trips")
+	if len(reader.FeedVersionIDs) == 1 {
+		q = q.Where("feed_version_id = ?", reader.FeedVersionIDs[0])
+	} else if len(reader.FeedVersionIDs) > 1 {
+		q = q.Where(sq.Eq{"feed_version_id": reader.FeedVersionIDs})
+	}
+	if cond := reader.filterCondition("gtfs_trips"); cond != nil {
+		q = q.Where(cond)
+	}
+	rows, err := q.Query()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	tripIDs := []string{}
+	for rows.Next() {
+		tripID := ""
+		if err := rows.Scan(&tripID); err != nil {
+			return nil, err
+		}
+		tripIDs = append(tripIDs, tripID)
+	}
+	return tripIDs, rows.Err()
+}
+
 // StopTimesByTripID sends StopTimes grouped by TripID.
 // Each group is sorted by stop_sequence.
 func (reader *Reader) StopTimesByTripID(tripIDs ...string) chan []tl.StopTime {
 	if len(tripIDs) == 0 {
-		q := reader.Adapter.Sqrl().Select("id").Distinct().From("gtfs_trips")
-		if len(reader.FeedVersionIDs) == 1 {
-			q = q.Where("feed_version_id = ?", reader.FeedVersionIDs[0])
-		} else if len(reader.FeedVersionIDs) > 1 {
-			q = q.Where(sq.Eq{"feed_version_id": reader.FeedVersionIDs})
-		}
-		rows, err := q.Query()
+		var err error
+		tripIDs, err = reader.tripIDs()
 		check(err)
-		defer rows.Close()
-		for rows.Next() {
-			tripID := ""
-			rows.Scan(&tripID)
-			tripIDs = append(tripIDs, tripID)
-		}
 	}
 	out := make(chan []tl.StopTime, bufferSize)
 	go func() {
 		for _, tripID := range tripIDs {
+			if reader.stopped() {
+				break
+			}
 			ents := []tl.StopTime{}
 			qstr, args, err := reader.Where().From("gtfs_stop_times").Where("trip_id = ?", tripID).OrderBy("stop_sequence").ToSql()
 			check(err)
-			check(reader.Adapter.Select(&ents, qstr, args...))
+			check(reader.selectEntities(&ents, qstr, args...))
 			if len(ents) > 0 {
-				out <- ents
+				select {
+				case out <- ents:
+				case <-reader.done():
+				}
 			}
 		}
 		close(out)
@@ -130,13 +323,17 @@ func (reader *Reader) Stops() chan tl.Stop {
 		offset := 0
 		for {
 			ents := []tl.Stop{}
-			qstr, args, err := reader.Where().From("gtfs_stops").OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
+			qstr, args, err := reader.selectFrom("gtfs_stops").OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
 			check(err)
-			check(reader.Adapter.Select(&ents, qstr, args...))
+			check(reader.selectEntities(&ents, qstr, args...))
 			for _, ent := range ents {
-				out <- ent
+				setStopCoordinates(&ent)
+				select {
+				case out <- ent:
+				case <-reader.done():
+				}
 			}
-			if len(ents) < reader.PageSize {
+			if len(ents) < reader.PageSize || reader.stopped() {
 				break
 			}
 			offset = offset + reader.PageSize
@@ -153,13 +350,16 @@ func (reader *Reader) StopTimes() chan tl.StopTime {
 		offset := 0
 		for {
 			ents := []tl.StopTime{}
-			qstr, args, err := reader.Where().From("gtfs_stop_times").OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
+			qstr, args, err := reader.selectFrom("gtfs_stop_times").OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
 			check(err)
-			check(reader.Adapter.Select(&ents, qstr, args...))
+			check(reader.selectEntities(&ents, qstr, args...))
 			for _, ent := range ents {
-				out <- ent
+				select {
+				case out <- ent:
+				case <-reader.done():
+				}
 			}
-			if len(ents) < reader.PageSize {
+			if len(ents) < reader.PageSize || reader.stopped() {
 				break
 			}
 			offset = offset + reader.PageSize
@@ -176,13 +376,16 @@ func (reader *Reader) Agencies() chan tl.Agency {
 		offset := 0
 		for {
 			ents := []tl.Agency{}
-			qstr, args, err := reader.Where().From("gtfs_agencies").OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
+			qstr, args, err := reader.selectFrom("gtfs_agencies").OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
 			check(err)
-			check(reader.Adapter.Select(&ents, qstr, args...))
+			check(reader.selectEntities(&ents, qstr, args...))
 			for _, ent := range ents {
-				out <- ent
+				select {
+				case out <- ent:
+				case <-reader.done():
+				}
 			}
-			if len(ents) < reader.PageSize {
+			if len(ents) < reader.PageSize || reader.stopped() {
 				break
 			}
 			offset = offset + reader.PageSize
@@ -199,13 +402,16 @@ func (reader *Reader) Calendars() chan tl.Calendar {
 		offset := 0
 		for {
 			ents := []tl.Calendar{}
-			qstr, args, err := reader.Where().From("gtfs_calendars").OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
+			qstr, args, err := reader.selectFrom("gtfs_calendars").OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
 			check(err)
-			check(reader.Adapter.Select(&ents, qstr, args...))
+			check(reader.selectEntities(&ents, qstr, args...))
 			for _, ent := range ents {
-				out <- ent
+				select {
+				case out <- ent:
+				case <-reader.done():
+				}
 			}
-			if len(ents) < reader.PageSize {
+			if len(ents) < reader.PageSize || reader.stopped() {
 				break
 			}
 			offset = offset + reader.PageSize
@@ -222,13 +428,16 @@ func (reader *Reader) CalendarDates() chan tl.CalendarDate {
 		offset := 0
 		for {
 			ents := []tl.CalendarDate{}
-			qstr, args, err := reader.Where().From("gtfs_calendar_dates").OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
+			qstr, args, err := reader.selectFrom("gtfs_calendar_dates").OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
 			check(err)
-			check(reader.Adapter.Select(&ents, qstr, args...))
+			check(reader.selectEntities(&ents, qstr, args...))
 			for _, ent := range ents {
-				out <- ent
+				select {
+				case out <- ent:
+				case <-reader.done():
+				}
 			}
-			if len(ents) < reader.PageSize {
+			if len(ents) < reader.PageSize || reader.stopped() {
 				break
 			}
 			offset = offset + reader.PageSize
@@ -245,13 +454,16 @@ func (reader *Reader) FareAttributes() chan tl.FareAttribute {
 		offset := 0
 		for {
 			ents := []tl.FareAttribute{}
-			qstr, args, err := reader.Where().From("gtfs_fare_attributes").OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
+			qstr, args, err := reader.selectFrom("gtfs_fare_attributes").OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
 			check(err)
-			check(reader.Adapter.Select(&ents, qstr, args...))
+			check(reader.selectEntities(&ents, qstr, args...))
 			for _, ent := range ents {
-				out <- ent
+				select {
+				case out <- ent:
+				case <-reader.done():
+				}
 			}
-			if len(ents) < reader.PageSize {
+			if len(ents) < reader.PageSize || reader.stopped() {
 				break
 			}
 			offset = offset + reader.PageSize
@@ -268,13 +480,16 @@ func (reader *Reader) FareRules() chan tl.FareRule {
 		offset := 0
 		for {
 			ents := []tl.FareRule{}
-			qstr, args, err := reader.Where().From("gtfs_fare_rules").OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
+			qstr, args, err := reader.selectFrom("gtfs_fare_rules").OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
 			check(err)
-			check(reader.Adapter.Select(&ents, qstr, args...))
+			check(reader.selectEntities(&ents, qstr, args...))
 			for _, ent := range ents {
-				out <- ent
+				select {
+				case out <- ent:
+				case <-reader.done():
+				}
 			}
-			if len(ents) < reader.PageSize {
+			if len(ents) < reader.PageSize || reader.stopped() {
 				break
 			}
 			offset = offset + reader.PageSize
@@ -291,13 +506,16 @@ func (reader *Reader) FeedInfos() chan tl.FeedInfo {
 		offset := 0
 		for {
 			ents := []tl.FeedInfo{}
-			qstr, args, err := reader.Where().From("gtfs_feed_infos").OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
+			qstr, args, err := reader.selectFrom("gtfs_feed_infos").OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
 			check(err)
-			check(reader.Adapter.Select(&ents, qstr, args...))
+			check(reader.selectEntities(&ents, qstr, args...))
 			for _, ent := range ents {
-				out <- ent
+				select {
+				case out <- ent:
+				case <-reader.done():
+				}
 			}
-			if len(ents) < reader.PageSize {
+			if len(ents) < reader.PageSize || reader.stopped() {
 				break
 			}
 			offset = offset + reader.PageSize
@@ -314,13 +532,16 @@ func (reader *Reader) Frequencies() chan tl.Frequency {
 		offset := 0
 		for {
 			ents := []tl.Frequency{}
-			qstr, args, err := reader.Where().From("gtfs_frequencies").OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
+			qstr, args, err := reader.selectFrom("gtfs_frequencies").OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
 			check(err)
-			check(reader.Adapter.Select(&ents, qstr, args...))
+			check(reader.selectEntities(&ents, qstr, args...))
 			for _, ent := range ents {
-				out <- ent
+				select {
+				case out <- ent:
+				case <-reader.done():
+				}
 			}
-			if len(ents) < reader.PageSize {
+			if len(ents) < reader.PageSize || reader.stopped() {
 				break
 			}
 			offset = offset + reader.PageSize
@@ -337,13 +558,16 @@ func (reader *Reader) Routes() chan tl.Route {
 		offset := 0
 		for {
 			ents := []tl.Route{}
-			qstr, args, err := reader.Where().From("gtfs_routes").OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
+			qstr, args, err := reader.selectFrom("gtfs_routes").OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
 			check(err)
-			check(reader.Adapter.Select(&ents, qstr, args...))
+			check(reader.selectEntities(&ents, qstr, args...))
 			for _, ent := range ents {
-				out <- ent
+				select {
+				case out <- ent:
+				case <-reader.done():
+				}
 			}
-			if len(ents) < reader.PageSize {
+			if len(ents) < reader.PageSize || reader.stopped() {
 				break
 			}
 			offset = offset + reader.PageSize
@@ -360,13 +584,16 @@ func (reader *Reader) Shapes() chan tl.Shape {
 		offset := 0
 		for {
 			ents := []tl.Shape{}
-			qstr, args, err := reader.Where().From("gtfs_shapes").OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
+			qstr, args, err := reader.selectFrom("gtfs_shapes").OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
 			check(err)
-			check(reader.Adapter.Select(&ents, qstr, args...))
+			check(reader.selectEntities(&ents, qstr, args...))
 			for _, ent := range ents {
-				out <- ent
+				select {
+				case out <- ent:
+				case <-reader.done():
+				}
 			}
-			if len(ents) < reader.PageSize {
+			if len(ents) < reader.PageSize || reader.stopped() {
 				break
 			}
 			offset = offset + reader.PageSize
@@ -383,13 +610,16 @@ func (reader *Reader) Transfers() chan tl.Transfer {
 		offset := 0
 		for {
 			ents := []tl.Transfer{}
-			qstr, args, err := reader.Where().From("gtfs_transfers").OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
+			qstr, args, err := reader.selectFrom("gtfs_transfers").OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
 			check(err)
-			check(reader.Adapter.Select(&ents, qstr, args...))
+			check(reader.selectEntities(&ents, qstr, args...))
 			for _, ent := range ents {
-				out <- ent
+				select {
+				case out <- ent:
+				case <-reader.done():
+				}
 			}
-			if len(ents) < reader.PageSize {
+			if len(ents) < reader.PageSize || reader.stopped() {
 				break
 			}
 			offset = offset + reader.PageSize
@@ -406,13 +636,16 @@ func (reader *Reader) Pathways() chan tl.Pathway {
 		offset := 0
 		for {
 			ents := []tl.Pathway{}
-			qstr, args, err := reader.Where().From("gtfs_pathways").OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
+			qstr, args, err := reader.selectFrom("gtfs_pathways").OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
 			check(err)
-			check(reader.Adapter.Select(&ents, qstr, args...))
+			check(reader.selectEntities(&ents, qstr, args...))
 			for _, ent := range ents {
-				out <- ent
+				select {
+				case out <- ent:
+				case <-reader.done():
+				}
 			}
-			if len(ents) < reader.PageSize {
+			if len(ents) < reader.PageSize || reader.stopped() {
 				break
 			}
 			offset = offset + reader.PageSize
@@ -429,13 +662,16 @@ func (reader *Reader) Levels() chan tl.Level {
 		offset := 0
 		for {
 			ents := []tl.Level{}
-			qstr, args, err := reader.Where().From("gtfs_levels").OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
+			qstr, args, err := reader.selectFrom("gtfs_levels").OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
 			check(err)
-			check(reader.Adapter.Select(&ents, qstr, args...))
+			check(reader.selectEntities(&ents, qstr, args...))
 			for _, ent := range ents {
-				out <- ent
+				select {
+				case out <- ent:
+				case <-reader.done():
+				}
 			}
-			if len(ents) < reader.PageSize {
+			if len(ents) < reader.PageSize || reader.stopped() {
 				break
 			}
 			offset = offset + reader.PageSize
@@ -452,13 +688,16 @@ func (reader *Reader) Trips() chan tl.Trip {
 		offset := 0
 		for {
 			ents := []tl.Trip{}
-			qstr, args, err := reader.Where().From("gtfs_trips").OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
+			qstr, args, err := reader.selectFrom("gtfs_trips").OrderBy("id").Offset(uint64(offset)).Limit(uint64(reader.PageSize)).ToSql()
 			check(err)
-			check(reader.Adapter.Select(&ents, qstr, args...))
+			check(reader.selectEntities(&ents, qstr, args...))
 			for _, ent := range ents {
-				out <- ent
+				select {
+				case out <- ent:
+				case <-reader.done():
+				}
 			}
-			if len(ents) < reader.PageSize {
+			if len(ents) < reader.PageSize || reader.stopped() {
 				break
 			}
 			offset = offset + reader.PageSize
@@ -0,0 +1,155 @@
+package tldb
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// ReaderFilter restricts the entities read from the selected feed versions.
+// Trips are selected if they match all of the filters; the entities they reference, and the entities that reference them, are also read.
+// This is similar to an extract, but executed as SQL subqueries.
+type ReaderFilter struct {
+	AgencyIDs   []string  // trips on routes operated by these agencies, by GTFS agency_id
+	RouteIDs    []string  // trips on these routes, by GTFS route_id
+	BoundingBox []float64 // trips that visit a stop inside min lon, min lat, max lon, max lat; stops inside are also read
+	StartDate   time.Time // trips with service that may be active on or after this date
+	EndDate     time.Time // trips with service that may be active on or before this date
+}
+
+// IsZero returns true if the filter does not restrict any entities.
+func (f ReaderFilter) IsZero() bool {
+	return len(f.AgencyIDs) == 0 && len(f.RouteIDs) == 0 && len(f.BoundingBox) == 0 && f.StartDate.IsZero() && f.EndDate.IsZero()
+}
+
+// inSubquery is a "column IN (subquery)" condition.
+// Subqueries use ? placeholders, which are replaced by the outer query.
+type inSubquery struct {
+	column string
+	query  sq.SelectBuilder
+}
+
+func (s inSubquery) ToSql() (string, []interface{}, error) {
+	qstr, args, err := s.query.ToSql()
+	return s.column + " IN (" + qstr + ")", args, err
+}
+
+// subquery selects a column from a table in the selected feed versions.
+func (reader *Reader) subquery(column string, table string) sq.SelectBuilder {
+	q := sq.Select(column).From(table)
+	if len(reader.FeedVersionIDs) > 0 {
+		q = q.Where(sq.Eq{"feed_version_id": reader.FeedVersionIDs})
+	}
+	return q
+}
+
+// selectFrom returns a select builder for the table with feed_version_id and the Filter set.
+func (reader *Reader) selectFrom(table string) sq.SelectBuilder {
+	q := reader.Where().From(table)
+	if cond := reader.filterCondition(table); cond != nil {
+		q = q.Where(cond)
+	}
+	return q
+}
+
+// filterCondition returns the Filter condition for a table, or nil.
+func (reader *Reader) filterCondition(table string) sq.Sqlizer {
+	if reader.Filter.IsZero() {
+		return nil
+	}
+	trips := reader.tripCondition()
+	tripColumn := func(col string) sq.SelectBuilder {
+		return reader.subquery(col, "gtfs_trips").Where(trips)
+	}
+	switch table {
+	case "gtfs_trips":
+		return trips
+	case "gtfs_stop_times", "gtfs_frequencies":
+		return inSubquery{"trip_id", tripColumn("id")}
+	case "gtfs_routes":
+		return inSubquery{"id", tripColumn("route_id")}
+	case "gtfs_agencies":
+		return inSubquery{"id", reader.subquery("agency_id", "gtfs_routes").Where(inSubquery{"id", tripColumn("route_id")})}
+	case "gtfs_calendars":
+		return inSubquery{"id", tripColumn("service_id")}
+	case "gtfs_calendar_dates":
+		return inSubquery{"service_id", tripColumn("service_id")}
+	case "gtfs_shapes":
+		return inSubquery{"id", tripColumn("shape_id")}
+	case "gtfs_stops":
+		return reader.stopCondition()
+	case "gtfs_levels":
+		return inSubquery{"id", reader.subquery("level_id", "gtfs_stops").Where(reader.stopCondition())}
+	case "gtfs_transfers", "gtfs_pathways":
+		stops := reader.subquery("id", "gtfs_stops").Where(reader.stopCondition())
+		return sq.And{inSubquery{"from_stop_id", stops}, inSubquery{"to_stop_id", stops}}
+	case "gtfs_fare_rules":
+		return sq.Or{sq.Eq{"route_id": nil}, inSubquery{"route_id", tripColumn("route_id")}}
+	case "gtfs_fare_attributes":
+		// Fares with selected rules, and fares without any rules
+		rules := reader.subquery("fare_id", "gtfs_fare_rules")
+		return sq.Or{
+			inSubquery{"id", rules.Where(sq.Or{sq.Eq{"route_id": nil}, inSubquery{"route_id", tripColumn("route_id")}})},
+			sq.Expr("NOT EXISTS (SELECT 1 FROM gtfs_fare_rules WHERE gtfs_fare_rules.fare_id = gtfs_fare_attributes.id)"),
+		}
+	}
+	return nil
+}
+
+// tripCondition returns the condition for trips that match all filters.
+func (reader *Reader) tripCondition() sq.And {
+	f := reader.Filter
+	cond := sq.And{}
+	if len(f.RouteIDs) > 0 {
+		cond = append(cond, inSubquery{"route_id", reader.subquery("id", "gtfs_routes").Where(sq.Eq{"route_id": f.RouteIDs})})
+	}
+	if len(f.AgencyIDs) > 0 {
+		agencies := reader.subquery("id", "gtfs_agencies").Where(sq.Eq{"agency_id": f.AgencyIDs})
+		cond = append(cond, inSubquery{"route_id", reader.subquery("id", "gtfs_routes").Where(inSubquery{"agency_id", agencies})})
+	}
+	if !f.StartDate.IsZero() || !f.EndDate.IsZero() {
+		// Calendars that overlap the dates, or have added dates in the range
+		overlap := sq.And{}
+		added := sq.And{sq.Eq{"exception_type": 1}}
+		if !f.StartDate.IsZero() {
+			overlap = append(overlap, sq.GtOrEq{"end_date": f.StartDate})
+			added = append(added, sq.GtOrEq{"date": f.StartDate})
+		}
+		if !f.EndDate.IsZero() {
+			overlap = append(overlap, sq.LtOrEq{"start_date": f.EndDate})
+			added = append(added, sq.LtOrEq{"date": f.EndDate})
+		}
+		services := reader.subquery("id", "gtfs_calendars").Where(sq.Or{
+			overlap,
+			inSubquery{"id", reader.subquery("service_id", "gtfs_calendar_dates").Where(added)},
+		})
+		cond = append(cond, inSubquery{"service_id", services})
+	}
+	if len(f.BoundingBox) > 0 {
+		stops := reader.subquery("id", "gtfs_stops").Where(reader.bboxCondition())
+		cond = append(cond, inSubquery{"id", reader.subquery("trip_id", "gtfs_stop_times").Where(inSubquery{"stop_id", stops})})
+	}
+	return cond
+}
+
+// stopCondition returns the condition for stops visited by the selected trips, stops inside the bounding box, and their parent stations.
+func (reader *Reader) stopCondition() sq.Sqlizer {
+	stops := sq.Or{inSubquery{"id", reader.subquery("stop_id", "gtfs_stop_times").Where(inSubquery{"trip_id", reader.subquery("id", "gtfs_trips").Where(reader.tripCondition())})}}
+	if len(reader.Filter.BoundingBox) > 0 {
+		stops = append(stops, reader.bboxCondition())
+	}
+	return sq.Or{stops, inSubquery{"id", reader.subquery("parent_station", "gtfs_stops").Where(stops)}}
+}
+
+// bboxCondition returns the condition for stops inside the bounding box.
+func (reader *Reader) bboxCondition() sq.Sqlizer {
+	b := reader.Filter.BoundingBox
+	if len(b) != 4 {
+		return sq.Expr("1 = 0")
+	}
+	if driver, _ := migrationDriver(reader.Adapter); driver == "postgres" {
+		return sq.Expr("ST_Intersects(geometry, ST_MakeEnvelope(?, ?, ?, ?, 4326)::geography)", b[0], b[1], b[2], b[3])
+	}
+	// SQLite; see st_x and st_y in sqlite.go
+	return sq.Expr("(st_x(geometry) BETWEEN ? AND ? AND st_y(geometry) BETWEEN ? AND ?)", b[0], b[2], b[1], b[3])
+}
@@ -1,3 +1,269 @@
 package tldb
 
-// Reader tests are handled as part of the round-trip Writer tests.
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/interline-io/transitland-lib/copier"
+	"github.com/interline-io/transitland-lib/internal/testutil"
+	"github.com/interline-io/transitland-lib/tl"
+	"github.com/interline-io/transitland-lib/tlcsv"
+)
+
+func TestReader_Iterate(t *testing.T) {
+	for k, v := range testAdapters {
+		t.Run(k, func(t *testing.T) {
+			adapter := v()
+			if err := adapter.Open(); err != nil {
+				t.Fatal(err)
+			}
+			if err := adapter.Create(); err != nil {
+				t.Fatal(err)
+			}
+			m, err := createMinEntities(adapter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Use a small page size to read multiple pages
+			reader := &Reader{Adapter: adapter, PageSize: 1, FeedVersionIDs: []int{m.FeedVersionID}}
+			it := reader.Iterate(&tl.Stop{})
+			defer it.Close()
+			stopids := []string{}
+			for it.Next() {
+				stopids = append(stopids, it.Value().(*tl.Stop).StopID)
+			}
+			if err := it.Err(); err != nil {
+				t.Error(err)
+			}
+			if len(stopids) != 2 || stopids[0] != "bar" || stopids[1] != "foo" {
+				t.Errorf("got stops %v, expected [bar foo]", stopids)
+			}
+			// Query errors are returned; drop the table in a transaction that is rolled back
+			errRollback := errors.New("rollback")
+			txerr := adapter.Tx(func(atx Adapter) error {
+				if _, err := atx.DBX().Exec("DROP TABLE gtfs_stops"); err != nil {
+					return err
+				}
+				txreader := &Reader{Adapter: atx, PageSize: 1, FeedVersionIDs: []int{m.FeedVersionID}}
+				it := txreader.Iterate(&tl.Stop{})
+				defer it.Close()
+				for it.Next() {
+				}
+				if it.Err() == nil {
+					t.Error("expected an error for a missing table")
+				}
+				return errRollback
+			})
+			if txerr != errRollback {
+				t.Error(txerr)
+			}
+		})
+	}
+}
+
+func TestReader_StopCoordinates(t *testing.T) {
+	for k, v := range testAdapters {
+		t.Run(k, func(t *testing.T) {
+			adapter := v()
+			if err := adapter.Open(); err != nil {
+				t.Fatal(err)
+			}
+			if err := adapter.Create(); err != nil {
+				t.Fatal(err)
+			}
+			m, err := createMinEntities(adapter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Only the Geometry is stored; StopLon and StopLat are set when reading
+			expect := map[string][2]float64{"bar": {-123.0, 42.0}, "foo": {-122.0, 43.0}}
+			check := func(stop tl.Stop) {
+				if c := [2]float64{stop.StopLon, stop.StopLat}; c != expect[stop.StopID] {
+					t.Errorf("stop '%s': got %v, expected %v", stop.StopID, c, expect[stop.StopID])
+				}
+			}
+			reader := &Reader{Adapter: adapter, PageSize: 1, FeedVersionIDs: []int{m.FeedVersionID}}
+			for stop := range reader.Stops() {
+				check(stop)
+			}
+			it := reader.Iterate(&tl.Stop{})
+			defer it.Close()
+			for it.Next() {
+				check(*it.Value().(*tl.Stop))
+			}
+			if err := it.Err(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestMergedReader(t *testing.T) {
+	for k, v := range testAdapters {
+		t.Run(k, func(t *testing.T) {
+			adapter := v()
+			if err := adapter.Open(); err != nil {
+				t.Fatal(err)
+			}
+			if err := adapter.Create(); err != nil {
+				t.Fatal(err)
+			}
+			m1, err := createMinEntities(adapter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			m2, err := createMinEntities(adapter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// The same agency in both feed versions
+			for _, id := range []int{m1.AgencyID, m2.AgencyID} {
+				if _, err := adapter.Sqrl().Update("gtfs_agencies").Set("agency_name", "Test Agency").Set("agency_url", "http://example.com").Where("id = ?", id).Exec(); err != nil {
+					t.Fatal(err)
+				}
+			}
+			namespaces := map[int]string{}
+			for _, fvid := range []int{m1.FeedVersionID, m2.FeedVersionID} {
+				feedid := ""
+				if err := adapter.Get(&feedid, "SELECT current_feeds.onestop_id FROM current_feeds JOIN feed_versions ON feed_versions.feed_id = current_feeds.id WHERE feed_versions.id = ?", fvid); err != nil {
+					t.Fatal(err)
+				}
+				namespaces[fvid] = feedid
+			}
+			reader := NewMergedReader(adapter, []int{m1.FeedVersionID, m2.FeedVersionID})
+			for _, err := range reader.ValidateStructure() {
+				t.Error(err)
+			}
+			agencies := []tl.Agency{}
+			for ent := range reader.Agencies() {
+				agencies = append(agencies, ent)
+			}
+			if len(agencies) != 1 {
+				t.Fatalf("got %d agencies, expected 1", len(agencies))
+			}
+			if exp := namespaces[m1.FeedVersionID] + ":ok"; agencies[0].AgencyID != exp {
+				t.Errorf("got agency_id '%s', expected '%s'", agencies[0].AgencyID, exp)
+			}
+			stopids := map[string]bool{}
+			for ent := range reader.Stops() {
+				stopids[ent.StopID] = true
+			}
+			for _, ns := range namespaces {
+				for _, stopid := range []string{"foo", "bar"} {
+					if !stopids[ns+":"+stopid] {
+						t.Errorf("did not find stop '%s:%s'", ns, stopid)
+					}
+				}
+			}
+			it := reader.Iterate(&tl.Route{})
+			defer it.Close()
+			count := 0
+			for it.Next() {
+				count++
+				if ent := it.Value().(*tl.Route); ent.AgencyID != strconv.Itoa(m1.AgencyID) {
+					t.Errorf("got route agency %s, expected %d", ent.AgencyID, m1.AgencyID)
+				}
+			}
+			if err := it.Err(); err != nil {
+				t.Error(err)
+			}
+			if count != 2 {
+				t.Errorf("got %d routes, expected 2", count)
+			}
+			// Feed versions from the same feed can not be merged
+			fv := tl.FeedVersion{}
+			fv.ID = m1.FeedVersionID
+			if err := adapter.Find(&fv); err != nil {
+				t.Fatal(err)
+			}
+			fv.ID = 0
+			fv.SHA1 = fv.SHA1 + "-2"
+			fvid, err := adapter.Insert(&fv)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if errs := NewMergedReader(adapter, []int{m1.FeedVersionID, fvid}).ValidateStructure(); len(errs) == 0 {
+				t.Error("expected error for feed versions from the same feed")
+			}
+		})
+	}
+}
+
+func TestReader_Filter(t *testing.T) {
+	date := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+	tcs := []struct {
+		name   string
+		filter ReaderFilter
+		counts map[string]int
+	}{
+		{"none", ReaderFilter{}, map[string]int{"gtfs_agencies": 1, "gtfs_routes": 5, "gtfs_trips": 11, "gtfs_stop_times": 28, "gtfs_stops": 9, "gtfs_fare_attributes": 2, "gtfs_fare_rules": 4}},
+		{"route", ReaderFilter{RouteIDs: []string{"AB"}}, map[string]int{"gtfs_agencies": 1, "gtfs_routes": 1, "gtfs_trips": 2, "gtfs_stop_times": 4, "gtfs_stops": 2, "gtfs_calendars": 1, "gtfs_fare_attributes": 1, "gtfs_fare_rules": 1}},
+		{"agency", ReaderFilter{AgencyIDs: []string{"DTA"}}, map[string]int{"gtfs_agencies": 1, "gtfs_routes": 5, "gtfs_trips": 11, "gtfs_stops": 9}},
+		{"unknown agency", ReaderFilter{AgencyIDs: []string{"unknown"}}, map[string]int{"gtfs_agencies": 0, "gtfs_routes": 0, "gtfs_trips": 0, "gtfs_stop_times": 0, "gtfs_stops": 0, "gtfs_calendars": 0}},
+		{"bbox", ReaderFilter{BoundingBox: []float64{-116.82, 36.88, -116.81, 36.89}}, map[string]int{"gtfs_routes": 2, "gtfs_trips": 4, "gtfs_stop_times": 8, "gtfs_stops": 3}},
+		{"dates", ReaderFilter{StartDate: date("2008-01-01"), EndDate: date("2008-01-31")}, map[string]int{"gtfs_trips": 11, "gtfs_calendars": 2}},
+		{"dates after service", ReaderFilter{StartDate: date("2020-01-01")}, map[string]int{"gtfs_trips": 0, "gtfs_calendars": 0, "gtfs_stops": 0}},
+		{"route and bbox", ReaderFilter{RouteIDs: []string{"STBA"}, BoundingBox: []float64{-116.82, 36.88, -116.81, 36.89}}, map[string]int{"gtfs_trips": 0, "gtfs_stops": 1}},
+	}
+	tables := map[string]tl.Entity{
+		"gtfs_agencies":        &tl.Agency{},
+		"gtfs_routes":          &tl.Route{},
+		"gtfs_trips":           &tl.Trip{},
+		"gtfs_stop_times":      &tl.StopTime{},
+		"gtfs_stops":           &tl.Stop{},
+		"gtfs_calendars":       &tl.Calendar{},
+		"gtfs_fare_attributes": &tl.FareAttribute{},
+		"gtfs_fare_rules":      &tl.FareRule{},
+	}
+	for k, v := range testAdapters {
+		t.Run(k, func(t *testing.T) {
+			adapter := v()
+			if err := adapter.Open(); err != nil {
+				t.Fatal(err)
+			}
+			if err := adapter.Create(); err != nil {
+				t.Fatal(err)
+			}
+			fvid, err := createTestFeedVersion(adapter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			reader, err := tlcsv.NewReader(testutil.ExampleDir.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := reader.Open(); err != nil {
+				t.Fatal(err)
+			}
+			defer reader.Close()
+			cp := copier.NewCopier(reader, &Writer{Adapter: adapter, FeedVersionID: fvid})
+			if result := cp.Copy(); result.WriteError != nil {
+				t.Fatal(result.WriteError)
+			}
+			for _, tc := range tcs {
+				t.Run(tc.name, func(t *testing.T) {
+					dbreader := &Reader{Adapter: adapter, PageSize: 1000, FeedVersionIDs: []int{fvid}, Filter: tc.filter}
+					for table, exp := range tc.counts {
+						it := dbreader.Iterate(tables[table])
+						count := 0
+						for it.Next() {
+							count++
+						}
+						if err := it.Err(); err != nil {
+							t.Error(err)
+						}
+						it.Close()
+						if count != exp {
+							t.Errorf("%s: got %d, expected %d", table, count, exp)
+						}
+					}
+				})
+			}
+		})
+	}
+}
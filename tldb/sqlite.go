@@ -4,6 +4,7 @@ package tldb
 
 import (
 	"database/sql"
+	"fmt"
 	"strings"
 
 	sq "github.com/Masterminds/squirrel"
@@ -38,12 +39,37 @@ func init() {
 				if err := conn.RegisterFunc("activate_feed_version", dummy, true); err != nil {
 					return err
 				}
+				// Point coordinates, used by Reader bounding box filters
+				if err := conn.RegisterFunc("st_x", pointX, true); err != nil {
+					return err
+				}
+				if err := conn.RegisterFunc("st_y", pointY, true); err != nil {
+					return err
+				}
 				return nil
 			},
 		})
 
 }
 
+// pointX returns the longitude of a Point geometry, or 0 if it is not a valid Point.
+func pointX(data []byte) float64 {
+	p := tl.Point{}
+	if err := p.Scan(data); err != nil || !p.Valid {
+		return 0
+	}
+	return p.X()
+}
+
+// pointY returns the latitude of a Point geometry, or 0 if it is not a valid Point.
+func pointY(data []byte) float64 {
+	p := tl.Point{}
+	if err := p.Scan(data); err != nil || !p.Valid {
+		return 0
+	}
+	return p.Y()
+}
+
 // SQLiteAdapter provides support for SQLite.
 type SQLiteAdapter struct {
 	DBURL string
@@ -87,8 +113,10 @@ func (adapter *SQLiteAdapter) Create() error {
 	if err != nil {
 		return err
 	}
-	_, err = adb.Exec(schema)
-	return err
+	if _, err := adb.Exec(schema); err != nil {
+		return err
+	}
+	return markMigrationsApplied(adapter)
 }
 
 // DBX returns the underlying Sqlx DB or Tx.
@@ -161,6 +189,58 @@ func (adapter *SQLiteAdapter) Insert(ent interface{}) (int, error) {
 	return int(eid), nil
 }
 
+// MultiUpsert inserts multiple entities, or updates the existing entities with the same values in the unique key columns.
+func (adapter *SQLiteAdapter) MultiUpsert(ents []interface{}, keys []string) ([]int, error) {
+	retids := []int{}
+	if len(ents) == 0 {
+		return retids, nil
+	}
+	table := getTableName(ents[0])
+	cols, vals, err := getInsert(ents[0])
+	if err != nil {
+		return retids, err
+	}
+	q, _, err := sq.Insert(table).Columns(cols...).Values(vals...).Suffix(getUpsertSuffix(cols, keys)).ToSql()
+	if err != nil {
+		return retids, err
+	}
+	// The ID of an updated row is not available from LastInsertId
+	keyIndexes := []int{}
+	where := []string{}
+	for _, key := range keys {
+		for i, col := range cols {
+			if col == key {
+				keyIndexes = append(keyIndexes, i)
+			}
+		}
+		where = append(where, fmt.Sprintf(`"%s" = ?`, key))
+	}
+	if len(keyIndexes) != len(keys) {
+		return retids, fmt.Errorf("unknown key columns for %s: %v", table, keys)
+	}
+	qid := fmt.Sprintf("SELECT id FROM %s WHERE %s", table, strings.Join(where, " AND "))
+	db := adapter.DBX()
+	for _, d := range ents {
+		_, vals, err := getInsert(d)
+		if err != nil {
+			return retids, err
+		}
+		if _, err := db.Exec(q, vals...); err != nil {
+			return retids, err
+		}
+		keyVals := []interface{}{}
+		for _, i := range keyIndexes {
+			keyVals = append(keyVals, vals[i])
+		}
+		eid := 0
+		if err := sqlx.Get(db, &eid, qid, keyVals...); err != nil {
+			return retids, err
+		}
+		retids = append(retids, eid)
+	}
+	return retids, nil
+}
+
 // MultiInsert inserts multiple entities.
 func (adapter *SQLiteAdapter) MultiInsert(ents []interface{}) ([]int, error) {
 	retids := []int{}
@@ -3,12 +3,29 @@
 package tldb
 
 import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
+// testDiskDB is the path of the on-disk test database, created in a tempdir by TestMain.
+var testDiskDB string
+
 func init() {
 	testAdapters["SQLiteAdapter-Memory"] = func() Adapter { return &SQLiteAdapter{DBURL: "sqlite3://:memory:"} }
-	testAdapters["SQLiteAdapter-Disk"] = func() Adapter { return &SQLiteAdapter{DBURL: "sqlite3://test.db"} }
+	testAdapters["SQLiteAdapter-Disk"] = func() Adapter { return &SQLiteAdapter{DBURL: "sqlite3://" + testDiskDB} }
+}
+
+func TestMain(m *testing.M) {
+	tmpdir, err := ioutil.TempDir("", "tldb")
+	if err != nil {
+		panic(err)
+	}
+	testDiskDB = filepath.Join(tmpdir, "test.db")
+	code := m.Run()
+	os.RemoveAll(tmpdir)
+	os.Exit(code)
 }
 
 func TestSQLiteAdapter(t *testing.T) {
@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/interline-io/transitland-lib/tl"
 	"github.com/jmoiron/sqlx/reflectx"
 	"github.com/rakyll/statik/fs"
 
@@ -41,6 +42,12 @@ type canSetFeedVersion interface {
 	SetFeedVersionID(int)
 }
 
+// hasExtraFields is implemented by entities that save extra fields in an "extra" column.
+type hasExtraFields interface {
+	ExtraFields() tl.ExtraFields
+	SetExtraFields(tl.ExtraFields)
+}
+
 func getSchema(filename string) (string, error) {
 	statikFS, err := fs.New()
 	if err != nil {
@@ -75,6 +82,10 @@ func getFieldNameIndexes(ent interface{}) ([]string, []string) {
 		names = append(names, fi.Path)
 		wraps = append(wraps, w)
 	}
+	if _, ok := ent.(hasExtraFields); ok {
+		names = append(names, "extra")
+		wraps = append(wraps, "")
+	}
 	return names, wraps
 }
 
@@ -85,6 +96,10 @@ func getInsert(ent interface{}) ([]string, []interface{}, error) {
 	fm := mapper.FieldMap(val)
 	names, _ := getFieldNameIndexes(ent)
 	for _, name := range names {
+		if v, ok := ent.(hasExtraFields); ok && name == "extra" {
+			vals = append(vals, v.ExtraFields())
+			continue
+		}
 		v, ok := fm[name]
 		if !ok {
 			// This should not happen.
@@ -113,6 +128,18 @@ func getID(ent interface{}) (int, error) {
 	return 0, errors.New("no ID")
 }
 
+// getUpsertSuffix returns an ON CONFLICT clause for the unique key columns that updates the other columns, except created_at.
+func getUpsertSuffix(cols []string, keys []string) string {
+	sets := []string{}
+	for _, col := range cols {
+		if contains(col, keys) || col == "created_at" {
+			continue
+		}
+		sets = append(sets, fmt.Sprintf(`"%s" = excluded."%s"`, col, col))
+	}
+	return fmt.Sprintf("ON CONFLICT (%s) DO UPDATE SET %s", strings.Join(keys, ", "), strings.Join(sets, ", "))
+}
+
 func contains(a string, b []string) bool {
 	for _, v := range b {
 		if a == v {
@@ -1,6 +1,7 @@
 package tldb
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strconv"
@@ -13,6 +14,34 @@ import (
 type Writer struct {
 	FeedVersionID int
 	Adapter       Adapter
+	// Update existing entities with the same GTFS ID in the feed version, instead of inserting duplicates.
+	// Tables without a GTFS ID, such as gtfs_calendar_dates, are cleared for the feed version before the first write.
+	Upsert   bool
+	replaced map[string]bool
+	ctx      context.Context
+}
+
+// upsertKeys are the unique key columns used to update existing entities in each table, after feed_version_id.
+var upsertKeys = map[string][]string{
+	"gtfs_agencies":        {"agency_id"},
+	"gtfs_routes":          {"route_id"},
+	"gtfs_levels":          {"level_id"},
+	"gtfs_stops":           {"stop_id"},
+	"gtfs_pathways":        {"pathway_id"},
+	"gtfs_fare_attributes": {"fare_id"},
+	"gtfs_calendars":       {"service_id"},
+	"gtfs_shapes":          {"shape_id"},
+	"gtfs_trips":           {"trip_id"},
+	"gtfs_stop_times":      {"trip_id", "stop_sequence"},
+}
+
+// copyMinBatchSize is the smallest batch of entities with GTFS IDs that is written using COPY, when supported by the adapter.
+// Smaller batches use a multi-row insert that returns the new IDs.
+const copyMinBatchSize = 100
+
+// canCopyInsertWithIDs is implemented by adapters that can allocate IDs before writing entities using COPY.
+type canCopyInsertWithIDs interface {
+	CopyInsertWithIDs([]interface{}) ([]int, error)
 }
 
 // NewWriter returns a Writer appropriate for the given connection url.
@@ -20,6 +49,11 @@ func NewWriter(dburl string) (*Writer, error) {
 	return &Writer{Adapter: newAdapter(dburl)}, nil
 }
 
+// SetContext sets a context; when it is done, entities are no longer written.
+func (writer *Writer) SetContext(ctx context.Context) {
+	writer.ctx = ctx
+}
+
 // Open the database.
 func (writer *Writer) Open() error {
 	return writer.Adapter.Open()
@@ -56,6 +90,9 @@ func (writer *Writer) Delete() error {
 
 // AddEntity writes an entity to the database.
 func (writer *Writer) AddEntity(ent tl.Entity) (string, error) {
+	if writer.ctx != nil && writer.ctx.Err() != nil {
+		return "", writer.ctx.Err()
+	}
 	// Set the FeedVersionID
 	if z, ok := ent.(canSetFeedVersion); ok {
 		z.SetFeedVersionID(writer.FeedVersionID)
@@ -65,6 +102,13 @@ func (writer *Writer) AddEntity(ent tl.Entity) (string, error) {
 		v.UpdateTimestamps()
 	}
 	// Save
+	if writer.Upsert {
+		eids, err := writer.upsert([]tl.Entity{ent})
+		if err != nil {
+			return "", err
+		}
+		return eids[0], nil
+	}
 	eid, err := writer.Adapter.Insert(ent)
 	// Update ID
 	if v, ok := ent.(canSetID); ok {
@@ -75,6 +119,9 @@ func (writer *Writer) AddEntity(ent tl.Entity) (string, error) {
 
 // AddEntities writes entities to the database.
 func (writer *Writer) AddEntities(ents []tl.Entity) ([]string, error) {
+	if writer.ctx != nil && writer.ctx.Err() != nil {
+		return []string{}, writer.ctx.Err()
+	}
 	if len(ents) == 0 {
 		return []string{}, nil
 	}
@@ -93,6 +140,9 @@ func (writer *Writer) AddEntities(ents []tl.Entity) ([]string, error) {
 		}
 		ients[i] = ent
 	}
+	if writer.Upsert {
+		return writer.upsert(ents)
+	}
 	if useCopy {
 		if err := writer.Adapter.CopyInsert(ients); err != nil {
 			return eids, err
@@ -102,7 +152,13 @@ func (writer *Writer) AddEntities(ents []tl.Entity) ([]string, error) {
 		}
 		return eids, nil
 	}
-	retids, err := writer.Adapter.MultiInsert(ients)
+	var retids []int
+	var err error
+	if v, ok := writer.Adapter.(canCopyInsertWithIDs); ok && len(ents) >= copyMinBatchSize {
+		retids, err = v.CopyInsertWithIDs(ients)
+	} else {
+		retids, err = writer.Adapter.MultiInsert(ients)
+	}
 	if err != nil {
 		return eids, err
 	}
@@ -119,6 +175,45 @@ func (writer *Writer) AddEntities(ents []tl.Entity) ([]string, error) {
 	return eids, nil
 }
 
+// upsert writes entities using the upsertKeys for the table, or clears the table for the feed version on the first write if it has no key.
+func (writer *Writer) upsert(ents []tl.Entity) ([]string, error) {
+	eids := []string{}
+	ients := make([]interface{}, len(ents))
+	for i, ent := range ents {
+		ients[i] = ent
+	}
+	table := getTableName(ents[0])
+	var retids []int
+	var err error
+	if keys, ok := upsertKeys[table]; ok {
+		retids, err = writer.Adapter.MultiUpsert(ients, append([]string{"feed_version_id"}, keys...))
+	} else {
+		if _, ok := ents[0].(canSetFeedVersion); ok && !writer.replaced[table] {
+			if _, err := writer.Adapter.Sqrl().Delete(table).Where("feed_version_id = ?", writer.FeedVersionID).Exec(); err != nil {
+				return eids, err
+			}
+			if writer.replaced == nil {
+				writer.replaced = map[string]bool{}
+			}
+			writer.replaced[table] = true
+		}
+		retids, err = writer.Adapter.MultiInsert(ients)
+	}
+	if err != nil {
+		return eids, err
+	}
+	if len(retids) != len(ents) {
+		return []string{}, errors.New("failed to write expected entities")
+	}
+	for i, ent := range ents {
+		eids = append(eids, strconv.Itoa(retids[i]))
+		if v, ok := ent.(canSetID); ok {
+			v.SetID(retids[i])
+		}
+	}
+	return eids, nil
+}
+
 // CreateFeedVersion creates a new FeedVersion and inserts into the database.
 func (writer *Writer) CreateFeedVersion(reader tl.Reader) (int, error) {
 	if reader == nil {
@@ -1,10 +1,13 @@
 package tldb
 
 import (
+	"context"
 	"testing"
 
+	"github.com/interline-io/transitland-lib/copier"
 	"github.com/interline-io/transitland-lib/internal/testutil"
 	"github.com/interline-io/transitland-lib/tl"
+	"github.com/interline-io/transitland-lib/tlcsv"
 )
 
 // Writer interface tests.
@@ -16,3 +19,169 @@ func TestWriter(t *testing.T) {
 		})
 	}
 }
+
+// cancelWriter cancels the copy after the first batch of entities is written.
+type cancelWriter struct {
+	*Writer
+	cancel func()
+}
+
+func (w *cancelWriter) AddEntities(ents []tl.Entity) ([]string, error) {
+	eids, err := w.Writer.AddEntities(ents)
+	w.cancel()
+	return eids, err
+}
+
+func TestWriter_CopyContext(t *testing.T) {
+	for k, v := range testAdapters {
+		t.Run(k, func(t *testing.T) {
+			adapter := v()
+			if err := adapter.Open(); err != nil {
+				t.Fatal(err)
+			}
+			if err := adapter.Create(); err != nil {
+				t.Fatal(err)
+			}
+			fvid, err := createTestFeedVersion(adapter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			reader, err := tlcsv.NewReader(testutil.ExampleDir.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := reader.Open(); err != nil {
+				t.Fatal(err)
+			}
+			defer reader.Close()
+			// Cancel partway through the copy; the transaction is rolled back
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			err = adapter.Tx(func(atx Adapter) error {
+				writer := &cancelWriter{Writer: &Writer{Adapter: atx, FeedVersionID: fvid}, cancel: cancel}
+				cp := copier.NewCopier(reader, writer)
+				return cp.CopyContext(ctx).WriteError
+			})
+			if err != context.Canceled {
+				t.Errorf("got error '%v', expected '%v'", err, context.Canceled)
+			}
+			for _, table := range []string{"gtfs_agencies", "gtfs_stops", "gtfs_trips", "gtfs_stop_times"} {
+				count := 0
+				if err := adapter.Get(&count, "SELECT count(*) FROM "+table+" WHERE feed_version_id = ?", fvid); err != nil {
+					t.Fatal(err)
+				}
+				if count != 0 {
+					t.Errorf("got %d rows in %s, expected 0", count, table)
+				}
+			}
+		})
+	}
+}
+
+func TestWriter_Upsert(t *testing.T) {
+	for k, v := range testAdapters {
+		t.Run(k, func(t *testing.T) {
+			adapter := v()
+			if err := adapter.Open(); err != nil {
+				t.Fatal(err)
+			}
+			if err := adapter.Create(); err != nil {
+				t.Fatal(err)
+			}
+			fvid, err := createTestFeedVersion(adapter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Copy the same feed twice; generated shapes have the same IDs
+			stopids := [][]int{}
+			shapeCounts := []int{}
+			for i := 0; i < 2; i++ {
+				reader, err := tlcsv.NewReader(testutil.ExampleDir.URL)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := reader.Open(); err != nil {
+					t.Fatal(err)
+				}
+				writer := &Writer{Adapter: adapter, FeedVersionID: fvid, Upsert: true}
+				cp := copier.NewCopier(reader, writer)
+				cp.CreateMissingShapes = true
+				if result := cp.Copy(); result.WriteError != nil {
+					t.Fatal(result.WriteError)
+				}
+				reader.Close()
+				count := 0
+				if err := adapter.Get(&count, "SELECT count(*) FROM gtfs_shapes WHERE feed_version_id = ? AND generated = ?", fvid, true); err != nil {
+					t.Fatal(err)
+				}
+				shapeCounts = append(shapeCounts, count)
+				ids := []int{}
+				if err := adapter.Select(&ids, "SELECT id FROM gtfs_stops WHERE feed_version_id = ? ORDER BY id", fvid); err != nil {
+					t.Fatal(err)
+				}
+				stopids = append(stopids, ids)
+			}
+			if !testutil.CompareSliceInt(stopids[0], stopids[1]) {
+				t.Errorf("got stop ids %v, expected %v", stopids[1], stopids[0])
+			}
+			if shapeCounts[0] == 0 || shapeCounts[1] != shapeCounts[0] {
+				t.Errorf("got %v generated shapes, expected the same number after each copy", shapeCounts)
+			}
+			for table, fn := range map[string]string{"gtfs_stops": "stops.txt", "gtfs_trips": "trips.txt", "gtfs_stop_times": "stop_times.txt", "gtfs_calendar_dates": "calendar_dates.txt"} {
+				count := 0
+				if err := adapter.Get(&count, "SELECT count(*) FROM "+table+" WHERE feed_version_id = ?", fvid); err != nil {
+					t.Fatal(err)
+				}
+				if exp := testutil.ExampleDir.Counts[fn]; count != exp {
+					t.Errorf("got %d rows in %s, expected %d", count, table, exp)
+				}
+			}
+		})
+	}
+}
+
+func TestWriter_ExtraFields(t *testing.T) {
+	for k, v := range testAdapters {
+		t.Run(k, func(t *testing.T) {
+			adapter := v()
+			if err := adapter.Open(); err != nil {
+				t.Fatal(err)
+			}
+			if err := adapter.Create(); err != nil {
+				t.Fatal(err)
+			}
+			fvid, err := createTestFeedVersion(adapter)
+			if err != nil {
+				t.Fatal(err)
+			}
+			writer := &Writer{Adapter: adapter, FeedVersionID: fvid}
+			ent := tl.Level{LevelID: "level1", LevelName: "Level 1"}
+			ent.SetExtra("internal_code", "L1")
+			ent.SetExtra("level_color", "blue")
+			if _, err := writer.AddEntity(&ent); err != nil {
+				t.Fatal(err)
+			}
+			ent2 := tl.Level{LevelID: "level2", LevelName: "Level 2"}
+			if _, err := writer.AddEntity(&ent2); err != nil {
+				t.Fatal(err)
+			}
+			reader := &Reader{Adapter: adapter, FeedVersionIDs: []int{fvid}, PageSize: 1000}
+			got := []tl.Level{}
+			for ent := range reader.Levels() {
+				got = append(got, ent)
+			}
+			if len(got) != 2 {
+				t.Fatalf("got %d levels, expected 2", len(got))
+			}
+			if keys := got[0].ExtraKeys(); len(keys) != 2 || keys[0] != "internal_code" || keys[1] != "level_color" {
+				t.Errorf("got extra keys %v", keys)
+			}
+			if extra := got[0].Extra(); extra["internal_code"] != "L1" || extra["level_color"] != "blue" {
+				t.Errorf("got extra %v", extra)
+			}
+			if extra := got[1].Extra(); len(extra) != 0 {
+				t.Errorf("got extra %v, expected none", extra)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package validator
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/interline-io/transitland-lib/tl"
+)
+
+// HTMLReport contains the data used to render a static HTML validation report.
+type HTMLReport struct {
+	Result       *Result
+	Agencies     []tl.Agency
+	Routes       []tl.Route
+	ServiceWeeks []ServiceWeek
+	MaxSeconds   int
+}
+
+// ServiceWeek is the total scheduled service, in seconds, for each day of a week starting on Monday.
+type ServiceWeek struct {
+	StartDate time.Time
+	Days      [7]int
+}
+
+// NewHTMLReport reads agencies, routes, and service levels from the reader to supplement a Result.
+func NewHTMLReport(reader tl.Reader, result *Result) (*HTMLReport, error) {
+	report := HTMLReport{Result: result}
+	for ent := range reader.Agencies() {
+		report.Agencies = append(report.Agencies, ent)
+	}
+	for ent := range reader.Routes() {
+		report.Routes = append(report.Routes, ent)
+	}
+	sort.Slice(report.Routes, func(i, j int) bool {
+		a, b := report.Routes[i], report.Routes[j]
+		if a.AgencyID != b.AgencyID {
+			return a.AgencyID < b.AgencyID
+		}
+		if a.RouteShortName != b.RouteShortName {
+			return a.RouteShortName < b.RouteShortName
+		}
+		return a.RouteID < b.RouteID
+	})
+	fvsls, err := tl.NewFeedVersionServiceInfosFromReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	for _, fvsl := range fvsls {
+		// Only use the feed totals
+		if fvsl.RouteID.Valid {
+			continue
+		}
+		days := [7]int{fvsl.Monday, fvsl.Tuesday, fvsl.Wednesday, fvsl.Thursday, fvsl.Friday, fvsl.Saturday, fvsl.Sunday}
+		for d := fvsl.StartDate; !d.After(fvsl.EndDate); d = d.AddDate(0, 0, 7) {
+			report.ServiceWeeks = append(report.ServiceWeeks, ServiceWeek{StartDate: d, Days: days})
+		}
+		for _, v := range days {
+			if v > report.MaxSeconds {
+				report.MaxSeconds = v
+			}
+		}
+	}
+	sort.Slice(report.ServiceWeeks, func(i, j int) bool {
+		return report.ServiceWeeks[i].StartDate.Before(report.ServiceWeeks[j].StartDate)
+	})
+	return &report, nil
+}
+
+// WriteHTML writes the report as a single self-contained HTML page.
+func (r *HTMLReport) WriteHTML(w io.Writer) error {
+	return htmlReportTemplate.Execute(w, r)
+}
+
+var htmlReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"date": func(t time.Time) string {
+		return t.Format("2006-01-02")
+	},
+	"hours": func(seconds int) string {
+		return fmt.Sprintf("%.1f", float64(seconds)/3600.0)
+	},
+	"shade": func(seconds int, max int) string {
+		if max <= 0 {
+			return "0.00"
+		}
+		return fmt.Sprintf("%.2f", float64(seconds)/float64(max))
+	},
+}).Parse(htmlReportSource))
+
+const htmlReportSource = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Validation report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; color: #222; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+th, td { border: 1px solid #ccc; padding: 2px 6px; text-align: left; font-size: 0.9em; }
+th { background: #eee; }
+.success { color: #080; }
+.failure { color: #b00; }
+.service td.day { width: 3em; text-align: right; }
+details { margin-bottom: 0.5em; }
+summary { cursor: pointer; }
+</style>
+</head>
+<body>
+<h1>Validation report</h1>
+{{with .Result}}
+{{if .Success}}<p class="success">Validation passed.</p>{{else}}<p class="failure">Validation failed.{{if .FailureReason}} {{.FailureReason}}{{end}}</p>{{end}}
+<p>{{.ErrorCount}} errors, {{.WarningCount}} warnings.</p>
+
+<h2>Entities</h2>
+<table>
+<tr><th>File</th><th>Count</th></tr>
+{{range $fn, $count := .EntityCount}}<tr><td>{{$fn}}</td><td>{{$count}}</td></tr>
+{{end}}</table>
+{{end}}
+
+<h2>Service calendar</h2>
+{{if .ServiceWeeks}}
+<p>Hours of scheduled service for each day, by week.</p>
+<table class="service">
+<tr><th>Week of</th><th>Mon</th><th>Tue</th><th>Wed</th><th>Thu</th><th>Fri</th><th>Sat</th><th>Sun</th></tr>
+{{$max := .MaxSeconds}}{{range .ServiceWeeks}}<tr><td>{{date .StartDate}}</td>{{range .Days}}<td class="day" style="background: rgba(0, 102, 204, {{shade . $max}})">{{hours .}}</td>{{end}}</tr>
+{{end}}</table>
+{{else}}
+<p>No scheduled service.</p>
+{{end}}
+
+{{with .Result}}
+<h2>Errors</h2>
+{{template "groups" .Errors}}
+<h2>Warnings</h2>
+{{template "groups" .Warnings}}
+{{end}}
+
+<h2>Agencies</h2>
+<table>
+<tr><th>agency_id</th><th>agency_name</th><th>agency_url</th><th>agency_timezone</th></tr>
+{{range .Agencies}}<tr><td>{{.AgencyID}}</td><td>{{.AgencyName}}</td><td>{{.AgencyURL}}</td><td>{{.AgencyTimezone}}</td></tr>
+{{end}}</table>
+
+<h2>Routes</h2>
+<table>
+<tr><th>route_id</th><th>agency_id</th><th>route_short_name</th><th>route_long_name</th><th>route_type</th></tr>
+{{range .Routes}}<tr><td>{{.RouteID}}</td><td>{{.AgencyID}}</td><td>{{.RouteShortName}}</td><td>{{.RouteLongName}}</td><td>{{.RouteType}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+{{define "groups"}}{{if .}}{{range .}}<details>
+<summary>{{.Filename}}: {{.Code}} ({{.Count}})</summary>
+<table>
+<tr><th>line</th><th>entity_id</th><th>field</th><th>value</th><th>message</th></tr>
+{{range .Errors}}<tr><td>{{if .Line}}{{.Line}}{{end}}</td><td>{{.EntityID}}</td><td>{{.Field}}</td><td>{{.Value}}</td><td>{{.Message}}</td></tr>
+{{end}}</table>
+</details>
+{{end}}{{else}}<p>None.</p>
+{{end}}{{end}}`
@@ -0,0 +1,36 @@
+package validator
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestHTMLReport(t *testing.T) {
+	reader := exampleReader("../test/data/validator-examples", "../test/data/validator-examples/errors/routes-duplicate")
+	v, _ := NewValidator(reader)
+	result := v.ValidateResult()
+	report, err := NewHTMLReport(reader, result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Agencies) == 0 || len(report.Routes) == 0 {
+		t.Errorf("expected agencies and routes")
+	}
+	if len(report.ServiceWeeks) == 0 || report.MaxSeconds == 0 {
+		t.Errorf("expected service weeks")
+	}
+	buf := bytes.NewBuffer(nil)
+	if err := report.WriteHTML(buf); err != nil {
+		t.Fatal(err)
+	}
+	s := buf.String()
+	for _, expect := range []string{"routes.txt: DuplicateIDError (1)", report.Agencies[0].AgencyName} {
+		if !strings.Contains(s, expect) {
+			t.Errorf("did not find '%s' in output", expect)
+		}
+	}
+	if strings.Contains(s, "ZgotmplZ") {
+		t.Errorf("found unsafe template value in output")
+	}
+}
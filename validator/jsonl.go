@@ -0,0 +1,58 @@
+package validator
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/interline-io/transitland-lib/copier"
+	"github.com/interline-io/transitland-lib/tl"
+)
+
+// JSONLErrorHandler writes every error and warning as a line of JSON as they are found, then passes them to the Next ErrorHandler, if any.
+type JSONLErrorHandler struct {
+	Next copier.ErrorHandler
+	enc  *json.Encoder
+	err  error
+}
+
+// NewJSONLErrorHandler returns a new JSONLErrorHandler that writes to w.
+func NewJSONLErrorHandler(w io.Writer, next copier.ErrorHandler) *JSONLErrorHandler {
+	return &JSONLErrorHandler{Next: next, enc: json.NewEncoder(w)}
+}
+
+// HandleSourceErrors writes source errors and warnings.
+func (h *JSONLErrorHandler) HandleSourceErrors(fn string, errs []error, warns []error) {
+	h.write(errs, warns, func(err error) error { return copier.NewCopyError(fn, "", err) })
+	if h.Next != nil {
+		h.Next.HandleSourceErrors(fn, errs, warns)
+	}
+}
+
+// HandleEntityErrors writes entity errors and warnings.
+func (h *JSONLErrorHandler) HandleEntityErrors(ent tl.Entity, errs []error, warns []error) {
+	h.write(errs, warns, func(err error) error { return copier.NewEntityCopyError(ent, err) })
+	if h.Next != nil {
+		h.Next.HandleEntityErrors(ent, errs, warns)
+	}
+}
+
+// Err returns the first error encountered while writing, if any.
+func (h *JSONLErrorHandler) Err() error {
+	return h.err
+}
+
+func (h *JSONLErrorHandler) write(errs []error, warns []error, wrap func(error) error) {
+	for _, err := range errs {
+		h.encode(NewErrorDetail(SeverityError, wrap(err)))
+	}
+	for _, err := range warns {
+		h.encode(NewErrorDetail(SeverityWarning, wrap(err)))
+	}
+}
+
+func (h *JSONLErrorHandler) encode(ed ErrorDetail) {
+	if h.err != nil {
+		return
+	}
+	h.err = h.enc.Encode(ed)
+}
@@ -0,0 +1,39 @@
+package validator
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/interline-io/transitland-lib/copier"
+)
+
+func TestJSONLErrorHandler(t *testing.T) {
+	reader := exampleReader("../test/data/validator-examples", "../test/data/validator-examples/errors/routes-duplicate")
+	v, _ := NewValidator(reader)
+	buf := bytes.NewBuffer(nil)
+	cpresult := copier.NewCopyResult()
+	v.Copier.ErrorHandler = NewJSONLErrorHandler(buf, cpresult)
+	v.Copier.Copy()
+	count := 0
+	found := false
+	scanner := bufio.NewScanner(buf)
+	for scanner.Scan() {
+		ed := ErrorDetail{}
+		if err := json.Unmarshal(scanner.Bytes(), &ed); err != nil {
+			t.Fatal(err)
+		}
+		if ed.Code == "DuplicateIDError" && ed.Filename == "routes.txt" && ed.EntityID == "03" {
+			found = true
+		}
+		count++
+	}
+	if !found {
+		t.Errorf("did not find DuplicateIDError for routes.txt")
+	}
+	// The next ErrorHandler should receive the same errors
+	if expect := len(cpresult.Errors) + len(cpresult.Warnings); count != expect {
+		t.Errorf("got %d lines, expected %d", count, expect)
+	}
+}
@@ -0,0 +1,139 @@
+package validator
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+
+	"github.com/interline-io/transitland-lib/copier"
+	"github.com/interline-io/transitland-lib/tl/causes"
+)
+
+// Severity levels for errors in a Result.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// DefaultErrorLimit is the default number of example errors kept for each error group by the validate command.
+const DefaultErrorLimit = 1000
+
+// Result is a machine-readable summary of a validation run.
+type Result struct {
+	Success       bool           `json:"success"`
+	FailureReason string         `json:"failure_reason"`
+	ErrorCount    int            `json:"error_count"`
+	WarningCount  int            `json:"warning_count"`
+	EntityCount   map[string]int `json:"entity_count"`
+	Errors        []ErrorGroup   `json:"errors"`
+	Warnings      []ErrorGroup   `json:"warnings"`
+}
+
+// ErrorGroup collects errors of the same type from the same file.
+type ErrorGroup struct {
+	Code     string        `json:"code"`
+	Severity string        `json:"severity"`
+	Filename string        `json:"filename"`
+	Count    int           `json:"count"`
+	Errors   []ErrorDetail `json:"errors"`
+}
+
+// ErrorDetail describes a single error or warning.
+type ErrorDetail struct {
+	Code     string `json:"code"`
+	Severity string `json:"severity"`
+	Filename string `json:"filename"`
+	Line     int    `json:"line"`
+	EntityID string `json:"entity_id"`
+	Field    string `json:"field"`
+	Value    string `json:"value"`
+	Message  string `json:"message"`
+}
+
+// NewResult creates a Result from a CopyResult, keeping at most errorLimit example errors for each group.
+// An errorLimit less than 0 keeps all errors. Group counts include errors not kept by the CopyResult.
+func NewResult(cpresult *copier.CopyResult, errorLimit int) *Result {
+	result := Result{
+		EntityCount: map[string]int{},
+	}
+	for k, v := range cpresult.EntityCount {
+		result.EntityCount[k] = v
+	}
+	result.Errors = groupErrors(SeverityError, cpresult.Errors, cpresult.ErrorCount, errorLimit)
+	result.Warnings = groupErrors(SeverityWarning, cpresult.Warnings, cpresult.WarningCount, errorLimit)
+	for _, g := range result.Errors {
+		result.ErrorCount += g.Count
+	}
+	for _, g := range result.Warnings {
+		result.WarningCount += g.Count
+	}
+	if cpresult.WriteError != nil {
+		result.FailureReason = cpresult.WriteError.Error()
+	}
+	result.Success = cpresult.WriteError == nil && result.ErrorCount == 0
+	return &result
+}
+
+// WriteJSON writes the Result as indented JSON.
+func (r *Result) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// NewErrorDetail flattens the context of an error and its causes into an ErrorDetail; see causes.ErrorContext.
+func NewErrorDetail(severity string, err error) ErrorDetail {
+	ctx := causes.ErrorContext(err)
+	return ErrorDetail{
+		Code:     causes.ErrorCode(err),
+		Severity: severity,
+		Filename: ctx.Filename,
+		Line:     ctx.Line,
+		EntityID: ctx.EntityID,
+		Field:    ctx.Field,
+		Value:    ctx.Value,
+		Message:  ctx.Message,
+	}
+}
+
+func groupErrors(severity string, errs []error, counts map[copier.ErrorKey]int, errorLimit int) []ErrorGroup {
+	groups := map[copier.ErrorKey]*ErrorGroup{}
+	getGroup := func(key copier.ErrorKey) *ErrorGroup {
+		g, ok := groups[key]
+		if !ok {
+			g = &ErrorGroup{
+				Code:     key.Code,
+				Severity: severity,
+				Filename: key.Filename,
+				Errors:   []ErrorDetail{},
+			}
+			groups[key] = g
+		}
+		return g
+	}
+	for _, err := range errs {
+		ed := NewErrorDetail(severity, err)
+		g := getGroup(copier.ErrorKey{Filename: ed.Filename, Code: ed.Code})
+		g.Count++
+		if errorLimit < 0 || len(g.Errors) < errorLimit {
+			g.Errors = append(g.Errors, ed)
+		}
+	}
+	// Use the total counts, which may be more than the number of errors kept
+	for key, count := range counts {
+		if g := getGroup(key); count > g.Count {
+			g.Count = count
+		}
+	}
+	ret := []ErrorGroup{}
+	for _, g := range groups {
+		ret = append(ret, *g)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Filename == ret[j].Filename {
+			return ret[i].Code < ret[j].Code
+		}
+		return ret[i].Filename < ret[j].Filename
+	})
+	return ret
+}
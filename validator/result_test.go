@@ -0,0 +1,126 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/interline-io/transitland-lib/copier"
+	"github.com/interline-io/transitland-lib/tl/causes"
+	"github.com/interline-io/transitland-lib/tlcsv"
+)
+
+func TestValidator_ValidateResult(t *testing.T) {
+	reader := exampleReader("../test/data/validator-examples", "../test/data/validator-examples/errors/routes-duplicate")
+	v, _ := NewValidator(reader)
+	result := v.ValidateResult()
+	if result.Success {
+		t.Errorf("expected success = false")
+	}
+	found := false
+	for _, g := range result.Errors {
+		if g.Filename == "routes.txt" && g.Code == "DuplicateIDError" {
+			found = true
+			if g.Count != 1 || len(g.Errors) != 1 {
+				t.Errorf("expected 1 error, got count %d with %d examples", g.Count, len(g.Errors))
+				continue
+			}
+			if g.Errors[0].EntityID != "03" {
+				t.Errorf("got entity_id '%s', expected '03'", g.Errors[0].EntityID)
+			}
+			if g.Errors[0].Line != 3 {
+				t.Errorf("got line %d, expected 3", g.Errors[0].Line)
+			}
+			if g.Errors[0].Severity != SeverityError {
+				t.Errorf("got severity '%s', expected '%s'", g.Errors[0].Severity, SeverityError)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("did not find DuplicateIDError for routes.txt")
+	}
+	// Check the JSON output can be read back.
+	buf := bytes.NewBuffer(nil)
+	if err := result.WriteJSON(buf); err != nil {
+		t.Fatal(err)
+	}
+	check := Result{}
+	if err := json.Unmarshal(buf.Bytes(), &check); err != nil {
+		t.Fatal(err)
+	}
+	if check.ErrorCount != result.ErrorCount {
+		t.Errorf("got %d errors, expected %d", check.ErrorCount, result.ErrorCount)
+	}
+}
+
+func TestNewResult(t *testing.T) {
+	cpresult := copier.NewCopyResult()
+	for i := 0; i < 5; i++ {
+		cpresult.Errors = append(cpresult.Errors, copier.NewCopyError("stops.txt", "a", causes.NewRequiredFieldError("stop_name")))
+	}
+	cpresult.Errors = append(cpresult.Errors, copier.NewCopyError("stops.txt", "b", causes.NewInvalidFieldError("stop_lat", "100", errors.New("out of bounds"))))
+	cpresult.Warnings = append(cpresult.Warnings, copier.NewCopyError("trips.txt", "c", errors.New("test")))
+	result := NewResult(cpresult, 2)
+	if result.ErrorCount != 6 {
+		t.Errorf("got %d errors, expected 6", result.ErrorCount)
+	}
+	if len(result.Errors) != 2 {
+		t.Fatalf("got %d error groups, expected 2", len(result.Errors))
+	}
+	g := result.Errors[1]
+	if g.Code != "RequiredFieldError" || g.Count != 5 || len(g.Errors) != 2 {
+		t.Errorf("got code %s count %d examples %d, expected RequiredFieldError count 5 examples 2", g.Code, g.Count, len(g.Errors))
+	}
+	ed := result.Errors[0].Errors[0]
+	exp := ErrorDetail{Code: "InvalidFieldError", Severity: SeverityError, Filename: "stops.txt", EntityID: "b", Field: "stop_lat", Value: "100", Message: "invalid value for field stop_lat: '100', reason: out of bounds"}
+	if ed != exp {
+		t.Errorf("got %#v, expected %#v", ed, exp)
+	}
+	if len(result.Warnings) != 1 || result.Warnings[0].Code != "UnknownError" {
+		t.Errorf("expected a single UnknownError warning group")
+	}
+}
+
+func TestNewResult_Counts(t *testing.T) {
+	// Counts from a bounded CopyResult include errors that were not kept
+	cpresult := copier.NewCopyResult()
+	cpresult.ErrorLimit = 1
+	for i := 0; i < 3; i++ {
+		cpresult.HandleSourceErrors("stops.txt", []error{causes.NewRequiredFieldError("stop_name")}, nil)
+	}
+	result := NewResult(cpresult, 10)
+	if result.ErrorCount != 3 {
+		t.Errorf("got %d errors, expected 3", result.ErrorCount)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Count != 3 || len(result.Errors[0].Errors) != 1 {
+		t.Errorf("expected a single group with count 3 and 1 example")
+	}
+}
+
+func TestValidator_ErrorLimit(t *testing.T) {
+	countExamples := func(errorLimit int) (int, int) {
+		reader, err := tlcsv.NewReader("../test/data/bad-entities")
+		if err != nil {
+			t.Fatal(err)
+		}
+		v, _ := NewValidator(reader)
+		v.ErrorLimit = errorLimit
+		result := v.ValidateResult()
+		count, examples := 0, 0
+		for _, g := range append(result.Errors, result.Warnings...) {
+			count += g.Count
+			examples += len(g.Errors)
+		}
+		return count, examples
+	}
+	// No limit by default
+	count, examples := countExamples(0)
+	if count == 0 || examples != count {
+		t.Errorf("got %d examples for %d errors, expected all errors", examples, count)
+	}
+	count, examples = countExamples(1)
+	if examples >= count {
+		t.Errorf("got %d examples for %d errors, expected fewer examples", examples, count)
+	}
+}
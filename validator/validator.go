@@ -8,8 +8,9 @@ import (
 
 // Validator checks a GTFS source for errors and warnings.
 type Validator struct {
-	Reader tl.Reader
-	Copier *copier.Copier
+	Reader     tl.Reader
+	Copier     *copier.Copier
+	ErrorLimit int // maximum number of example errors of each type; 0 for no limit
 }
 
 // NewValidator returns a new Validator.
@@ -25,13 +26,31 @@ func NewValidator(reader tl.Reader) (*Validator, error) {
 }
 
 // Validate checks the feed and returns any errors and warnings that are found.
+// If ErrorLimit is set, at most ErrorLimit errors and warnings of each type are returned for each file.
 func (v *Validator) Validate() ([]error, []error) {
+	v.Copier.ErrorLimit = v.errorLimit()
 	result := v.Copier.Copy()
 	result.DisplayErrors()
 	result.DisplaySummary()
 	return result.Errors, result.Warnings
 }
 
+// ValidateResult checks the feed and returns a Result with grouped errors and warnings.
+func (v *Validator) ValidateResult() *Result {
+	v.Copier.ErrorLimit = v.errorLimit()
+	cpresult := v.Copier.Copy()
+	cpresult.DisplaySummary()
+	return NewResult(cpresult, v.errorLimit())
+}
+
+// errorLimit returns ErrorLimit using the copier convention, where -1 is no limit.
+func (v *Validator) errorLimit() int {
+	if v.ErrorLimit <= 0 {
+		return -1
+	}
+	return v.ErrorLimit
+}
+
 type errorWithContext interface {
 	Context() *causes.Context
 }